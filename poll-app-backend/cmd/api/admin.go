@@ -0,0 +1,67 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/user"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+// grantAdmin gives the account with email the admin role. It is how the
+// first admin of a database is made; after that, admins promote each other
+// through the API.
+func grantAdmin(ctx context.Context, client *ent.Client, email string) (*ent.User, error) {
+	u, err := client.User.Query().
+		Where(user.EmailEqualFold(strings.TrimSpace(email))).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("no account with email %s; register it first", email)
+		}
+		return nil, err
+	}
+	if u.Role == roleAdmin {
+		return u, nil
+	}
+	return u.Update().SetRole(roleAdmin).Save(ctx)
+}
+
+// runGrantAdminCommand implements "api grant-admin", which gives an
+// existing account the admin role from the server's shell
+func runGrantAdminCommand(args []string) {
+	fs := flag.NewFlagSet("grant-admin", flag.ExitOnError)
+	dsn := fs.String("dsn", envDSN(), "PostgreSQL connection string (defaults to $POLLS_DSN)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: api grant-admin [-dsn DSN] EMAIL")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	if err := requireSchemaCurrent(ctx, *dsn); err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := ent.Open(dialect.Postgres, *dsn)
+	if err != nil {
+		log.Fatalf("failed opening connection to postgres: %v", err)
+	}
+	defer client.Close()
+
+	u, err := grantAdmin(ctx, client, fs.Arg(0))
+	if err != nil {
+		log.Fatalf("failed granting admin: %v", err)
+	}
+	fmt.Printf("%s is an admin\n", u.Email)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"backend/ent"
+	"context"
+)
+
+// Audited actions
+const (
+	auditPollUpdated     = "poll.updated"
+	auditPollClosed      = "poll.closed"
+	auditPollReopened    = "poll.reopened"
+	auditPollDeleted     = "poll.deleted"
+	auditUserRoleChanged = "user.role_changed"
+	auditUserDisabled    = "user.disabled"
+	auditUserEnabled     = "user.enabled"
+)
+
+// Kinds of record an audit entry can point at
+const (
+	auditTargetPoll = "poll"
+	auditTargetUser = "user"
+)
+
+// recordAudit adds an entry to the audit log. Pass tx.Client() to record it
+// in the same transaction as the change itself.
+func recordAudit(ctx context.Context, client *ent.Client, actor *ent.User, action, targetType string, targetID int, details map[string]any) error {
+	create := client.AuditLog.Create().
+		SetAction(action).
+		SetActorEmail(actor.Email).
+		SetActorID(actor.ID).
+		SetTargetType(targetType).
+		SetTargetID(targetID)
+	if details != nil {
+		create.SetDetails(details)
+	}
+	return create.Exec(ctx)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptCost is the work factor used for new password hashes
+const bcryptCost = 12
+
+// dummyPasswordHash is compared against when no user matches the email,
+// so a failed lookup takes as long as a failed password check
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("not-a-real-password"), bcryptCost)
+
+// hashPassword returns the bcrypt hash of a plaintext password
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// isPasswordHash reports whether a stored password is already a bcrypt hash.
+// Rows created before hashing was introduced hold the plaintext value.
+func isPasswordHash(stored string) bool {
+	_, err := bcrypt.Cost([]byte(stored))
+	return err == nil && strings.HasPrefix(stored, "$2")
+}
+
+// checkPassword compares a plaintext password with the stored value.
+// needsRehash is true when the stored value is a legacy plaintext password
+// (or a hash with an outdated cost) that should be replaced after a successful login.
+func checkPassword(stored, password string) (ok bool, needsRehash bool) {
+	if !isPasswordHash(stored) {
+		// Legacy plaintext row - still compare in constant time
+		match := subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+		return match, match
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)); err != nil {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(stored))
+	return true, err == nil && cost < bcryptCost
+}
+
+// burnPasswordCheck runs a bcrypt comparison that always fails so that
+// unknown emails cannot be told apart from wrong passwords by timing
+func burnPasswordCheck(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+}
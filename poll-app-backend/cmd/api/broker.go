@@ -0,0 +1,161 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"context"
+	"log"
+	"sync"
+)
+
+// resultUpdate is a snapshot of a poll's vote counts, pushed to everyone
+// watching the poll's results stream. Each update replaces the previous
+// one completely, so a subscriber that misses some only needs the latest.
+type resultUpdate struct {
+	PollID     int        `json:"poll_id"`
+	TotalVotes int        `json:"total_votes"`
+	Options    []irvTally `json:"options"`
+}
+
+// resultsBroker fans result updates for a poll out to its subscribers.
+// memoryBroker delivers within this process only. A multi-instance
+// deployment can swap in a broker whose Publish sends a PostgreSQL NOTIFY
+// and whose LISTEN loop hands received updates to an embedded memoryBroker.
+// Handlers never need to know which one is in use.
+type resultsBroker interface {
+	Subscribe(pollID int) *resultSubscription
+	Unsubscribe(sub *resultSubscription)
+	Publish(update resultUpdate)
+	Close()
+}
+
+// resultSubscription receives the updates for one poll. Its channel holds at
+// most one pending update: if the subscriber hasn't read the last one by the
+// time a new one arrives, the stale one is dropped, so a slow client never
+// holds up publishers or builds an unbounded backlog.
+type resultSubscription struct {
+	pollID  int
+	updates chan resultUpdate
+}
+
+// Updates returns the channel new snapshots arrive on. It is closed when the
+// subscription is removed.
+func (s *resultSubscription) Updates() <-chan resultUpdate {
+	return s.updates
+}
+
+// memoryBroker is the in-process resultsBroker
+type memoryBroker struct {
+	mu     sync.Mutex
+	subs   map[int]map[*resultSubscription]struct{}
+	closed bool
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{subs: make(map[int]map[*resultSubscription]struct{})}
+}
+
+func (b *memoryBroker) Subscribe(pollID int) *resultSubscription {
+	sub := &resultSubscription{
+		pollID:  pollID,
+		updates: make(chan resultUpdate, 1),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.updates)
+		return sub
+	}
+	if b.subs[pollID] == nil {
+		b.subs[pollID] = make(map[*resultSubscription]struct{})
+	}
+	b.subs[pollID][sub] = struct{}{}
+	return sub
+}
+
+func (b *memoryBroker) Unsubscribe(sub *resultSubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs, ok := b.subs[sub.pollID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subs, sub.pollID)
+	}
+	close(sub.updates)
+}
+
+// Publish never blocks: each subscriber's pending update, if any, is
+// replaced by this one. Sends happen under the lock, which is what keeps
+// them from racing Unsubscribe closing the channel.
+func (b *memoryBroker) Publish(update resultUpdate) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs[update.PollID] {
+		select {
+		case <-sub.updates:
+		default:
+		}
+		sub.updates <- update
+	}
+}
+
+// Close ends every subscription, so open results streams finish and the
+// server can shut down. Subscriptions made afterwards start out closed.
+func (b *memoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for pollID, subs := range b.subs {
+		for sub := range subs {
+			close(sub.updates)
+		}
+		delete(b.subs, pollID)
+	}
+}
+
+// resultSnapshot loads the current vote counts of a poll
+func (app *application) resultSnapshot(ctx context.Context, pollID int) (resultUpdate, error) {
+	pollData, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Only(ctx)
+	if err != nil {
+		return resultUpdate{}, err
+	}
+
+	update := resultUpdate{
+		PollID:     pollData.ID,
+		TotalVotes: pollData.TotalVotes,
+		Options:    make([]irvTally, 0, len(pollData.Edges.Options)),
+	}
+	for _, option := range pollData.Edges.Options {
+		update.Options = append(update.Options, irvTally{
+			OptionID:   option.ID,
+			OptionText: option.OptionText,
+			Votes:      option.VoteCount,
+		})
+	}
+	return update, nil
+}
+
+// publishResults pushes a poll's fresh counts to its results streams. It runs
+// after the vote transaction has committed; failing to load the snapshot
+// only costs watchers one update, so it is logged rather than returned.
+func (app *application) publishResults(ctx context.Context, pollID int) {
+	update, err := app.resultSnapshot(ctx, pollID)
+	if err != nil {
+		log.Printf("Warning: failed to publish results for poll %d: %v", pollID, err)
+		return
+	}
+	app.Results.Publish(update)
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configEnvPrefix starts the name of every environment variable the server
+// reads its settings from: -db-max-open-conns is POLLS_DB_MAX_OPEN_CONNS
+const configEnvPrefix = "POLLS_"
+
+// config is everything the server can be configured with. Each setting is
+// a flag, an environment variable and a key of the optional YAML config
+// file; see loadConfig for how they combine.
+type config struct {
+	ConfigFile     string
+	Addr           string
+	Domain         string
+	DSN            string
+	TokenSecret    string
+	AllowedOrigins []string
+
+	DB struct {
+		MaxOpenConns    int
+		MaxIdleConns    int
+		ConnMaxLifetime time.Duration
+		ConnMaxIdleTime time.Duration
+	}
+
+	HTTP struct {
+		ReadHeaderTimeout time.Duration
+		ReadTimeout       time.Duration
+		WriteTimeout      time.Duration
+		IdleTimeout       time.Duration
+		MaxHeaderBytes    int
+		ShutdownDelay     time.Duration
+		ShutdownTimeout   time.Duration
+	}
+
+	StartupTimeout    time.Duration
+	SchedulerInterval time.Duration
+
+	// WebhookAllowPrivate lets webhooks point at loopback and private
+	// addresses, which is only safe where every user is trusted
+	WebhookAllowPrivate bool
+
+	Features struct {
+		Seed            bool
+		Scheduler       bool
+		WebhookDelivery bool
+		Metrics         bool
+	}
+}
+
+// stringList is a flag holding a comma-separated list. Setting it replaces
+// the list rather than adding to it, so a later source overrides an
+// earlier one.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = nil
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// configFlags registers a flag for every setting, with the defaults, on fs
+func configFlags(fs *flag.FlagSet, cfg *config) {
+	fs.StringVar(&cfg.ConfigFile, "config", "", "YAML file to read settings from")
+	fs.StringVar(&cfg.Addr, "addr", ":8080", "Address to listen on, host:port")
+	fs.StringVar(&cfg.Domain, "domain", "example.com", "Public domain name of the API")
+	fs.StringVar(&cfg.DSN, "dsn", defaultDSN, "PostgreSQL connection string")
+	fs.StringVar(&cfg.TokenSecret, "token-secret", "", "Secret used to sign session tokens (also read from $TOKEN_SECRET)")
+	cfg.AllowedOrigins = []string{"http://localhost:3000"}
+	fs.Var((*stringList)(&cfg.AllowedOrigins), "allowed-origins", "Comma-separated origins browsers may call the API from, or * for any")
+
+	fs.IntVar(&cfg.DB.MaxOpenConns, "db-max-open-conns", 25, "Most open database connections (0 for no limit)")
+	fs.IntVar(&cfg.DB.MaxIdleConns, "db-max-idle-conns", 25, "Most idle database connections kept for reuse")
+	fs.DurationVar(&cfg.DB.ConnMaxLifetime, "db-conn-max-lifetime", time.Hour, "Longest a database connection is reused (0 for no limit)")
+	fs.DurationVar(&cfg.DB.ConnMaxIdleTime, "db-conn-max-idle-time", 15*time.Minute, "Longest a database connection stays idle (0 for no limit)")
+
+	fs.DurationVar(&cfg.HTTP.ReadHeaderTimeout, "http-read-header-timeout", 5*time.Second, "Time allowed to read a request's headers")
+	fs.DurationVar(&cfg.HTTP.ReadTimeout, "http-read-timeout", 30*time.Second, "Time allowed to read a whole request (0 for no limit)")
+	fs.DurationVar(&cfg.HTTP.WriteTimeout, "http-write-timeout", 0, "Time allowed to write a response (0 for no limit; result streams set their own)")
+	fs.DurationVar(&cfg.HTTP.IdleTimeout, "http-idle-timeout", 2*time.Minute, "Longest an idle keep-alive connection stays open")
+	fs.IntVar(&cfg.HTTP.MaxHeaderBytes, "http-max-header-bytes", 64<<10, "Largest request header block accepted, in bytes")
+	fs.DurationVar(&cfg.HTTP.ShutdownDelay, "http-shutdown-delay", 5*time.Second, "Time between failing readiness and draining on shutdown, so load balancers stop sending requests")
+	fs.DurationVar(&cfg.HTTP.ShutdownTimeout, "http-shutdown-timeout", 30*time.Second, "Time allowed for in-flight requests to finish on shutdown")
+
+	fs.DurationVar(&cfg.StartupTimeout, "startup-timeout", 30*time.Second, "Time allowed for the schema check and seeding at startup")
+	fs.DurationVar(&cfg.SchedulerInterval, "scheduler-interval", schedulerInterval, "How often scheduled polls are opened, closed and recurred")
+	fs.BoolVar(&cfg.WebhookAllowPrivate, "webhook-allow-private", false, "Allow webhooks to loopback and private network addresses (development only)")
+
+	fs.BoolVar(&cfg.Features.Seed, "seed", true, "Seed an empty database with sample users and polls")
+	fs.BoolVar(&cfg.Features.Scheduler, "scheduler", true, "Run the poll scheduler in this instance")
+	fs.BoolVar(&cfg.Features.WebhookDelivery, "webhook-delivery", true, "Deliver queued webhooks from this instance")
+	fs.BoolVar(&cfg.Features.Metrics, "metrics", true, "Serve Prometheus metrics on /metrics")
+}
+
+// configEnvName is the environment variable for a flag
+func configEnvName(flagName string) string {
+	return configEnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// envDSN is the -dsn default for subcommands, which share the server's
+// $POLLS_DSN but read no other settings
+func envDSN() string {
+	if dsn := os.Getenv(configEnvName("dsn")); dsn != "" {
+		return dsn
+	}
+	return defaultDSN
+}
+
+// loadConfig builds the configuration from, lowest precedence first: the
+// defaults, the config file named by -config or $POLLS_CONFIG, environment
+// variables, and command-line flags. Every source goes through the flags'
+// parsers, so a value is spelled the same way wherever it comes from.
+func loadConfig(args []string) (*config, error) {
+	cfg := &config{}
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	configFlags(fs, cfg)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	// Flags are applied again last, so note them before anything else
+	// overwrites their values
+	explicit := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = f.Value.String()
+	})
+
+	if _, ok := explicit["config"]; !ok {
+		cfg.ConfigFile = os.Getenv(configEnvName("config"))
+	}
+	if cfg.ConfigFile != "" {
+		if err := applyConfigFile(fs, cfg.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if v, ok := os.LookupEnv("TOKEN_SECRET"); ok {
+		cfg.TokenSecret = v
+	}
+	var errs []error
+	fs.VisitAll(func(f *flag.Flag) {
+		name := configEnvName(f.Name)
+		if v, ok := os.LookupEnv(name); ok && f.Name != "config" {
+			if err := fs.Set(f.Name, v); err != nil {
+				errs = append(errs, fmt.Errorf("$%s: %w", name, err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	for name, v := range explicit {
+		if err := fs.Set(name, v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyConfigFile sets the flags named in a YAML file. Keys are flag names,
+// and may be grouped under their first word:
+//
+//	addr: ":8080"
+//	allowed-origins: [https://polls.example.com]
+//	db:
+//	  max-open-conns: 50
+//	http:
+//	  read-timeout: 10s
+//
+// Underscores may stand in for hyphens. Unknown keys are an error, so a
+// typo doesn't silently leave a default in place.
+func applyConfigFile(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	var errs []error
+	var apply func(prefix string, values map[string]any)
+	apply = func(prefix string, values map[string]any) {
+		for key, value := range values {
+			name := prefix + strings.ReplaceAll(strings.ToLower(key), "_", "-")
+			if group, ok := value.(map[string]any); ok {
+				apply(name+"-", group)
+				continue
+			}
+			if fs.Lookup(name) == nil || name == "config" {
+				errs = append(errs, fmt.Errorf("unknown setting %q", name))
+				continue
+			}
+
+			var raw string
+			switch v := value.(type) {
+			case []any:
+				items := make([]string, len(v))
+				for i, item := range v {
+					items[i] = fmt.Sprint(item)
+				}
+				raw = strings.Join(items, ",")
+			case nil:
+				raw = ""
+			default:
+				raw = fmt.Sprint(v)
+			}
+			if err := fs.Set(name, raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+	apply("", doc)
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// validate reports every setting that can't work, so a bad deploy fails at
+// startup with the whole list rather than one problem at a time
+func (cfg *config) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, port, err := net.SplitHostPort(cfg.Addr); err != nil || port == "" {
+		errs = append(errs, fmt.Errorf("addr %q must be host:port, like :8080", cfg.Addr))
+	}
+	check(strings.TrimSpace(cfg.Domain) != "", "domain can't be empty")
+	check(strings.TrimSpace(cfg.DSN) != "", "dsn can't be empty")
+
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		u, err := url.Parse(origin)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" &&
+			(u.Path == "" || u.Path == "/") && u.RawQuery == "",
+			"allowed origin %q must be a scheme and host, like https://polls.example.com", origin)
+	}
+
+	check(cfg.DB.MaxOpenConns >= 0, "db-max-open-conns can't be negative")
+	check(cfg.DB.MaxIdleConns >= 0, "db-max-idle-conns can't be negative")
+	check(cfg.DB.MaxOpenConns == 0 || cfg.DB.MaxIdleConns <= cfg.DB.MaxOpenConns,
+		"db-max-idle-conns (%d) can't exceed db-max-open-conns (%d)", cfg.DB.MaxIdleConns, cfg.DB.MaxOpenConns)
+	check(cfg.DB.ConnMaxLifetime >= 0, "db-conn-max-lifetime can't be negative")
+	check(cfg.DB.ConnMaxIdleTime >= 0, "db-conn-max-idle-time can't be negative")
+
+	check(cfg.HTTP.ReadHeaderTimeout > 0, "http-read-header-timeout must be positive")
+	check(cfg.HTTP.ReadTimeout >= 0, "http-read-timeout can't be negative")
+	check(cfg.HTTP.WriteTimeout >= 0, "http-write-timeout can't be negative")
+	check(cfg.HTTP.IdleTimeout >= 0, "http-idle-timeout can't be negative")
+	check(cfg.HTTP.MaxHeaderBytes >= 4<<10, "http-max-header-bytes must be at least 4096")
+	check(cfg.HTTP.ShutdownDelay >= 0, "http-shutdown-delay can't be negative")
+	check(cfg.HTTP.ShutdownTimeout > 0, "http-shutdown-timeout must be positive")
+
+	check(cfg.StartupTimeout > 0, "startup-timeout must be positive")
+	check(cfg.SchedulerInterval >= time.Second, "scheduler-interval must be at least 1s")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// allowsOrigin reports whether browsers on origin may call the API
+func (cfg *config) allowsOrigin(origin string) bool {
+	return origin != "" && (slices.Contains(cfg.AllowedOrigins, "*") || slices.Contains(cfg.AllowedOrigins, origin))
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed five-field cron expression ("minute hour
+// day-of-month month day-of-week") read in a time zone. Fields take *,
+// single values, a-b ranges, comma lists and /n steps; months and weekdays
+// may also be written as three-letter names, and Sunday is 0 or 7. As in
+// cron, when both day fields are restricted a day matching either one
+// counts; a day field starting with * isn't restricted. @hourly, @daily,
+// @weekly and @monthly are accepted as shorthands.
+//
+// Across daylight saving changes, times that don't exist because clocks
+// went forward are skipped, and a time that happens twice because clocks
+// went back counts once, unless the schedule runs every hour.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+	loc                           *time.Location
+}
+
+// cronMacros are the shorthands parseCronSchedule expands
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// cronField describes the values one field of an expression may hold
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDOM    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12,
+		names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronDOW = cronField{name: "day of week", min: 0, max: 7,
+		names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// cronEveryHour is the hour field of a schedule that runs every hour
+const cronEveryHour = 1<<24 - 1
+
+// cronSearchLimit bounds how far ahead next looks, so an expression naming
+// a day that never comes (the 31st of February) can't loop forever
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// parseCronSchedule parses spec, read in the IANA time zone tz (UTC when
+// empty)
+func parseCronSchedule(spec, tz string) (*cronSchedule, error) {
+	loc := time.UTC
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", tz)
+		}
+	}
+
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if expanded, ok := cronMacros[spec]; ok {
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.New("schedule must have five fields: minute hour day-of-month month day-of-week")
+	}
+
+	s := &cronSchedule{loc: loc}
+	var err error
+	if s.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hour, err = cronHour.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if s.dom, err = cronDOM.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if s.month, err = cronMonth.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.dow, err = cronDOW.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// 7 is another name for Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domAny = strings.HasPrefix(fields[2], "*")
+	s.dowAny = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parse turns one field into a bit set of the values it matches
+func (f cronField) parse(expr string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepExpr, f.name)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		if rangeExpr != "*" {
+			loExpr, hiExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if lo, err = f.value(loExpr); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if hi, err = f.value(hiExpr); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				// A plain value; "5/15" runs from 5 to the end
+				hi = lo
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("range %q in %s field runs backwards", rangeExpr, f.name)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// value parses a single number or name of the field
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if s == name {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s must be between %d and %d, not %q", f.name, f.min, f.max, s)
+	}
+	return n, nil
+}
+
+// dayMatches reports whether the date of t is one the schedule runs on
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the first time after after that the schedule matches, or the
+// zero time if it never does
+func (s *cronSchedule) next(after time.Time) time.Time {
+	t := after.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	// Skip ahead a month, day or hour at a time while the larger fields
+	// don't match
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = s.startOfHour(t.Year(), t.Month()+1, 1, 0)
+		case !s.dayMatches(t):
+			t = s.startOfHour(t.Year(), t.Month(), t.Day()+1, 0)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = s.startOfHour(t.Year(), t.Month(), t.Day(), t.Hour()+1)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		case s.hour != cronEveryHour && t.Add(-time.Hour).Hour() == t.Hour():
+			// The second pass through an hour repeated when clocks went back
+			t = s.startOfHour(t.Year(), t.Month(), t.Day(), t.Hour()+1)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// startOfHour returns the start of the given hour in the schedule's time
+// zone. When clocks skip that time, time.Date may return an instant before
+// the gap, which would send next back to where it started; the first
+// moment after the gap is returned instead.
+func (s *cronSchedule) startOfHour(year int, month time.Month, day, hour int) time.Time {
+	t := time.Date(year, month, day, hour, 0, 0, 0, s.loc)
+	want := time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	got := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	if got.Before(want) {
+		t = t.Add(want.Sub(got))
+	}
+	return t
+}
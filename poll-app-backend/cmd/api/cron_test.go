@@ -0,0 +1,188 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// bits lists the values set in a field's bit set
+func bits(set uint64) []int {
+	var values []int
+	for v := range 64 {
+		if set&(1<<v) != 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func TestCronFieldParse(t *testing.T) {
+	tests := []struct {
+		field cronField
+		expr  string
+		want  []int
+	}{
+		{cronMinute, "*", bits(1<<60 - 1)},
+		{cronMinute, "7", []int{7}},
+		{cronMinute, "*/15", []int{0, 15, 30, 45}},
+		{cronMinute, "5/15", []int{5, 20, 35, 50}},
+		{cronMinute, "10-13", []int{10, 11, 12, 13}},
+		{cronMinute, "1-10/3", []int{1, 4, 7, 10}},
+		{cronMinute, "1,5,10-12,58/2", []int{1, 5, 10, 11, 12, 58}},
+		{cronHour, "22-23,0-1", []int{0, 1, 22, 23}},
+		{cronDOM, "*/10", []int{1, 11, 21, 31}},
+		{cronMonth, "jan-mar,dec", []int{1, 2, 3, 12}},
+		{cronMonth, "*/4", []int{1, 5, 9}},
+		{cronDOW, "mon-fri", []int{1, 2, 3, 4, 5}},
+		{cronDOW, "sat,7", []int{6, 7}},
+	}
+	for _, tt := range tests {
+		got, err := tt.field.parse(tt.expr)
+		if err != nil {
+			t.Errorf("%s %q: %v", tt.field.name, tt.expr, err)
+			continue
+		}
+		if !slices.Equal(bits(got), tt.want) {
+			t.Errorf("%s %q = %v, want %v", tt.field.name, tt.expr, bits(got), tt.want)
+		}
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	tests := []struct {
+		spec, tz string
+		err      string
+	}{
+		{"* * * *", "", "five fields"},
+		{"* * * * * *", "", "five fields"},
+		{"60 * * * *", "", "minute must be between 0 and 59"},
+		{"* 24 * * *", "", "hour must be between 0 and 23"},
+		{"* * 0 * *", "", "day of month must be between 1 and 31"},
+		{"* * * 13 *", "", "month must be between 1 and 12"},
+		{"* * * * 8", "", "day of week must be between 0 and 7"},
+		{"5-1 * * * *", "", "runs backwards"},
+		{"*/0 * * * *", "", "invalid step"},
+		{"*/x * * * *", "", "invalid step"},
+		{"1- * * * *", "", "minute must be"},
+		{"1,,2 * * * *", "", "minute must be"},
+		{"* * * smarch *", "", "month must be"},
+		{"@yearly", "", "five fields"},
+		{"0 9 * * *", "Mars/Olympus_Mons", "unknown time zone"},
+	}
+	for _, tt := range tests {
+		_, err := parseCronSchedule(tt.spec, tt.tz)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("parseCronSchedule(%q, %q) = %v, want an error containing %q", tt.spec, tt.tz, err, tt.err)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		tz    string
+		after string // RFC 3339
+		want  string // RFC 3339, or "" for never
+	}{
+		{"every quarter hour", "*/15 * * * *", "", "2026-10-17T10:07:30Z", "2026-10-17T10:15:00Z"},
+		{"strictly after", "*/15 * * * *", "", "2026-10-17T10:15:00Z", "2026-10-17T10:30:00Z"},
+		{"end of day rolls to the next", "0 9 * * *", "", "2026-10-17T09:00:00Z", "2026-10-18T09:00:00Z"},
+		{"weekdays skip the weekend", "0 9 * * mon-fri", "", "2026-10-16T10:00:00Z", "2026-10-19T09:00:00Z"},
+		{"Sunday as 7", "0 0 * * 7", "", "2026-10-17T00:00:00Z", "2026-10-18T00:00:00Z"},
+		{"hour list", "30 8,17 * * *", "", "2026-10-17T09:00:00Z", "2026-10-17T17:30:00Z"},
+
+		// Both day fields restricted: either one matching is enough
+		{"day of month or weekday, weekday first", "0 0 13 * fri", "", "2026-10-01T00:00:00Z", "2026-10-02T00:00:00Z"},
+		{"day of month or weekday, date first", "0 0 13 * fri", "", "2026-10-09T00:00:00Z", "2026-10-13T00:00:00Z"},
+		// A day field starting with * isn't restricted, so both must match
+		{"stepped day of month and weekday", "0 0 */2 * mon", "", "2026-10-01T00:00:00Z", "2026-10-05T00:00:00Z"},
+		{"weekday only", "0 0 * * tue", "", "2026-10-01T00:00:00Z", "2026-10-06T00:00:00Z"},
+
+		{"short month skipped", "0 0 31 * *", "", "2026-10-31T01:00:00Z", "2026-12-31T00:00:00Z"},
+		{"end of month", "0 0 31 * *", "", "2026-09-15T00:00:00Z", "2026-10-31T00:00:00Z"},
+		{"year rollover", "0 0 1 1 *", "", "2026-12-31T23:59:00Z", "2027-01-01T00:00:00Z"},
+		{"leap day", "0 0 29 feb *", "", "2026-03-01T00:00:00Z", "2028-02-29T00:00:00Z"},
+		{"a day that never comes", "0 0 30 feb *", "", "2026-03-01T00:00:00Z", ""},
+		{"monthly macro", "@monthly", "", "2026-10-17T00:00:00Z", "2026-11-01T00:00:00Z"},
+		{"weekly macro", "@weekly", "", "2026-10-17T00:00:00Z", "2026-10-18T00:00:00Z"},
+
+		{"read in the time zone", "0 9 * * *", "Europe/Berlin", "2026-10-17T08:00:00Z", "2026-10-18T07:00:00Z"},
+		{"time zone offset changes", "0 9 * * *", "Europe/Berlin", "2026-10-24T08:00:00Z", "2026-10-25T08:00:00Z"},
+
+		// New York springs forward at 2:00 on 2026-03-08: 2:30 doesn't exist
+		{"time in a DST gap is skipped", "30 2 * * *", "America/New_York", "2026-03-08T05:00:00Z", "2026-03-09T06:30:00Z"},
+		{"steps carry on after a DST gap", "*/30 * * * *", "America/New_York", "2026-03-08T06:45:00Z", "2026-03-08T07:00:00Z"},
+		{"day after a DST gap", "0 3 * * *", "America/New_York", "2026-03-08T05:00:00Z", "2026-03-08T07:00:00Z"},
+		// Santiago springs forward at midnight on 2026-09-06, so that day
+		// starts at 1:00
+		{"day starting after a DST gap", "0 12 * * sun", "America/Santiago", "2026-09-05T16:00:00Z", "2026-09-06T15:00:00Z"},
+		{"midnight in a DST gap is skipped", "0 0 * * *", "America/Santiago", "2026-09-05T12:00:00Z", "2026-09-07T03:00:00Z"},
+
+		// and falls back at 2:00 on 2026-11-01: 1:30 happens twice
+		{"repeated time, first pass", "30 1 * * *", "America/New_York", "2026-11-01T04:00:00Z", "2026-11-01T05:30:00Z"},
+		{"repeated time counts once", "30 1 * * *", "America/New_York", "2026-11-01T05:30:00Z", "2026-11-02T06:30:00Z"},
+		{"hourly runs in both passes", "30 * * * *", "America/New_York", "2026-11-01T05:30:00Z", "2026-11-01T06:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(tt.spec, tt.tz)
+			if err != nil {
+				t.Fatalf("parseCronSchedule(%q): %v", tt.spec, err)
+			}
+			after, err := time.Parse(time.RFC3339, tt.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := schedule.next(after)
+			if tt.want == "" {
+				if !got.IsZero() {
+					t.Errorf("next(%s) = %s, want never", tt.after, got)
+				}
+				return
+			}
+			want, err := time.Parse(time.RFC3339, tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(want) {
+				t.Errorf("next(%s) = %s, want %s", tt.after, got.UTC().Format(time.RFC3339), tt.want)
+			}
+			if got.Location() != schedule.loc {
+				t.Errorf("next returned a time in %s, want %s", got.Location(), schedule.loc)
+			}
+		})
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		err  string // "" when the schedule is accepted
+	}{
+		{"0 * * * *", ""},
+		{"@daily", ""},
+		{"0 9,10 * * *", ""}, // exactly minRecurrenceGap apart
+		{"0 9 * * mon", ""},
+		{"* * * * *", "at least 1h0m0s apart"},
+		{"*/30 * * * *", "at least 1h0m0s apart"},
+		{"0,30 9 * * *", "at least 1h0m0s apart"},
+		{"0 9 * * *,", "invalid recurrence"},
+		{"0 0 30 feb *", "never occurs"},
+	}
+	for _, tt := range tests {
+		_, err := parseRecurrence(tt.spec, "", now)
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("parseRecurrence(%q) = %v, want it accepted", tt.spec, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("parseRecurrence(%q) = %v, want an error containing %q", tt.spec, err, tt.err)
+		}
+	}
+}
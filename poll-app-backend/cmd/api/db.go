@@ -1,6 +1,8 @@
 package main
 
 import (
+	"backend/ent"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -25,10 +27,37 @@ func openDB(dsn string) (*sql.DB,error) {
 }
 
 func (app *application) connectToDB() (*sql.DB, error) {
-	connection, err := openDB(app.DSN)
+	connection, err := openDB(app.Config.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("unable to connect to database: %v", err)
 	}
 	log.Println("Connected to database successfully")
 	return connection, nil
-}
\ No newline at end of file
+}
+
+// withTx runs fn inside an ent transaction, committing if it returns nil
+// and rolling back otherwise (including on panic)
+func withTx(ctx context.Context, client *ent.Client, fn func(tx *ent.Tx) error) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			err = fmt.Errorf("%w: rolling back transaction: %v", err, rerr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	return nil
+}
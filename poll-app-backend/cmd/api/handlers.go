@@ -4,11 +4,11 @@ import (
 	"backend/ent"
 	"backend/ent/poll"
 	"backend/ent/user"
-	"backend/ent/vote"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/julienschmidt/httprouter"
@@ -22,7 +22,7 @@ func (app *application) Home(w http.ResponseWriter, r *http.Request, _ httproute
 	}
 	payload.Message = "Welcome to the Home Page"
 	payload.Status = "success"
-	payload.Version = "1.0.0"
+	payload.Version = version
 
 	// Set the response header and encode the payload as JSON
 	_ = app.writeJSON(w, http.StatusOK, payload)
@@ -30,24 +30,167 @@ func (app *application) Home(w http.ResponseWriter, r *http.Request, _ httproute
 
 func (app *application) About(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	fmt.Fprintln(w, "About Page")
-	fmt.Fprintln(w, "Domain:", app.Domain)
+	fmt.Fprintln(w, "Domain:", app.Config.Domain)
 }
 
+// AllPolls lists polls a page at a time. Query parameters:
+//
+//	limit          page size (default 20, max 100)
+//	cursor         next_cursor from the previous page
+//	sort           newest (default), most_votes or closing_soon
+//	status         draft, open, closed, expired or template
+//	poll_type      only polls of this type
+//	created_by     only polls created by this email
+//	created_after  / created_before  RFC3339 bounds on created_at
 func (app *application) AllPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	// Use Ent to get all polls with their options
-	polls, err := app.DB.Poll.Query().
-		WithOptions().                 // Load related poll options
-		Order(ent.Desc("created_at")). // Order by newest first using field name
+	params, err := readPollListParams(r.URL.Query())
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query := app.DB.Poll.Query().Where(params.filters(time.Now())...)
+
+	// Total matching polls across all pages
+	total, err := query.Clone().Count(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	if params.Cursor != nil {
+		after, err := params.after()
+		if err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		query = query.Where(after)
+	}
+
+	// Use Ent to get one page of polls with their options, fetching one
+	// extra row to tell whether there is another page
+	polls, err := query.
+		WithOptions(). // Load related poll options
+		Order(params.order()...).
+		Limit(params.Limit + 1).
 		All(r.Context())
 
 	if err != nil {
 		app.errorJSON(w, err)
 		return
 	}
-	_ = app.writeJSON(w, http.StatusOK, polls)
+
+	var nextCursor *string
+	if len(polls) > params.Limit {
+		polls = polls[:params.Limit]
+		cursor, err := params.cursorFor(polls[len(polls)-1])
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		nextCursor = &cursor
+	}
+
+	// Leave out vote counts the caller isn't allowed to see yet
+	views, err := app.pollViews(r.Context(), app.requestVoter(r), polls)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	_ = app.writeJSON(w, http.StatusOK, struct {
+		Polls      []pollView `json:"polls"`
+		NextCursor *string    `json:"next_cursor"`
+		Total      int        `json:"total"`
+	}{
+		Polls:      views,
+		NextCursor: nextCursor,
+		Total:      total,
+	})
+}
+
+// SearchPolls runs a full-text search over poll titles, descriptions and
+// option texts. Query parameters: q (required), limit, offset.
+func (app *application) SearchPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	qs := r.URL.Query()
+
+	query := strings.TrimSpace(qs.Get("q"))
+	if query == "" {
+		app.errorJSON(w, errors.New("q is required"), http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultPageSize
+	if v := qs.Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			app.errorJSON(w, fmt.Errorf("limit must be between 1 and %d", maxPageSize), http.StatusBadRequest)
+			return
+		}
+	}
+
+	offset := 0
+	if v := qs.Get("offset"); v != "" {
+		var err error
+		offset, err = strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			app.errorJSON(w, errors.New("offset must be a non-negative integer"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	hits, total, err := app.Searcher.Search(r.Context(), query, limit, offset)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// Load the matched polls with their options, keeping the ranked order
+	ids := make([]int, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.PollID)
+	}
+	polls, err := app.DB.Poll.Query().
+		Where(poll.IDIn(ids...)).
+		WithOptions().
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	views, err := app.pollViews(r.Context(), app.requestVoter(r), polls)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	pollsByID := make(map[int]pollView, len(views))
+	for _, v := range views {
+		pollsByID[v.ID] = v
+	}
+
+	type searchResult struct {
+		searchHit
+		Poll pollView `json:"poll"`
+	}
+	results := make([]searchResult, 0, len(hits))
+	for _, hit := range hits {
+		if p, ok := pollsByID[hit.PollID]; ok {
+			results = append(results, searchResult{searchHit: hit, Poll: p})
+		}
+	}
+
+	_ = app.writeJSON(w, http.StatusOK, struct {
+		Results []searchResult `json:"results"`
+		Total   int            `json:"total"`
+	}{
+		Results: results,
+		Total:   total,
+	})
 }
 
-// GetPoll handles getting a single poll by ID for pie chart display
+// GetPoll handles getting a single poll by ID for pie chart display.
+// Private polls also take an invite link's token as ?invite=.
 func (app *application) GetPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	idStr := ps.ByName("id")
 
@@ -75,8 +218,21 @@ func (app *application) GetPoll(w http.ResponseWriter, r *http.Request, ps httpr
 		return
 	}
 
+	// Private polls need an invitation
+	if err := app.requestPollAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	// Hide the vote counts until the poll's results are visible to the caller
+	view, err := app.pollViewFor(r.Context(), app.requestVoter(r), pollData)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
 	// Success - return poll with options
-	app.writeJSON(w, http.StatusOK, pollData)
+	app.writeJSON(w, http.StatusOK, view)
 }
 
 // Login handles user authentication
@@ -94,15 +250,15 @@ func (app *application) Login(w http.ResponseWriter, r *http.Request, _ httprout
 		return
 	}
 
-	// Use Ent to check credentials
+	// Use Ent to look up the user by email
 	userData, err := app.DB.User.Query().
-		Where(user.EmailEQ(loginReq.Email)).       // Find user by email
-		Where(user.PasswordEQ(loginReq.Password)). // Check password matches
-		Only(r.Context())                          // Get exactly one user
+		Where(user.EmailEqualFold(strings.TrimSpace(loginReq.Email))). // Find user by email
+		Only(r.Context())                                              // Get exactly one user
 
 	if err != nil {
 		if ent.IsNotFound(err) {
-			// Invalid credentials
+			// Unknown email - burn the same time as a real check
+			burnPasswordCheck(loginReq.Password)
 			app.errorJSON(w, errors.New("invalid email or password"), http.StatusUnauthorized)
 		} else {
 			// Database error
@@ -112,26 +268,60 @@ func (app *application) Login(w http.ResponseWriter, r *http.Request, _ httprout
 		return
 	}
 
-	// Success - return user info
+	// Verify the password against the stored hash
+	ok, needsRehash := checkPassword(userData.Password, loginReq.Password)
+	if !ok {
+		app.errorJSON(w, errors.New("invalid email or password"), http.StatusUnauthorized)
+		return
+	}
+
+	// Only say the account is disabled once the password proved who's asking
+	if userData.DisabledAt != nil {
+		app.errorJSON(w, errAccountDisabled, http.StatusForbidden)
+		return
+	}
+
+	// Upgrade legacy plaintext (or weak) hashes now that we know the password
+	if needsRehash {
+		hash, err := hashPassword(loginReq.Password)
+		if err == nil {
+			var updated *ent.User
+			if updated, err = userData.Update().SetPassword(hash).Save(r.Context()); err == nil {
+				userData = updated
+			}
+		}
+		if err != nil {
+			// Login still succeeds, the row will be upgraded next time
+			fmt.Printf("Warning: could not rehash password for user %d: %v\n", userData.ID, err)
+		}
+	}
+
+	// Issue a session token for subsequent requests
+	token, expiresAt, err := app.generateToken(userData)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	// Success - return user info along with the token
 	app.writeJSON(w, http.StatusOK, JSONResponse{
 		Error:   false,
 		Message: "Login successful",
-		Data:    userData,
+		Data: struct {
+			User      *ent.User `json:"user"`
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}{
+			User:      userData,
+			Token:     token,
+			ExpiresAt: expiresAt,
+		},
 	})
 }
 
 // CreatePoll handles creating a new poll with options
 func (app *application) CreatePoll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	// Define the structure for the poll creation request
-	var createReq struct {
-		Title           string   `json:"title"`
-		Description     string   `json:"description"`
-		PollType        string   `json:"poll_type"`
-		CreatedBy       string   `json:"created_by"`
-		MaxVotesPerUser int      `json:"max_votes_per_user"`
-		ExpiresAt       *string  `json:"expires_at"` // pointer to handle null
-		Options         []string `json:"options"`
-	}
+	var createReq pollInput
 
 	// Parse the JSON body
 	err := app.readJSON(w, r, &createReq)
@@ -140,97 +330,27 @@ func (app *application) CreatePoll(w http.ResponseWriter, r *http.Request, _ htt
 		return
 	}
 
-	// Validate required fields
-	if createReq.Title == "" {
-		app.errorJSON(w, errors.New("poll title is required"), http.StatusBadRequest)
-		return
-	}
-
-	if len(createReq.Options) < 2 {
-		app.errorJSON(w, errors.New("at least 2 options are required"), http.StatusBadRequest)
-		return
-	}
-
-	// Validate poll type
-	if createReq.PollType != "single_choice" && createReq.PollType != "multiple_choice" {
-		app.errorJSON(w, errors.New("poll_type must be 'single_choice' or 'multiple_choice'"), http.StatusBadRequest)
-		return
-	}
-
-	// Validate max votes for multiple choice polls
-	if createReq.PollType == "multiple_choice" && createReq.MaxVotesPerUser < 1 {
-		app.errorJSON(w, errors.New("max_votes_per_user must be at least 1 for multiple choice polls"), http.StatusBadRequest)
+	// Validate and fill in defaults
+	if err := createReq.validate(time.Now()); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
-	// Parse expiry date if provided
-	var expiresAt *time.Time
-	if createReq.ExpiresAt != nil && *createReq.ExpiresAt != "" {
-		parsedTime, err := time.Parse(time.RFC3339, *createReq.ExpiresAt)
-		if err != nil {
-			app.errorJSON(w, errors.New("invalid expires_at format, use RFC3339"), http.StatusBadRequest)
-			return
-		}
+	// The creator is always the authenticated user, never the request body
+	creator := app.contextGetUser(r)
 
-		// Check if expiry date is in the future
-		if parsedTime.Before(time.Now()) {
-			app.errorJSON(w, errors.New("expires_at must be in the future"), http.StatusBadRequest)
-			return
-		}
-
-		expiresAt = &parsedTime
-	}
-
-	// Set default values
-	if createReq.PollType == "" {
-		createReq.PollType = "single_choice"
-	}
-	if createReq.MaxVotesPerUser == 0 {
-		createReq.MaxVotesPerUser = 1
-	}
-	if createReq.CreatedBy == "" {
-		createReq.CreatedBy = "anonymous"
-	}
-
-	// Create the poll using Ent
-	pollBuilder := app.DB.Poll.Create().
-		SetTitle(createReq.Title).
-		SetPollType(createReq.PollType).
-		SetCreatedBy(createReq.CreatedBy).
-		SetMaxVotesPerUser(createReq.MaxVotesPerUser)
-
-	// Add optional fields
-	if createReq.Description != "" {
-		pollBuilder = pollBuilder.SetDescription(createReq.Description)
-	}
-	if expiresAt != nil {
-		pollBuilder = pollBuilder.SetExpiresAt(*expiresAt)
-	}
-
-	// Create the poll
-	createdPoll, err := pollBuilder.Save(r.Context())
+	// Create the poll and its options together
+	var createdPoll *ent.Poll
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		createdPoll, err = createPoll(r.Context(), tx.Client(), &createReq, creator)
+		return err
+	})
 	if err != nil {
 		fmt.Printf("Error creating poll: %v\n", err)
 		app.errorJSON(w, errors.New("failed to create poll"), http.StatusInternalServerError)
 		return
 	}
 
-	// Create poll options
-	for _, optionText := range createReq.Options {
-		if optionText != "" { // Skip empty options
-			_, err := app.DB.PollOption.Create().
-				SetOptionText(optionText).
-				SetVoteCount(0).
-				SetPoll(createdPoll).
-				Save(r.Context())
-			if err != nil {
-				fmt.Printf("Error creating poll option: %v\n", err)
-				// If option creation fails, we could optionally delete the poll
-				// For now, just log the error and continue
-			}
-		}
-	}
-
 	// Fetch the created poll with its options for the response
 	pollWithOptions, err := app.DB.Poll.Query().
 		Where(poll.IDEQ(createdPoll.ID)).
@@ -242,6 +362,9 @@ func (app *application) CreatePoll(w http.ResponseWriter, r *http.Request, _ htt
 		pollWithOptions = createdPoll
 	}
 
+	pollsCreated.WithLabelValues(pollSourceAPI).Inc()
+	app.notifyPollEvent(r.Context(), createdPoll.ID, eventPollCreated, "")
+
 	// Success response
 	app.writeJSON(w, http.StatusCreated, JSONResponse{
 		Error:   false,
@@ -255,179 +378,245 @@ func (app *application) CreatePoll(w http.ResponseWriter, r *http.Request, _ htt
 func (app *application) VoteOnPoll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	// 📋 VOTE REQUEST STRUCTURE: What the frontend sends us
 	var voteReq struct {
-		PollID          int    `json:"poll_id"`          // Which poll to vote on
-		OptionIDs       []int  `json:"option_ids"`       // Which options to vote for (array for multiple choice)
-		VoterIdentifier string `json:"voter_identifier"` // Who is voting (usually email)
+		PollID    int           `json:"poll_id"`      // Which poll to vote on
+		OptionIDs []int         `json:"option_ids"`   // Which options to vote for (array for multiple choice, in order for ranked choice)
+		Scores    []optionScore `json:"scores"`       // Score for every option (rating polls only)
+		Invite    string        `json:"invite_token"` // Invite link token (private polls only)
 	}
 
 	// 🔍 PARSE REQUEST: Convert JSON body to our struct
 	err := app.readJSON(w, r, &voteReq)
 	if err != nil {
+		recordVoteRejection(newRequestError(http.StatusBadRequest, err))
 		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
 	// ✅ BASIC VALIDATION: Check required fields
 	if voteReq.PollID == 0 {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
 		app.errorJSON(w, errors.New("poll_id is required"), http.StatusBadRequest)
 		return
 	}
-	if len(voteReq.OptionIDs) == 0 {
-		app.errorJSON(w, errors.New("at least one option must be selected"), http.StatusBadRequest)
+
+	// ⭐ RATING BALLOTS: Scores name their own options
+	optionIDs, scores, err := ballotFromScores(voteReq.OptionIDs, voteReq.Scores)
+	if err != nil {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
+		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
-	if voteReq.VoterIdentifier == "" {
-		app.errorJSON(w, errors.New("voter_identifier is required"), http.StatusBadRequest)
+	if err := validateSelection(optionIDs); err != nil {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
+		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
-	// 🔍 GET POLL: Fetch the poll with its options from database
-	pollData, err := app.DB.Poll.Query().
-		Where(poll.IDEQ(voteReq.PollID)). // Find poll by ID
-		WithOptions().                    // Include poll options
-		Only(r.Context())                 // Get exactly one result
+	// 👤 VOTER: The authenticated user, or an anonymous voter identified by a
+	// cookie we issue; never taken from the request body
+	voter := app.requestOrNewVoter(w, r)
 
+	// 🔒 CAST VOTES ATOMICALLY: Every check and write happens in one transaction
+	createdVotes, err := app.castVotes(r.Context(), voteReq.PollID, optionIDs, scores, voter, voteReq.Invite)
 	if err != nil {
-		if ent.IsNotFound(err) {
-			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
-		} else {
-			app.errorJSON(w, err, http.StatusInternalServerError)
-		}
+		app.errorJSON(w, err, statusFromError(err))
 		return
 	}
 
-	// ⏰ CHECK EXPIRY: Make sure poll is still accepting votes
-	// For optional time fields in Ent, zero time means "no expiry set"
-	if !pollData.ExpiresAt.IsZero() && time.Now().After(pollData.ExpiresAt) {
-		app.errorJSON(w, errors.New("poll has expired"), http.StatusBadRequest)
-		return
+	// 📊 PREPARE RESPONSE: Get updated poll data with new vote counts
+	updatedPoll, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(voteReq.PollID)).
+		WithOptions().
+		Only(r.Context())
+
+	var pollResult *pollView
+	if err != nil {
+		// Votes were committed, but we can't fetch updated data
+		fmt.Printf("Warning: Votes created but couldn't fetch updated poll: %v\n", err)
+	} else if view, err := app.pollViewFor(r.Context(), voter, updatedPoll); err != nil {
+		fmt.Printf("Warning: Votes created but couldn't check result visibility: %v\n", err)
+	} else {
+		// 🙈 RESULT VISIBILITY: Counts only show if the poll's setting allows
+		pollResult = &view
 	}
 
-	// 🔍 CHECK EXISTING VOTES: See what this user already voted for
-	existingVotes, err := app.DB.Vote.Query().
-		Where(vote.VoterIdentifierEQ(voteReq.VoterIdentifier)). // Same voter
-		Where(vote.HasPollWith(poll.IDEQ(voteReq.PollID))).     // Same poll
-		All(r.Context())
+	// 🎉 SUCCESS RESPONSE: Let frontend know voting worked
+	responseData := struct {
+		Message    string      `json:"message"`
+		Poll       *pollView   `json:"poll"`
+		VotesCount int         `json:"votes_count"`
+		NewVotes   []*ent.Vote `json:"new_votes"`
+	}{
+		Message:    fmt.Sprintf("Successfully voted for %d option(s)", len(optionIDs)),
+		Poll:       pollResult,
+		VotesCount: len(createdVotes),
+		NewVotes:   createdVotes,
+	}
 
+	app.writeJSON(w, http.StatusCreated, JSONResponse{
+		Error:   false,
+		Message: "Vote cast successfully",
+		Data:    responseData,
+	})
+}
+
+// ChangeVote replaces the caller's selection on a poll
+func (app *application) ChangeVote(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var voteReq struct {
+		PollID    int           `json:"poll_id"`
+		OptionIDs []int         `json:"option_ids"`
+		Scores    []optionScore `json:"scores"`
+		Invite    string        `json:"invite_token"`
+	}
+
+	err := app.readJSON(w, r, &voteReq)
 	if err != nil {
-		app.errorJSON(w, err, http.StatusInternalServerError)
+		recordVoteRejection(newRequestError(http.StatusBadRequest, err))
+		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
-	// 🚫 PREVENT DUPLICATE VOTING: For single choice, no existing votes allowed
-	if pollData.PollType == "single_choice" && len(existingVotes) > 0 {
-		app.errorJSON(w, errors.New("you have already voted on this poll"), http.StatusBadRequest)
+	if voteReq.PollID == 0 {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
+		app.errorJSON(w, errors.New("poll_id is required"), http.StatusBadRequest)
+		return
+	}
+	optionIDs, scores, err := ballotFromScores(voteReq.OptionIDs, voteReq.Scores)
+	if err != nil {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := validateSelection(optionIDs); err != nil {
+		votesRejected.WithLabelValues(voteRejectInvalid).Inc()
+		app.errorJSON(w, err, http.StatusBadRequest)
 		return
 	}
 
-	// 🔢 VALIDATE MULTIPLE CHOICE LIMITS: Check if user would exceed max votes
-	if pollData.PollType == "multiple_choice" {
-		totalVotesAfter := len(existingVotes) + len(voteReq.OptionIDs)
-		if totalVotesAfter > pollData.MaxVotesPerUser {
-			app.errorJSON(w,
-				fmt.Errorf("you can only vote for %d options total, but you're trying to vote for %d",
-					pollData.MaxVotesPerUser, totalVotesAfter),
-				http.StatusBadRequest)
-			return
-		}
+	voter := app.requestVoter(r)
+	newVotes, err := app.replaceVotes(r.Context(), voteReq.PollID, optionIDs, scores, voter, voteReq.Invite)
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
 	}
 
-	// 🎯 VALIDATE OPTION IDS: Make sure all selected options belong to this poll
-	validOptionIDs := make(map[int]bool)
-	for _, option := range pollData.Edges.Options {
-		validOptionIDs[option.ID] = true
+	updatedPoll, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(voteReq.PollID)).
+		WithOptions().
+		Only(r.Context())
+	var pollResult *pollView
+	if err != nil {
+		fmt.Printf("Warning: Vote changed but couldn't fetch updated poll: %v\n", err)
+	} else if view, err := app.pollViewFor(r.Context(), voter, updatedPoll); err != nil {
+		fmt.Printf("Warning: Vote changed but couldn't check result visibility: %v\n", err)
+	} else {
+		pollResult = &view
 	}
 
-	for _, optionID := range voteReq.OptionIDs {
-		if !validOptionIDs[optionID] {
-			app.errorJSON(w,
-				fmt.Errorf("option ID %d does not belong to poll %d", optionID, voteReq.PollID),
-				http.StatusBadRequest)
-			return
-		}
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Vote changed successfully",
+		Data: struct {
+			Poll     *pollView   `json:"poll"`
+			NewVotes []*ent.Vote `json:"new_votes"`
+		}{
+			Poll:     pollResult,
+			NewVotes: newVotes,
+		},
+	})
+}
+
+// RetractVote withdraws all of the caller's votes on the poll named by :id
+func (app *application) RetractVote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
 	}
 
-	// 🚫 CHECK FOR DUPLICATE VOTES: Make sure user isn't voting for same option twice
-	existingOptionIDs := make(map[int]bool)
-	for _, existingVote := range existingVotes {
-		// Get the option ID for each existing vote
-		voteWithOption, err := app.DB.Vote.Query().
-			Where(vote.IDEQ(existingVote.ID)).
-			WithOption().
-			Only(r.Context())
-		if err == nil && voteWithOption.Edges.Option != nil {
-			existingOptionIDs[voteWithOption.Edges.Option.ID] = true
-		}
+	removed, err := app.retractVotes(r.Context(), pollID, app.requestVoter(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
 	}
 
-	for _, optionID := range voteReq.OptionIDs {
-		if existingOptionIDs[optionID] {
-			app.errorJSON(w,
-				fmt.Errorf("you have already voted for option %d", optionID),
-				http.StatusBadRequest)
-			return
-		}
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: fmt.Sprintf("Withdrew %d vote(s)", removed),
+	})
+}
+
+// UpdatePollSettings lets a poll's creator change its voting, visibility and invite settings
+func (app *application) UpdatePollSettings(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
 	}
 
-	// 🗳️ CREATE VOTES: All validation passed, now create the vote records
-	var createdVotes []*ent.Vote
+	var settingsReq struct {
+		AllowVoteChanges  *bool    `json:"allow_vote_changes"`
+		Visibility        *string  `json:"visibility"`
+		ResultsVisibility *string  `json:"results_visibility"`
+		InvitedEmails     []string `json:"invited_emails"`
+	}
 
-	for _, optionID := range voteReq.OptionIDs {
-		// Create the vote record
-		newVote, err := app.DB.Vote.Create().
-			SetVoterIdentifier(voteReq.VoterIdentifier).
-			SetPollID(voteReq.PollID).
-			SetOptionID(optionID).
-			Save(r.Context())
+	err = app.readJSON(w, r, &settingsReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
 
-		if err != nil {
-			app.errorJSON(w,
-				fmt.Errorf("failed to create vote for option %d: %v", optionID, err),
-				http.StatusInternalServerError)
+	if settingsReq.Visibility != nil {
+		if err := validateVisibility(*settingsReq.Visibility); err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
 			return
 		}
-		createdVotes = append(createdVotes, newVote)
-
-		// Update the vote count on the option
-		_, err = app.DB.PollOption.UpdateOneID(optionID).
-			AddVoteCount(1). // Increment vote count by 1
-			Save(r.Context())
-
+	}
+	if settingsReq.ResultsVisibility != nil {
+		if err := validateResultsVisibility(*settingsReq.ResultsVisibility); err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+	}
+	if settingsReq.InvitedEmails != nil {
+		settingsReq.InvitedEmails, err = normalizeInvitedEmails(settingsReq.InvitedEmails)
 		if err != nil {
-			// Note: In production, you might want to use database transactions
-			// to ensure vote creation and count updates happen atomically
-			fmt.Printf("Warning: Created vote but failed to update count for option %d: %v\n", optionID, err)
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
 		}
 	}
 
-	// 📊 PREPARE RESPONSE: Get updated poll data with new vote counts
-	updatedPoll, err := app.DB.Poll.Query().
-		Where(poll.IDEQ(voteReq.PollID)).
-		WithOptions().
-		Only(r.Context())
-
+	pollData, err := app.findManagedPoll(r.Context(), pollID, app.contextGetUser(r))
 	if err != nil {
-		// Votes were created successfully, but we can't fetch updated data
-		fmt.Printf("Warning: Votes created but couldn't fetch updated poll: %v\n", err)
-		updatedPoll = pollData
+		app.errorJSON(w, err, statusFromError(err))
+		return
 	}
 
-	// 🎉 SUCCESS RESPONSE: Let frontend know voting worked
-	responseData := struct {
-		Message    string      `json:"message"`
-		Poll       *ent.Poll   `json:"poll"`
-		VotesCount int         `json:"votes_count"`
-		NewVotes   []*ent.Vote `json:"new_votes"`
-	}{
-		Message:    fmt.Sprintf("Successfully voted for %d option(s)", len(voteReq.OptionIDs)),
-		Poll:       updatedPoll,
-		VotesCount: len(createdVotes),
-		NewVotes:   createdVotes,
+	updater := pollData.Update()
+	if settingsReq.AllowVoteChanges != nil {
+		updater = updater.SetAllowVoteChanges(*settingsReq.AllowVoteChanges)
+	}
+	if settingsReq.Visibility != nil {
+		updater = updater.SetVisibility(*settingsReq.Visibility)
+	}
+	if settingsReq.ResultsVisibility != nil {
+		updater = updater.SetResultsVisibility(*settingsReq.ResultsVisibility)
+	}
+	// A new invite list replaces the old one
+	if settingsReq.InvitedEmails != nil {
+		updater = updater.SetInvitedEmails(settingsReq.InvitedEmails)
 	}
 
-	app.writeJSON(w, http.StatusCreated, JSONResponse{
+	updatedPoll, err := updater.Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
 		Error:   false,
-		Message: "Vote cast successfully",
-		Data:    responseData,
+		Message: "Poll settings updated",
+		Data:    updatedPoll,
 	})
 }
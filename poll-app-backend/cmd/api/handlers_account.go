@@ -0,0 +1,396 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/user"
+	"backend/ent/vote"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// minPasswordLength is the shortest password accepted on registration or change
+const minPasswordLength = 8
+
+// Register handles user self-registration
+func (app *application) Register(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var registerReq struct {
+		Email       string `json:"email"`
+		Password    string `json:"password"`
+		DisplayName string `json:"display_name"`
+		AvatarURL   string `json:"avatar_url"`
+	}
+
+	// Parse the JSON body
+	err := app.readJSON(w, r, &registerReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Validate the email format and normalise it
+	email, err := normalizeEmail(registerReq.Email)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := validatePassword(registerReq.Password, email); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := validateProfile(registerReq.DisplayName, registerReq.AvatarURL); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Check the email isn't already taken
+	exists, err := app.DB.User.Query().
+		Where(user.EmailEqualFold(email)).
+		Exist(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	if exists {
+		app.errorJSON(w, errors.New("an account with this email already exists"), http.StatusConflict)
+		return
+	}
+
+	passwordHash, err := hashPassword(registerReq.Password)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	userBuilder := app.DB.User.Create().
+		SetEmail(email).
+		SetPassword(passwordHash)
+	if registerReq.DisplayName != "" {
+		userBuilder = userBuilder.SetDisplayName(strings.TrimSpace(registerReq.DisplayName))
+	}
+	if registerReq.AvatarURL != "" {
+		userBuilder = userBuilder.SetAvatarURL(registerReq.AvatarURL)
+	}
+
+	newUser, err := userBuilder.Save(r.Context())
+	if err != nil {
+		// The unique index still catches a registration racing with ours
+		if ent.IsConstraintError(err) {
+			app.errorJSON(w, errors.New("an account with this email already exists"), http.StatusConflict)
+			return
+		}
+		fmt.Printf("Error creating user: %v\n", err)
+		app.errorJSON(w, errors.New("failed to create account"), http.StatusInternalServerError)
+		return
+	}
+
+	app.writeJSON(w, http.StatusCreated, JSONResponse{
+		Error:   false,
+		Message: "Account created successfully",
+		Data:    newUser,
+	})
+}
+
+// GetAccount returns the authenticated user's profile
+func (app *application) GetAccount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	app.writeJSON(w, http.StatusOK, app.contextGetUser(r))
+}
+
+// UpdateAccount updates the authenticated user's profile fields.
+// Fields left out of the request are unchanged; an empty string clears them.
+func (app *application) UpdateAccount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var updateReq struct {
+		DisplayName *string `json:"display_name"`
+		AvatarURL   *string `json:"avatar_url"`
+	}
+
+	err := app.readJSON(w, r, &updateReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var displayName, avatarURL string
+	if updateReq.DisplayName != nil {
+		displayName = strings.TrimSpace(*updateReq.DisplayName)
+	}
+	if updateReq.AvatarURL != nil {
+		avatarURL = strings.TrimSpace(*updateReq.AvatarURL)
+	}
+	if err := validateProfile(displayName, avatarURL); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updater := app.contextGetUser(r).Update()
+	if updateReq.DisplayName != nil {
+		if displayName == "" {
+			updater = updater.ClearDisplayName()
+		} else {
+			updater = updater.SetDisplayName(displayName)
+		}
+	}
+	if updateReq.AvatarURL != nil {
+		if avatarURL == "" {
+			updater = updater.ClearAvatarURL()
+		} else {
+			updater = updater.SetAvatarURL(avatarURL)
+		}
+	}
+
+	updatedUser, err := updater.Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Profile updated",
+		Data:    updatedUser,
+	})
+}
+
+// ChangePassword replaces the authenticated user's password after
+// checking the current one
+func (app *application) ChangePassword(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var passwordReq struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+
+	err := app.readJSON(w, r, &passwordReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	currentUser := app.contextGetUser(r)
+
+	if ok, _ := checkPassword(currentUser.Password, passwordReq.CurrentPassword); !ok {
+		app.errorJSON(w, errors.New("current password is incorrect"), http.StatusUnauthorized)
+		return
+	}
+
+	if err := validatePassword(passwordReq.NewPassword, currentUser.Email); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if passwordReq.NewPassword == passwordReq.CurrentPassword {
+		app.errorJSON(w, errors.New("new password must be different from the current password"), http.StatusBadRequest)
+		return
+	}
+
+	passwordHash, err := hashPassword(passwordReq.NewPassword)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// Bumping the token version signs out every other session, including
+	// any stolen one; the caller gets a fresh token to carry on with
+	updatedUser, err := currentUser.Update().
+		SetPassword(passwordHash).
+		AddTokenVersion(1).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	token, expiresAt, err := app.generateToken(updatedUser)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Password changed successfully",
+		Data: struct {
+			Token     string    `json:"token"`
+			ExpiresAt time.Time `json:"expires_at"`
+		}{
+			Token:     token,
+			ExpiresAt: expiresAt,
+		},
+	})
+}
+
+// DeleteAccount permanently removes the authenticated user's account.
+// The password must be supplied again to confirm.
+func (app *application) DeleteAccount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var deleteReq struct {
+		Password string `json:"password"`
+	}
+
+	err := app.readJSON(w, r, &deleteReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	currentUser := app.contextGetUser(r)
+
+	if ok, _ := checkPassword(currentUser.Password, deleteReq.Password); !ok {
+		app.errorJSON(w, errors.New("password is incorrect"), http.StatusUnauthorized)
+		return
+	}
+
+	// The votes stay counted but lose the email, so a later account that
+	// registers it doesn't collide with them in the unique vote index. The
+	// polls stay up without an owner, and their created_by no longer names
+	// the email either.
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		err := tx.Vote.Update().
+			Where(vote.HasVoterWith(user.IDEQ(currentUser.ID))).
+			ClearVoterIdentifier().
+			Exec(r.Context())
+		if err != nil {
+			return err
+		}
+		err = tx.Poll.Update().
+			Where(poll.HasOwnerWith(user.IDEQ(currentUser.ID))).
+			ClearCreatedBy().
+			Exec(r.Context())
+		if err != nil {
+			return err
+		}
+		return tx.User.DeleteOne(currentUser).Exec(r.Context())
+	})
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Account deleted",
+	})
+}
+
+// MyPolls returns the polls created by the authenticated user
+func (app *application) MyPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	polls, err := app.contextGetUser(r).QueryPolls().
+		WithOptions().
+		Order(ent.Desc(poll.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, polls)
+}
+
+// MyVotedPolls returns the polls the authenticated user has voted on,
+// each with the votes they cast on it
+func (app *application) MyVotedPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	currentUser := app.contextGetUser(r)
+
+	polls, err := app.DB.Poll.Query().
+		Where(poll.HasVotesWith(vote.HasVoterWith(user.IDEQ(currentUser.ID)))).
+		WithOptions().
+		WithVotes(func(q *ent.VoteQuery) {
+			// Only include this user's own votes
+			q.Where(vote.HasVoterWith(user.IDEQ(currentUser.ID))).
+				WithOption()
+		}).
+		Order(ent.Desc(poll.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// Polls that only show results once they close stay redacted
+	views, err := app.pollViews(r.Context(), voterRef{user: currentUser}, polls)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, views)
+}
+
+// normalizeEmail checks an email address is well formed and returns it
+// trimmed and lower-cased
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", errors.New("email is required")
+	}
+
+	// Reject display-name forms like "Bob <bob@example.com>"
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", errors.New("invalid email address")
+	}
+
+	// Require a dot in the domain so "user@localhost" style addresses are rejected
+	_, domain, _ := strings.Cut(email, "@")
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", errors.New("invalid email address")
+	}
+
+	return email, nil
+}
+
+// validatePassword enforces the password strength rules
+func validatePassword(password, email string) error {
+	if len(password) < minPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", minPasswordLength)
+	}
+	// bcrypt ignores anything past 72 bytes
+	if len(password) > 72 {
+		return errors.New("password must be at most 72 bytes long")
+	}
+
+	var hasLetter, hasDigit bool
+	for _, c := range password {
+		switch {
+		case unicode.IsLetter(c):
+			hasLetter = true
+		case unicode.IsDigit(c):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return errors.New("password must contain at least one letter and one number")
+	}
+
+	if strings.EqualFold(password, email) {
+		return errors.New("password must not be the same as your email")
+	}
+
+	return nil
+}
+
+// validateProfile checks the optional profile fields
+func validateProfile(displayName, avatarURL string) error {
+	if len(displayName) > 100 {
+		return errors.New("display_name must be at most 100 characters")
+	}
+
+	if avatarURL != "" {
+		if len(avatarURL) > 2048 {
+			return errors.New("avatar_url is too long")
+		}
+		u, err := url.Parse(avatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("avatar_url must be an absolute http or https URL")
+		}
+	}
+
+	return nil
+}
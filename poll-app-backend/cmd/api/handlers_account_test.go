@@ -0,0 +1,35 @@
+package main
+
+import (
+	"backend/ent/user"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestRegisterStartsAsVoter checks self-registered accounts can vote but
+// can't create polls until an admin promotes them
+func TestRegisterStartsAsVoter(t *testing.T) {
+	app := newTestApp(t)
+
+	body := `{"email": "new@example.com", "password": "passphrase42"}`
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.Register(rec, req, nil)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("register got status %d: %s", rec.Code, rec.Body)
+	}
+
+	u, err := app.DB.User.Query().Where(user.EmailEQ("new@example.com")).Only(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Role != roleVoter {
+		t.Errorf("new account has role %q, want %q", u.Role, roleVoter)
+	}
+	if canCreatePolls(u) {
+		t.Error("new account may create polls")
+	}
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/auditlog"
+	"backend/ent/user"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// readIDPage parses the limit and cursor query parameters of the admin list
+// endpoints, which page newest first by ID. The cursor is the last ID of the
+// previous page.
+func readIDPage(qs url.Values) (limit, cursor int, err error) {
+	limit = defaultPageSize
+	if v := qs.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return 0, 0, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+	}
+	if v := qs.Get("cursor"); v != "" {
+		cursor, err = strconv.Atoi(v)
+		if err != nil || cursor < 1 {
+			return 0, 0, errors.New("invalid cursor")
+		}
+	}
+	return limit, cursor, nil
+}
+
+// nextIDCursor returns the cursor for the page after one ending at lastID,
+// or nil if that page was the last
+func nextIDCursor(n, limit, lastID int) *string {
+	if n < limit {
+		return nil
+	}
+	c := strconv.Itoa(lastID)
+	return &c
+}
+
+// AdminListUsers lists every account, newest first. Query parameters:
+//
+//	limit     page size (default 20, max 100)
+//	cursor    next_cursor from the previous page
+//	role      only users with this role
+//	disabled  true or false, to filter on whether the account is disabled
+//	email     only users whose email contains this text
+func (app *application) AdminListUsers(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	qs := r.URL.Query()
+
+	limit, cursor, err := readIDPage(qs)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query := app.DB.User.Query()
+	if role := user.Role(qs.Get("role")); role != "" {
+		if !slices.Contains(userRoles, role) {
+			app.errorJSON(w, errors.New("role must be 'admin', 'creator' or 'voter'"), http.StatusBadRequest)
+			return
+		}
+		query = query.Where(user.RoleEQ(role))
+	}
+	if v := qs.Get("disabled"); v != "" {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			app.errorJSON(w, errors.New("disabled must be true or false"), http.StatusBadRequest)
+			return
+		}
+		if disabled {
+			query = query.Where(user.DisabledAtNotNil())
+		} else {
+			query = query.Where(user.DisabledAtIsNil())
+		}
+	}
+	if email := strings.TrimSpace(qs.Get("email")); email != "" {
+		query = query.Where(user.EmailContainsFold(email))
+	}
+
+	total, err := query.Clone().Count(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	if cursor > 0 {
+		query = query.Where(user.IDLT(cursor))
+	}
+	users, err := query.
+		Order(ent.Desc(user.FieldID)).
+		Limit(limit).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	var nextCursor *string
+	if len(users) > 0 {
+		nextCursor = nextIDCursor(len(users), limit, users[len(users)-1].ID)
+	}
+
+	_ = app.writeJSON(w, http.StatusOK, struct {
+		Users      []*ent.User `json:"users"`
+		NextCursor *string     `json:"next_cursor"`
+		Total      int         `json:"total"`
+	}{
+		Users:      users,
+		NextCursor: nextCursor,
+		Total:      total,
+	})
+}
+
+// AdminUpdateUser changes an account's role or disables and re-enables it.
+// Fields left out of the request are unchanged. Admins can't change their
+// own account this way, so the last admin can't lock everyone out.
+func (app *application) AdminUpdateUser(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid user ID"), http.StatusBadRequest)
+		return
+	}
+
+	var updateReq struct {
+		Role     *user.Role `json:"role"`
+		Disabled *bool      `json:"disabled"`
+	}
+
+	err = app.readJSON(w, r, &updateReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if updateReq.Role != nil && !slices.Contains(userRoles, *updateReq.Role) {
+		app.errorJSON(w, errors.New("role must be 'admin', 'creator' or 'voter'"), http.StatusBadRequest)
+		return
+	}
+
+	admin := app.contextGetUser(r)
+	if id == admin.ID {
+		app.errorJSON(w, errors.New("you can't change your own role or disable your own account"), http.StatusBadRequest)
+		return
+	}
+
+	var updatedUser *ent.User
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		target, err := tx.User.Get(r.Context(), id)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return newRequestError(http.StatusNotFound, errors.New("user not found"))
+			}
+			return err
+		}
+
+		update := tx.User.UpdateOne(target)
+		type auditEntry struct {
+			action  string
+			details map[string]any
+		}
+		var entries []auditEntry
+
+		if updateReq.Role != nil && *updateReq.Role != target.Role {
+			update.SetRole(*updateReq.Role)
+			entries = append(entries, auditEntry{auditUserRoleChanged, map[string]any{
+				"email": target.Email,
+				"from":  target.Role,
+				"to":    *updateReq.Role,
+			}})
+		}
+		if updateReq.Disabled != nil {
+			switch {
+			case *updateReq.Disabled && target.DisabledAt == nil:
+				update.SetDisabledAt(time.Now())
+				entries = append(entries, auditEntry{auditUserDisabled, map[string]any{"email": target.Email}})
+			case !*updateReq.Disabled && target.DisabledAt != nil:
+				update.ClearDisabledAt()
+				entries = append(entries, auditEntry{auditUserEnabled, map[string]any{"email": target.Email}})
+			}
+		}
+
+		updatedUser, err = update.Save(r.Context())
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			err := recordAudit(r.Context(), tx.Client(), admin, entry.action, auditTargetUser, target.ID, entry.details)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "User updated",
+		Data:    updatedUser,
+	})
+}
+
+// AdminAuditLog lists audit entries, newest first. Query parameters:
+//
+//	limit        page size (default 20, max 100)
+//	cursor       next_cursor from the previous page
+//	action       only this action, e.g. poll.deleted
+//	actor        only actions by this email
+//	target_type  poll or user
+//	target_id    only actions on this record (needs target_type)
+func (app *application) AdminAuditLog(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	qs := r.URL.Query()
+
+	limit, cursor, err := readIDPage(qs)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	query := app.DB.AuditLog.Query()
+	if action := qs.Get("action"); action != "" {
+		query = query.Where(auditlog.ActionEQ(action))
+	}
+	if actor := strings.TrimSpace(qs.Get("actor")); actor != "" {
+		query = query.Where(auditlog.ActorEmailEqualFold(actor))
+	}
+
+	targetType := qs.Get("target_type")
+	switch targetType {
+	case "":
+	case auditTargetPoll, auditTargetUser:
+		query = query.Where(auditlog.TargetTypeEQ(targetType))
+	default:
+		app.errorJSON(w, errors.New("target_type must be 'poll' or 'user'"), http.StatusBadRequest)
+		return
+	}
+	if v := qs.Get("target_id"); v != "" {
+		targetID, err := strconv.Atoi(v)
+		if err != nil || targetID < 1 || targetType == "" {
+			app.errorJSON(w, errors.New("target_id must be a positive ID and needs target_type"), http.StatusBadRequest)
+			return
+		}
+		query = query.Where(auditlog.TargetIDEQ(targetID))
+	}
+
+	if cursor > 0 {
+		query = query.Where(auditlog.IDLT(cursor))
+	}
+	entries, err := query.
+		Order(ent.Desc(auditlog.FieldID)).
+		Limit(limit).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	var nextCursor *string
+	if len(entries) > 0 {
+		nextCursor = nextIDCursor(len(entries), limit, entries[len(entries)-1].ID)
+	}
+
+	_ = app.writeJSON(w, http.StatusOK, struct {
+		Entries    []*ent.AuditLog `json:"entries"`
+		NextCursor *string         `json:"next_cursor"`
+	}{
+		Entries:    entries,
+		NextCursor: nextCursor,
+	})
+}
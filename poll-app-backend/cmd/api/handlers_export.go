@@ -0,0 +1,342 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"backend/ent/user"
+	"backend/ent/vote"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Formats accepted by GET /poll/:id/export
+const (
+	exportCSV  = "csv"
+	exportJSON = "json"
+	exportXLSX = "xlsx"
+)
+
+// exportBatchSize is how many votes are loaded per query while streaming
+const exportBatchSize = 1000
+
+// exportVote is one Vote row of an export. The sql tags name the columns
+// it is scanned from.
+type exportVote struct {
+	ID              int       `sql:"id" json:"id"`
+	OptionID        int       `sql:"poll_option_votes" json:"option_id"`
+	OptionText      string    `sql:"-" json:"option_text"`
+	VoterIdentifier string    `sql:"voter_identifier" json:"voter_identifier"`
+	Rank            *int      `sql:"rank" json:"rank,omitempty"`
+	Score           *int      `sql:"score" json:"score,omitempty"`
+	CreatedAt       time.Time `sql:"created_at" json:"created_at"`
+}
+
+// exportEncoder writes one export format. Options is called first, then,
+// only for the poll's owner, StartVotes followed by Vote for every row.
+type exportEncoder interface {
+	Options(pollData *ent.Poll, options []irvTally) error
+	StartVotes() error
+	Vote(v exportVote) error
+	Close() error
+}
+
+// ExportPoll downloads a poll's per-option totals as CSV, JSON or XLSX
+// (?format=, default csv). The poll's owner also gets every vote with its
+// voter identifier and timestamp. Votes are read in batches and written as
+// they arrive, so exports of any size run in constant memory.
+func (app *application) ExportPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportCSV
+	}
+
+	var contentType string
+	switch format {
+	case exportCSV:
+		contentType = "text/csv; charset=utf-8"
+	case exportJSON:
+		contentType = "application/json"
+	case exportXLSX:
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		app.errorJSON(w, errors.New("format must be one of 'csv', 'json', 'xlsx'"), http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		WithOwner(func(q *ent.UserQuery) {
+			q.Select(user.FieldID)
+		}).
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+
+	if err := app.requestPollAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+	if err := app.requestResultsAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	options := make([]irvTally, 0, len(pollData.Edges.Options))
+	optionTexts := make(map[int]string, len(pollData.Edges.Options))
+	for _, option := range pollData.Edges.Options {
+		options = append(options, irvTally{
+			OptionID:   option.ID,
+			OptionText: option.OptionText,
+			Votes:      option.VoteCount,
+		})
+		optionTexts[option.ID] = option.OptionText
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="poll-%d-results.%s"`, pollData.ID, format))
+	w.WriteHeader(http.StatusOK)
+
+	var enc exportEncoder
+	switch format {
+	case exportCSV:
+		enc = &csvExport{w: csv.NewWriter(w)}
+	case exportJSON:
+		enc = &jsonExport{w: w}
+	case exportXLSX:
+		enc = &xlsxExport{x: newXLSXWriter(w)}
+	}
+
+	// The status line has gone out, so from here on a failure can only be
+	// logged and the download cut short
+	err = app.writeExport(r.Context(), enc, pollData, options, optionTexts, isPollOwner(pollData, app.contextGetUser(r)))
+	if err != nil {
+		log.Printf("Export of poll %d failed: %v", pollData.ID, err)
+	}
+}
+
+// writeExport feeds the totals and, if includeVotes, every vote of a poll to
+// an encoder
+func (app *application) writeExport(ctx context.Context, enc exportEncoder, pollData *ent.Poll, options []irvTally, optionTexts map[int]string, includeVotes bool) error {
+	if err := enc.Options(pollData, options); err != nil {
+		return err
+	}
+
+	if includeVotes {
+		if err := enc.StartVotes(); err != nil {
+			return err
+		}
+
+		// Page through the votes by ID rather than loading them all
+		lastID := 0
+		for {
+			var batch []exportVote
+			err := app.DB.Vote.Query().
+				Where(vote.HasPollWith(poll.IDEQ(pollData.ID))).
+				Where(vote.IDGT(lastID)).
+				Order(ent.Asc(vote.FieldID)).
+				Limit(exportBatchSize).
+				Select(vote.FieldID, vote.OptionColumn, vote.FieldVoterIdentifier,
+					vote.FieldRank, vote.FieldScore, vote.FieldCreatedAt).
+				Scan(ctx, &batch)
+			if err != nil {
+				return err
+			}
+
+			for _, v := range batch {
+				v.OptionText = optionTexts[v.OptionID]
+				if err := enc.Vote(v); err != nil {
+					return err
+				}
+			}
+
+			if len(batch) < exportBatchSize {
+				break
+			}
+			lastID = batch[len(batch)-1].ID
+		}
+	}
+
+	return enc.Close()
+}
+
+// csvExport writes the totals table, then, after a blank line, the votes
+// table, each with its own header row
+type csvExport struct {
+	w *csv.Writer
+}
+
+func (e *csvExport) Options(_ *ent.Poll, options []irvTally) error {
+	if err := e.w.Write([]string{"option_id", "option_text", "votes"}); err != nil {
+		return err
+	}
+	for _, option := range options {
+		err := e.w.Write([]string{
+			strconv.Itoa(option.OptionID),
+			csvSafe(option.OptionText),
+			strconv.Itoa(option.Votes),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExport) StartVotes() error {
+	if err := e.w.Write(nil); err != nil {
+		return err
+	}
+	return e.w.Write([]string{"vote_id", "option_id", "option_text", "voter_identifier", "rank", "score", "created_at"})
+}
+
+func (e *csvExport) Vote(v exportVote) error {
+	return e.w.Write([]string{
+		strconv.Itoa(v.ID),
+		strconv.Itoa(v.OptionID),
+		csvSafe(v.OptionText),
+		csvSafe(v.VoterIdentifier),
+		optionalInt(v.Rank),
+		optionalInt(v.Score),
+		v.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvExport) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvSafe keeps spreadsheet programs from running user-supplied text as a
+// formula by prefixing it with a quote when it starts like one
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// optionalInt formats a nullable integer, leaving NULL empty
+func optionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+// jsonExport writes {"poll": ..., "options": [...], "votes": [...]}, with
+// the votes array streamed element by element
+type jsonExport struct {
+	w          io.Writer
+	votes      int
+	votesBegun bool
+}
+
+func (e *jsonExport) Options(pollData *ent.Poll, options []irvTally) error {
+	// The options are listed on their own, and the owner isn't exported
+	p := *pollData
+	p.Edges = ent.PollEdges{}
+
+	head, err := json.Marshal(struct {
+		Poll    *ent.Poll  `json:"poll"`
+		Options []irvTally `json:"options"`
+	}{&p, options})
+	if err != nil {
+		return err
+	}
+	// Leave the object open for the votes
+	_, err = e.w.Write(head[:len(head)-1])
+	return err
+}
+
+func (e *jsonExport) StartVotes() error {
+	e.votesBegun = true
+	_, err := io.WriteString(e.w, `,"votes":[`)
+	return err
+}
+
+func (e *jsonExport) Vote(v exportVote) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if e.votes > 0 {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.votes++
+	_, err = e.w.Write(b)
+	return err
+}
+
+func (e *jsonExport) Close() error {
+	end := "}\n"
+	if e.votesBegun {
+		end = "]}\n"
+	}
+	_, err := io.WriteString(e.w, end)
+	return err
+}
+
+// xlsxExport writes a "Results" sheet and, for the owner, a "Votes" sheet
+type xlsxExport struct {
+	x *xlsxWriter
+}
+
+func (e *xlsxExport) Options(_ *ent.Poll, options []irvTally) error {
+	if err := e.x.StartSheet("Results"); err != nil {
+		return err
+	}
+	if err := e.x.WriteRow("option_id", "option_text", "votes"); err != nil {
+		return err
+	}
+	for _, option := range options {
+		if err := e.x.WriteRow(option.OptionID, option.OptionText, option.Votes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *xlsxExport) StartVotes() error {
+	if err := e.x.StartSheet("Votes"); err != nil {
+		return err
+	}
+	return e.x.WriteRow("vote_id", "option_id", "option_text", "voter_identifier", "rank", "score", "created_at")
+}
+
+func (e *xlsxExport) Vote(v exportVote) error {
+	return e.x.WriteRow(v.ID, v.OptionID, v.OptionText, v.VoterIdentifier, v.Rank, v.Score, v.CreatedAt)
+}
+
+func (e *xlsxExport) Close() error {
+	return e.x.Close()
+}
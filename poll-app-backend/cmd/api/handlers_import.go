@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// maxImportBytes caps the size of an uploaded import file
+const maxImportBytes = 10 << 20 // 10MB
+
+// ImportPolls creates polls in bulk from a CSV or JSON file sent as the
+// request body (see readImportFile for the layout). The format comes from
+// ?format= or else the Content-Type. With ?dry_run=true the file is only
+// validated. Either every poll is created or, if any row is invalid, none
+// are, and the report lists the failing rows with the reason.
+func (app *application) ImportPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	qs := r.URL.Query()
+
+	dryRun := false
+	if v := qs.Get("dry_run"); v != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			app.errorJSON(w, errors.New("dry_run must be true or false"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	format := qs.Get("format")
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "text/csv":
+			format = importCSV
+		case "application/json":
+			format = importJSON
+		default:
+			app.errorJSON(w, errors.New("set ?format=csv or ?format=json, or a text/csv or application/json Content-Type"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+	rows, err := readImportFile(r.Body, format)
+	if err != nil {
+		var tooBig *http.MaxBytesError
+		if errors.As(err, &tooBig) {
+			app.errorJSON(w, fmt.Errorf("import files can be at most %d bytes", maxImportBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	report, err := app.importPolls(r.Context(), rows, app.contextGetUser(r), dryRun)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		app.writeJSON(w, http.StatusUnprocessableEntity, JSONResponse{
+			Error:   true,
+			Message: fmt.Sprintf("%d of %d poll(s) failed validation, nothing was imported", len(report.Errors), report.Total),
+			Data:    report,
+		})
+		return
+	}
+
+	status, message := http.StatusCreated, fmt.Sprintf("Imported %d poll(s)", report.Created)
+	if dryRun {
+		status, message = http.StatusOK, fmt.Sprintf("All %d poll(s) are valid", report.Total)
+	}
+	app.writeJSON(w, status, JSONResponse{
+		Error:   false,
+		Message: message,
+		Data:    report,
+	})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/pollinvite"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// inviteLink is an invite as shown to the poll's creator, with the token to
+// share and whether it still works
+type inviteLink struct {
+	*ent.PollInvite
+	Token  string `json:"token"`
+	Active bool   `json:"active"`
+}
+
+// newInviteLink pairs an invite with its token
+func (app *application) newInviteLink(pollID int, invite *ent.PollInvite, now time.Time) (inviteLink, error) {
+	token, err := app.generateInviteToken(pollID, invite.ID)
+	if err != nil {
+		return inviteLink{}, err
+	}
+	return inviteLink{PollInvite: invite, Token: token, Active: inviteActive(invite, now)}, nil
+}
+
+// ListPollInvites shows who has access to a poll: its visibility, the
+// invited emails and every invite link, revoked ones included
+func (app *application) ListPollInvites(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.findManagedPoll(r.Context(), pollID, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	invites, err := pollData.QueryInvites().
+		Order(ent.Desc(pollinvite.FieldID)).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	now := time.Now()
+	links := make([]inviteLink, 0, len(invites))
+	for _, invite := range invites {
+		link, err := app.newInviteLink(pollID, invite, now)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		links = append(links, link)
+	}
+
+	invited := pollData.InvitedEmails
+	if invited == nil {
+		invited = []string{}
+	}
+
+	app.writeJSON(w, http.StatusOK, struct {
+		Visibility    string       `json:"visibility"`
+		InvitedEmails []string     `json:"invited_emails"`
+		Links         []inviteLink `json:"links"`
+	}{
+		Visibility:    pollData.Visibility,
+		InvitedEmails: invited,
+		Links:         links,
+	})
+}
+
+// CreatePollInvite generates an invite link to a poll. Anyone holding its
+// token can see and vote on the poll while it is private, until the link
+// expires or is revoked.
+func (app *application) CreatePollInvite(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	var inviteReq struct {
+		Label     string  `json:"label"`
+		ExpiresAt *string `json:"expires_at"`
+	}
+
+	err = app.readJSON(w, r, &inviteReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if inviteReq.ExpiresAt != nil && *inviteReq.ExpiresAt != "" {
+		t, err := time.Parse(time.RFC3339, *inviteReq.ExpiresAt)
+		if err != nil {
+			app.errorJSON(w, errors.New("invalid expires_at format, use RFC3339"), http.StatusBadRequest)
+			return
+		}
+		if t.Before(now) {
+			app.errorJSON(w, errors.New("expires_at must be in the future"), http.StatusBadRequest)
+			return
+		}
+		expiresAt = &t
+	}
+
+	label := strings.TrimSpace(inviteReq.Label)
+	if len(label) > 100 {
+		app.errorJSON(w, errors.New("label must be at most 100 characters"), http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.findManagedPoll(r.Context(), pollID, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	invite, err := app.DB.PollInvite.Create().
+		SetPoll(pollData).
+		SetLabel(label).
+		SetNillableExpiresAt(expiresAt).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	link, err := app.newInviteLink(pollID, invite, now)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	message := "Invite link created"
+	if pollData.Visibility != visibilityPrivate {
+		message = "Invite link created; it only restricts access once the poll is private"
+	}
+
+	app.writeJSON(w, http.StatusCreated, JSONResponse{
+		Error:   false,
+		Message: message,
+		Data:    link,
+	})
+}
+
+// RevokePollInvite stops an invite link from granting access. Votes already
+// cast through it stay counted.
+func (app *application) RevokePollInvite(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+	inviteID, err := strconv.Atoi(ps.ByName("invite_id"))
+	if err != nil || inviteID < 1 {
+		app.errorJSON(w, errors.New("invalid invite ID"), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := app.findManagedPoll(r.Context(), pollID, app.contextGetUser(r)); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	invite, err := app.DB.PollInvite.Query().
+		Where(pollinvite.IDEQ(inviteID)).
+		Where(pollinvite.HasPollWith(poll.IDEQ(pollID))).
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("invite not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+	if invite.RevokedAt != nil {
+		app.errorJSON(w, errors.New("invite is already revoked"), http.StatusConflict)
+		return
+	}
+
+	invite, err = invite.Update().
+		SetRevokedAt(time.Now()).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Invite link revoked",
+		Data:    invite,
+	})
+}
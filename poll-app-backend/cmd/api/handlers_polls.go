@@ -0,0 +1,555 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"backend/ent/vote"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// optionEdit is one entry of the options list in a poll edit. Entries with
+// an ID keep (and may rename) that option; entries without one add a new
+// option.
+type optionEdit struct {
+	ID         int    `json:"id"`
+	OptionText string `json:"option_text"`
+}
+
+// parseTimeEdit reads a time field such as expires_at from an edit
+// request: absent leaves the field alone, null clears it, and an RFC3339
+// time in the future replaces it
+func parseTimeEdit(raw json.RawMessage, name string, now time.Time) (set bool, t *time.Time, err error) {
+	if len(raw) == 0 {
+		return false, nil, nil
+	}
+	if string(raw) == "null" {
+		return true, nil, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false, nil, fmt.Errorf("%s must be an RFC3339 string or null", name)
+	}
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return false, nil, fmt.Errorf("invalid %s format, use RFC3339", name)
+	}
+	if parsed.Before(now) {
+		return false, nil, fmt.Errorf("%s must be in the future", name)
+	}
+	return true, &parsed, nil
+}
+
+// UpdatePoll edits a poll's title, description, opening time, expiry,
+// recurrence and options. Fields left out are unchanged; PUT is accepted as
+// well as PATCH.
+//
+// opens_at can only be changed while the poll is a draft; null opens it
+// straight away. Setting a recurrence on a poll without votes turns it into
+// a recurring template; edits to a template apply from its next occurrence.
+// When options is sent it is the complete new list: listed options are kept
+// or renamed, unlisted ones are removed along with their votes, and entries
+// without an id are added. Renaming or removing an option that has votes is
+// refused unless acknowledge_votes is true.
+func (app *application) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	var updateReq struct {
+		Title            *string         `json:"title"`
+		Description      *string         `json:"description"`
+		OpensAt          json.RawMessage `json:"opens_at"`
+		ExpiresAt        json.RawMessage `json:"expires_at"`
+		Recurrence       *string         `json:"recurrence"`
+		RecurrenceTZ     *string         `json:"recurrence_timezone"`
+		Options          []optionEdit    `json:"options"`
+		AcknowledgeVotes bool            `json:"acknowledge_votes"`
+	}
+
+	err = app.readJSON(w, r, &updateReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if updateReq.Title != nil && strings.TrimSpace(*updateReq.Title) == "" {
+		app.errorJSON(w, errors.New("poll title is required"), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	setOpens, opensAt, err := parseTimeEdit(updateReq.OpensAt, "opens_at", now)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	setExpiry, expiresAt, err := parseTimeEdit(updateReq.ExpiresAt, "expires_at", now)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if updateReq.Options != nil {
+		if len(updateReq.Options) < 2 {
+			app.errorJSON(w, errors.New("at least 2 options are required"), http.StatusBadRequest)
+			return
+		}
+		seen := make(map[int]bool)
+		for i, option := range updateReq.Options {
+			updateReq.Options[i].OptionText = strings.TrimSpace(option.OptionText)
+			if updateReq.Options[i].OptionText == "" {
+				app.errorJSON(w, errors.New("option_text can't be empty"), http.StatusBadRequest)
+				return
+			}
+			if option.ID < 0 || (option.ID > 0 && seen[option.ID]) {
+				app.errorJSON(w, fmt.Errorf("option %d is listed more than once", option.ID), http.StatusBadRequest)
+				return
+			}
+			seen[option.ID] = option.ID > 0
+		}
+	}
+
+	optionsChanged, opened := false, false
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockManagedPoll(r.Context(), tx, pollID, app.contextGetUser(r))
+		if err != nil {
+			return err
+		}
+
+		var changed []string
+		update := tx.Poll.UpdateOne(pollData)
+
+		recurring := pollData.Recurrence != "" || updateReq.Recurrence != nil
+		if recurring && (setOpens || setExpiry) {
+			return newRequestError(http.StatusBadRequest,
+				errors.New("recurring polls can't have opens_at or expires_at; each occurrence closes when the next one starts"))
+		}
+		if updateReq.Recurrence != nil || updateReq.RecurrenceTZ != nil {
+			if err := editRecurrence(pollData, update, updateReq.Recurrence, updateReq.RecurrenceTZ, now); err != nil {
+				return err
+			}
+			changed = append(changed, "recurrence")
+		}
+
+		if updateReq.Title != nil {
+			update.SetTitle(strings.TrimSpace(*updateReq.Title))
+			changed = append(changed, "title")
+		}
+		if updateReq.Description != nil {
+			update.SetDescription(*updateReq.Description)
+			changed = append(changed, "description")
+		}
+		if setOpens {
+			if pollData.State != pollStateDraft {
+				return newRequestError(http.StatusConflict, errors.New("poll has already opened"))
+			}
+			changed = append(changed, "opens_at")
+			if opensAt != nil {
+				update.SetOpensAt(*opensAt)
+			} else {
+				update.SetOpensAt(now).SetState(pollStateOpen)
+				opened = true
+			}
+		}
+		if setExpiry {
+			changed = append(changed, "expires_at")
+			if expiresAt != nil {
+				update.SetExpiresAt(*expiresAt)
+			} else {
+				update.ClearExpiresAt()
+			}
+		}
+
+		// A draft must not expire before it opens
+		opens, expires := pollData.OpensAt, &pollData.ExpiresAt
+		if setOpens {
+			opens = opensAt
+		}
+		if setExpiry {
+			expires = expiresAt
+		}
+		if pollData.State == pollStateDraft && opens != nil && expires != nil && !expires.IsZero() && !expires.After(*opens) {
+			return newRequestError(http.StatusBadRequest, errors.New("expires_at must be after opens_at"))
+		}
+
+		if updateReq.Options != nil {
+			optionsChanged = true
+			if err := editOptions(r.Context(), tx, pollData, update, updateReq.Options, updateReq.AcknowledgeVotes); err != nil {
+				return err
+			}
+			changed = append(changed, "options")
+		}
+
+		if err := update.Exec(r.Context()); err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx.Client(), app.contextGetUser(r), auditPollUpdated, auditTargetPoll, pollID,
+			map[string]any{"fields": changed})
+	})
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	if optionsChanged {
+		app.publishResults(r.Context(), pollID)
+	}
+	if opened {
+		app.notifyPollEvent(r.Context(), pollID, eventPollOpened, "")
+	}
+
+	updatedPoll, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Only(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Poll updated",
+		Data:    updatedPoll,
+	})
+}
+
+// editRecurrence applies a new recurrence or recurrence time zone to a
+// locked poll, adding the changes to its pending update. A poll that isn't
+// recurring yet becomes a template, which it may only do before anyone has
+// voted on it. A closed template stays paused until it is reopened.
+func editRecurrence(pollData *ent.Poll, update *ent.PollUpdateOne, recurrence, tz *string, now time.Time) error {
+	if pollData.Recurrence == "" {
+		if pollData.State == pollStateClosed {
+			return newRequestError(http.StatusConflict, errors.New("closed polls can't become recurring"))
+		}
+		if pollData.TotalVotes > 0 {
+			return newRequestError(http.StatusConflict, errors.New("only polls without votes can become recurring"))
+		}
+	}
+
+	spec, zone := pollData.Recurrence, pollData.RecurrenceTimezone
+	if recurrence != nil {
+		spec = strings.TrimSpace(*recurrence)
+	}
+	if tz != nil {
+		zone = strings.TrimSpace(*tz)
+	}
+	if spec == "" {
+		return newRequestError(http.StatusBadRequest,
+			errors.New("recurrence can't be empty; close or delete the template to stop the series"))
+	}
+	schedule, err := parseRecurrence(spec, zone, now)
+	if err != nil {
+		return newRequestError(http.StatusBadRequest, err)
+	}
+
+	update.SetRecurrence(spec).SetRecurrenceTimezone(zone)
+	if pollData.State != pollStateClosed {
+		update.
+			SetState(pollStateTemplate).
+			SetNextOccurrenceAt(schedule.next(now)).
+			ClearOpensAt().
+			ClearExpiresAt()
+	}
+	return nil
+}
+
+// editOptions applies a complete new option list to a locked poll, adding
+// the matching changes to the poll's pending update
+func editOptions(ctx context.Context, tx *ent.Tx, pollData *ent.Poll, update *ent.PollUpdateOne, edits []optionEdit, acknowledgeVotes bool) error {
+	current, err := tx.PollOption.Query().
+		Where(polloption.HasPollWith(poll.IDEQ(pollData.ID))).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*ent.PollOption, len(current))
+	for _, option := range current {
+		byID[option.ID] = option
+	}
+
+	// Sort the current options into kept-as-is, renamed and removed
+	listed := make(map[int]bool, len(edits))
+	var renamed, removed []*ent.PollOption
+	for _, edit := range edits {
+		if edit.ID == 0 {
+			continue
+		}
+		option, ok := byID[edit.ID]
+		if !ok {
+			return newRequestError(http.StatusBadRequest,
+				fmt.Errorf("option ID %d does not belong to poll %d", edit.ID, pollData.ID))
+		}
+		listed[edit.ID] = true
+		if option.OptionText != edit.OptionText {
+			renamed = append(renamed, option)
+		}
+	}
+	for _, option := range current {
+		if !listed[option.ID] {
+			removed = append(removed, option)
+		}
+	}
+
+	// Changing an option people voted for changes what their vote means
+	if !acknowledgeVotes {
+		var voted []string
+		for _, option := range append(append([]*ent.PollOption{}, renamed...), removed...) {
+			hasVotes, err := tx.Vote.Query().
+				Where(vote.HasOptionWith(polloption.IDEQ(option.ID))).
+				Exist(ctx)
+			if err != nil {
+				return err
+			}
+			if hasVotes {
+				voted = append(voted, fmt.Sprintf("%d (%q)", option.ID, option.OptionText))
+			}
+		}
+		if len(voted) > 0 {
+			return newRequestError(http.StatusConflict, fmt.Errorf(
+				"options %s already have votes; send acknowledge_votes: true to rename or remove them",
+				strings.Join(voted, ", ")))
+		}
+	}
+
+	// Removed options take their votes with them
+	removedVotes := 0
+	for _, option := range removed {
+		if _, err := tx.Vote.Delete().Where(vote.HasOptionWith(polloption.IDEQ(option.ID))).Exec(ctx); err != nil {
+			return err
+		}
+		if err := tx.PollOption.DeleteOne(option).Exec(ctx); err != nil {
+			return err
+		}
+		removedVotes += option.VoteCount
+	}
+
+	texts := make([]string, 0, len(edits))
+	for _, edit := range edits {
+		texts = append(texts, edit.OptionText)
+		if edit.ID == 0 {
+			err := tx.PollOption.Create().
+				SetOptionText(edit.OptionText).
+				SetPoll(pollData).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		} else if byID[edit.ID].OptionText != edit.OptionText {
+			if err := tx.PollOption.UpdateOneID(edit.ID).SetOptionText(edit.OptionText).Exec(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	update.SetSearchText(pollSearchText(texts))
+	if removedVotes > 0 {
+		update.SetTotalVotes(max(0, pollData.TotalVotes-removedVotes))
+	}
+
+	// Keep the ballot size in step with the option count
+	switch pollData.PollType {
+	case pollTypeRating:
+		update.SetMaxVotesPerUser(len(edits))
+	case pollTypeRankedChoice, pollTypeMultipleChoice:
+		if pollData.MaxVotesPerUser > len(edits) {
+			update.SetMaxVotesPerUser(len(edits))
+		}
+	}
+
+	return nil
+}
+
+// ClosePoll ends voting on a poll before its expiry
+func (app *application) ClosePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	var closedPoll *ent.Poll
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockManagedPoll(r.Context(), tx, pollID, app.contextGetUser(r))
+		if err != nil {
+			return err
+		}
+		if pollData.State == pollStateClosed {
+			return newRequestError(http.StatusConflict, errors.New("poll is already closed"))
+		}
+
+		closedPoll, err = tx.Poll.UpdateOne(pollData).
+			SetState(pollStateClosed).
+			SetClosedAt(time.Now()).
+			Save(r.Context())
+		if err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx.Client(), app.contextGetUser(r), auditPollClosed, auditTargetPoll, pollID, nil)
+	})
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	app.notifyPollEvent(r.Context(), pollID, eventPollClosed, "")
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Poll closed",
+		Data:    closedPoll,
+	})
+}
+
+// ReopenPoll starts accepting votes again on a closed or expired poll. An
+// expired poll needs a new expires_at (or null for no expiry) in the body.
+// Reopening a closed recurring template resumes its series from the next
+// occurrence. Webhooks get poll.opened with the action "reopened".
+func (app *application) ReopenPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	var reopenReq struct {
+		ExpiresAt json.RawMessage `json:"expires_at"`
+	}
+
+	// The body is optional
+	err = app.readJSON(w, r, &reopenReq)
+	if err != nil && !errors.Is(err, io.EOF) {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	setExpiry, expiresAt, err := parseTimeEdit(reopenReq.ExpiresAt, "expires_at", now)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	var reopenedPoll *ent.Poll
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockManagedPoll(r.Context(), tx, pollID, app.contextGetUser(r))
+		if err != nil {
+			return err
+		}
+
+		if pollData.State == pollStateDraft {
+			return newRequestError(http.StatusConflict, errors.New("poll hasn't opened yet"))
+		}
+		expired := !pollData.ExpiresAt.IsZero() && now.After(pollData.ExpiresAt)
+		if pollData.State != pollStateClosed && !expired {
+			return newRequestError(http.StatusConflict, errors.New("poll is already open"))
+		}
+		if expired && !setExpiry {
+			return newRequestError(http.StatusBadRequest,
+				errors.New("poll has expired; send a new expires_at (or null) to reopen it"))
+		}
+
+		update := tx.Poll.UpdateOne(pollData).
+			SetState(pollStateOpen).
+			ClearClosedAt()
+		if pollData.Recurrence != "" {
+			if setExpiry {
+				return newRequestError(http.StatusBadRequest, errors.New("recurring polls can't have expires_at"))
+			}
+			schedule, err := parseCronSchedule(pollData.Recurrence, pollData.RecurrenceTimezone)
+			if err != nil {
+				return newRequestError(http.StatusBadRequest, fmt.Errorf("invalid recurrence: %w", err))
+			}
+			update.
+				SetState(pollStateTemplate).
+				SetNextOccurrenceAt(schedule.next(now))
+		}
+		if setExpiry {
+			if expiresAt != nil {
+				update.SetExpiresAt(*expiresAt)
+			} else {
+				update.ClearExpiresAt()
+			}
+		}
+
+		reopenedPoll, err = update.Save(r.Context())
+		if err != nil {
+			return err
+		}
+		return recordAudit(r.Context(), tx.Client(), app.contextGetUser(r), auditPollReopened, auditTargetPoll, pollID, nil)
+	})
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	app.notifyPollEvent(r.Context(), pollID, eventPollOpened, pollActionReopened)
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Poll reopened",
+		Data:    reopenedPoll,
+	})
+}
+
+// DeletePoll permanently removes a poll together with its options and votes
+func (app *application) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	err = withTx(r.Context(), app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockManagedPoll(r.Context(), tx, pollID, app.contextGetUser(r))
+		if err != nil {
+			return err
+		}
+		if err := deletePoll(r.Context(), tx, pollData.ID); err != nil {
+			return err
+		}
+		// The poll is gone, so the entry keeps enough to say what it was
+		return recordAudit(r.Context(), tx.Client(), app.contextGetUser(r), auditPollDeleted, auditTargetPoll, pollID,
+			map[string]any{"title": pollData.Title, "created_by": pollData.CreatedBy, "total_votes": pollData.TotalVotes})
+	})
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Poll deleted",
+	})
+}
+
+// deletePoll removes a poll's votes, then its options, then the poll, so
+// no foreign key is left dangling
+func deletePoll(ctx context.Context, tx *ent.Tx, pollID int) error {
+	if _, err := tx.Vote.Delete().Where(vote.HasPollWith(poll.IDEQ(pollID))).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting votes: %w", err)
+	}
+	if _, err := tx.PollOption.Delete().Where(polloption.HasPollWith(poll.IDEQ(pollID))).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting options: %w", err)
+	}
+	if err := tx.Poll.DeleteOneID(pollID).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting poll: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"backend/ent/vote"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/julienschmidt/httprouter"
+)
+
+// PollResults returns the tabulated results of a poll. Choice polls report
+// each option's total; ranked_choice polls run an instant-runoff count and
+// report every round; rating polls report score statistics per option.
+func (app *application) PollResults(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+
+	if err := app.requestPollAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+	if err := app.requestResultsAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	options := make([]irvTally, 0, len(pollData.Edges.Options))
+	for _, option := range pollData.Edges.Options {
+		options = append(options, irvTally{
+			OptionID:   option.ID,
+			OptionText: option.OptionText,
+			Votes:      option.VoteCount,
+		})
+	}
+
+	payload := struct {
+		PollID   int           `json:"poll_id"`
+		Title    string        `json:"title"`
+		PollType string        `json:"poll_type"`
+		Options  []irvTally    `json:"options"`
+		Runoff   *irvResult    `json:"runoff,omitempty"`
+		Ratings  []ratingStats `json:"ratings,omitempty"`
+	}{
+		PollID:   pollData.ID,
+		Title:    pollData.Title,
+		PollType: pollData.PollType,
+		Options:  options,
+	}
+
+	if pollData.PollType == pollTypeRankedChoice {
+		ballots, err := app.rankedBallots(r.Context(), pollData.ID)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		runoff := runInstantRunoff(options, ballots)
+		payload.Runoff = &runoff
+	}
+
+	if pollData.PollType == pollTypeRating {
+		ratings, err := app.ratingResults(r.Context(), pollData)
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+		payload.Ratings = ratings
+	}
+
+	app.writeJSON(w, http.StatusOK, payload)
+}
+
+// rankedBallots loads every ranked ballot cast on a poll, each as a list of
+// option IDs in preference order
+func (app *application) rankedBallots(ctx context.Context, pollID int) ([][]int, error) {
+	var rows []struct {
+		Voter    string `json:"voter_identifier"`
+		OptionID int    `json:"poll_option_votes"`
+		Rank     int    `json:"rank"`
+	}
+
+	err := app.DB.Vote.Query().
+		Where(vote.HasPollWith(poll.IDEQ(pollID))).
+		Where(vote.RankNotNil()).
+		Order(ent.Asc(vote.FieldVoterIdentifier), ent.Asc(vote.FieldRank)).
+		Select(vote.FieldVoterIdentifier, vote.FieldRank, vote.OptionColumn).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var ballots [][]int
+	for i, row := range rows {
+		if i == 0 || rows[i-1].Voter != row.Voter {
+			ballots = append(ballots, nil)
+		}
+		ballots[len(ballots)-1] = append(ballots[len(ballots)-1], row.OptionID)
+	}
+
+	return ballots, nil
+}
+
+// histogramBucket is how many voters gave an option one particular score
+type histogramBucket struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+// ratingStats summarises the scores given to one option of a rating poll
+type ratingStats struct {
+	OptionID   int               `json:"option_id"`
+	OptionText string            `json:"option_text"`
+	Ratings    int               `json:"ratings"`
+	Mean       float64           `json:"mean"`
+	Median     float64           `json:"median"`
+	StdDev     float64           `json:"std_dev"`
+	Histogram  []histogramBucket `json:"histogram"`
+}
+
+// ratingResults computes per-option statistics for a rating poll (loaded
+// with its options). Count, mean and mean of squares are aggregated by the
+// database, as is the score histogram; the median is then read off the
+// histogram, which has at most one row per point on the scale, so no
+// individual votes are loaded. Plain AVG is used instead of PERCENTILE_CONT
+// or STDDEV so the same queries run on SQLite.
+func (app *application) ratingResults(ctx context.Context, pollData *ent.Poll) ([]ratingStats, error) {
+	var summaries []struct {
+		OptionID   int     `json:"poll_option_votes"`
+		Count      int     `json:"count"`
+		Mean       float64 `json:"mean"`
+		MeanSquare float64 `json:"mean_square"`
+	}
+
+	err := app.DB.Vote.Query().
+		Where(vote.HasPollWith(poll.IDEQ(pollData.ID))).
+		Where(vote.ScoreNotNil()).
+		GroupBy(vote.OptionColumn).
+		Aggregate(
+			ent.As(ent.Count(), "count"),
+			ent.As(ent.Mean(vote.FieldScore), "mean"),
+			func(s *sql.Selector) string {
+				c := s.C(vote.FieldScore)
+				return sql.As(fmt.Sprintf("AVG(%s * %s)", c, c), "mean_square")
+			},
+		).
+		Scan(ctx, &summaries)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []struct {
+		OptionID int `json:"poll_option_votes"`
+		Score    int `json:"score"`
+		Count    int `json:"count"`
+	}
+
+	err = app.DB.Vote.Query().
+		Where(vote.HasPollWith(poll.IDEQ(pollData.ID))).
+		Where(vote.ScoreNotNil()).
+		GroupBy(vote.OptionColumn, vote.FieldScore).
+		Aggregate(ent.As(ent.Count(), "count")).
+		Scan(ctx, &buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	minScore, maxScore := ratingScale(pollData)
+
+	stats := make([]ratingStats, 0, len(pollData.Edges.Options))
+	byOption := make(map[int]*ratingStats, len(pollData.Edges.Options))
+	for _, option := range pollData.Edges.Options {
+		histogram := make([]histogramBucket, 0, maxScore-minScore+1)
+		for score := minScore; score <= maxScore; score++ {
+			histogram = append(histogram, histogramBucket{Score: score})
+		}
+		stats = append(stats, ratingStats{
+			OptionID:   option.ID,
+			OptionText: option.OptionText,
+			Histogram:  histogram,
+		})
+	}
+	for i := range stats {
+		byOption[stats[i].OptionID] = &stats[i]
+	}
+
+	for _, summary := range summaries {
+		st, ok := byOption[summary.OptionID]
+		if !ok {
+			continue
+		}
+		st.Ratings = summary.Count
+		st.Mean = summary.Mean
+		// Population standard deviation: sqrt(E[x^2] - E[x]^2)
+		st.StdDev = math.Sqrt(math.Max(0, summary.MeanSquare-summary.Mean*summary.Mean))
+	}
+
+	for _, bucket := range buckets {
+		st, ok := byOption[bucket.OptionID]
+		if !ok || bucket.Score < minScore || bucket.Score > maxScore {
+			continue
+		}
+		st.Histogram[bucket.Score-minScore].Count = bucket.Count
+	}
+
+	for i := range stats {
+		stats[i].Median = histogramMedian(stats[i].Histogram, stats[i].Ratings)
+	}
+
+	return stats, nil
+}
+
+// histogramMedian returns the median score of a histogram holding total
+// ratings, averaging the two middle scores when total is even
+func histogramMedian(histogram []histogramBucket, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	// 1-based positions of the middle rating(s)
+	lower, upper := (total+1)/2, total/2+1
+	var lowerScore, upperScore int
+	seen := 0
+	for _, bucket := range histogram {
+		if seen < lower && seen+bucket.Count >= lower {
+			lowerScore = bucket.Score
+		}
+		if seen < upper && seen+bucket.Count >= upper {
+			upperScore = bucket.Score
+		}
+		seen += bucket.Count
+	}
+
+	if total%2 == 1 {
+		return float64(lowerScore)
+	}
+	return float64(lowerScore+upperScore) / 2
+}
+
+// Timing of the results stream. Heartbeats keep proxies from closing an idle
+// connection; a client that can't take a write within streamWriteTimeout is
+// dropped.
+const (
+	streamHeartbeat    = 15 * time.Second
+	streamWriteTimeout = 10 * time.Second
+)
+
+// StreamResults pushes a poll's vote counts as Server-Sent Events. The
+// current counts are sent straight away as a "results" event, followed by
+// a new one every time a vote on the poll is cast, changed or withdrawn.
+func (app *application) StreamResults(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.DB.Poll.Get(r.Context(), pollID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+	if err := app.requestPollAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+	if err := app.requestResultsAccess(r, pollData); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	// Subscribe before taking the first snapshot so no vote slips in between
+	sub := app.Results.Subscribe(pollID)
+	defer app.Results.Unsubscribe(sub)
+
+	snapshot, err := app.resultSnapshot(r.Context(), pollID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// send writes one event and flushes it, giving up on clients that
+	// stop reading
+	send := func(event string) error {
+		err := rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		if _, err := io.WriteString(w, event); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+
+	sendResults := func(update resultUpdate) error {
+		data, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+		return send(fmt.Sprintf("event: results\ndata: %s\n\n", data))
+	}
+
+	if err := sendResults(snapshot); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := send(": heartbeat\n\n"); err != nil {
+				return
+			}
+		case update, ok := <-sub.Updates():
+			if !ok {
+				return
+			}
+			if err := sendResults(update); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// seriesOption is one option's count in one occurrence of a recurring poll
+type seriesOption struct {
+	OptionText string  `json:"option_text"`
+	Votes      int     `json:"votes"`
+	Share      float64 `json:"share"` // fraction of the occurrence's votes, 0 to 1
+}
+
+// seriesOccurrence summarises one poll of a recurring series
+type seriesOccurrence struct {
+	PollID        int            `json:"poll_id"`
+	StartedAt     time.Time      `json:"started_at"`
+	ClosedAt      *time.Time     `json:"closed_at,omitempty"`
+	State         string         `json:"state"`
+	TotalVotes    int            `json:"total_votes"`
+	ResultsHidden bool           `json:"results_hidden,omitempty"`
+	Options       []seriesOption `json:"options,omitempty"`
+}
+
+// seriesTrend follows one option, matched by its text, across the
+// occurrences. Entries line up with the occurrences and are null where an
+// occurrence had no such option or its results are hidden from the caller.
+type seriesTrend struct {
+	OptionText string     `json:"option_text"`
+	Votes      []*int     `json:"votes"`
+	Shares     []*float64 `json:"shares"`
+}
+
+// PollSeries returns the occurrences of a recurring poll with each one's
+// results, and the trend of every option across them, so the occurrences
+// can be compared. The ID may be the template's or any occurrence's.
+// Occurrences come oldest first, a page of the most recent at a time:
+//
+//	limit   occurrences per page (default 20, max 100)
+//	cursor  next_cursor from the previous page, to go further back
+func (app *application) PollSeries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid poll ID"), http.StatusBadRequest)
+		return
+	}
+
+	limit, cursor, err := readIDPage(r.URL.Query())
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	pollData, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithTemplate(func(q *ent.PollQuery) {
+			q.WithOptions(func(q *ent.PollOptionQuery) {
+				q.Order(ent.Asc(polloption.FieldID))
+			})
+		}).
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("poll not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+
+	template := pollData.Edges.Template
+	if pollData.Recurrence != "" {
+		template = pollData
+	}
+	if template == nil {
+		if err := app.requestPollAccess(r, pollData); err != nil {
+			app.errorJSON(w, err, statusFromError(err))
+			return
+		}
+		app.errorJSON(w, errors.New("poll isn't part of a recurring series"), http.StatusNotFound)
+		return
+	}
+	if err := app.requestPollAccess(r, template); err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	query := app.DB.Poll.Query().
+		Where(poll.HasTemplateWith(poll.IDEQ(template.ID)))
+
+	total, err := query.Clone().Count(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	if cursor > 0 {
+		query = query.Where(poll.IDLT(cursor))
+	}
+	occurrences, err := query.
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Order(ent.Desc(poll.FieldID)).
+		Limit(limit).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	var nextCursor *string
+	if len(occurrences) > 0 {
+		nextCursor = nextIDCursor(len(occurrences), limit, occurrences[len(occurrences)-1].ID)
+	}
+	slices.Reverse(occurrences)
+
+	// Counts the caller may not see yet are zeroed here
+	views, err := app.pollViews(r.Context(), app.requestVoter(r), occurrences)
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	// Options are followed by text, in the template's current order and
+	// then any the template no longer has
+	var trend []*seriesTrend
+	trendByText := make(map[string]*seriesTrend)
+	addTrend := func(text string) *seriesTrend {
+		t, ok := trendByText[text]
+		if !ok {
+			t = &seriesTrend{
+				OptionText: text,
+				Votes:      make([]*int, len(views)),
+				Shares:     make([]*float64, len(views)),
+			}
+			trendByText[text] = t
+			trend = append(trend, t)
+		}
+		return t
+	}
+	for _, option := range template.Edges.Options {
+		addTrend(option.OptionText)
+	}
+
+	series := make([]seriesOccurrence, len(views))
+	for i, view := range views {
+		series[i] = seriesOccurrence{
+			PollID:        view.ID,
+			StartedAt:     view.CreatedAt,
+			ClosedAt:      view.ClosedAt,
+			State:         view.State,
+			TotalVotes:    view.TotalVotes,
+			ResultsHidden: view.ResultsHidden,
+		}
+		for _, option := range view.Edges.Options {
+			t := addTrend(option.OptionText)
+			if view.ResultsHidden {
+				continue
+			}
+
+			share := 0.0
+			if view.TotalVotes > 0 {
+				share = float64(option.VoteCount) / float64(view.TotalVotes)
+			}
+			series[i].Options = append(series[i].Options, seriesOption{
+				OptionText: option.OptionText,
+				Votes:      option.VoteCount,
+				Share:      share,
+			})
+			t.Votes[i] = &option.VoteCount
+			t.Shares[i] = &share
+		}
+	}
+
+	_ = app.writeJSON(w, http.StatusOK, struct {
+		Template    *ent.Poll          `json:"template"`
+		Occurrences []seriesOccurrence `json:"occurrences"`
+		Trend       []*seriesTrend     `json:"trend"`
+		NextCursor  *string            `json:"next_cursor"`
+		Total       int                `json:"total"`
+	}{
+		Template:    template,
+		Occurrences: series,
+		Trend:       trend,
+		NextCursor:  nextCursor,
+		Total:       total,
+	})
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/user"
+	"backend/ent/webhook"
+	"backend/ent/webhookdelivery"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// minWebhookSecretLen is the shortest secret accepted from clients
+const minWebhookSecretLen = 16
+
+// webhookResolveTimeout bounds the DNS lookup of a webhook's host
+const webhookResolveTimeout = 5 * time.Second
+
+// validateWebhookURL checks a webhook endpoint is an absolute http(s) URL
+// whose host is, or resolves only to, public addresses. Delivery checks the
+// address again when it connects, as DNS answers can change.
+func (app *application) validateWebhookURL(ctx context.Context, raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+	if len(raw) > 2048 {
+		return errors.New("url must be at most 2048 characters")
+	}
+	if app.Config.WebhookAllowPrivate {
+		return nil
+	}
+
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !isPublicAddress(ip) {
+			return errWebhookAddress
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, webhookResolveTimeout)
+	defer cancel()
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("url host %q could not be resolved", host)
+	}
+	for _, ip := range ips {
+		if !isPublicAddress(ip) {
+			return errWebhookAddress
+		}
+	}
+	return nil
+}
+
+// validateWebhookEvents checks an event filter names known events only
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return errors.New("at least one event must be selected")
+	}
+	for _, event := range events {
+		if !slices.Contains(webhookEvents, event) {
+			return fmt.Errorf("unknown event %q, expected one of %s", event, strings.Join(webhookEvents, ", "))
+		}
+	}
+	return nil
+}
+
+// generateWebhookSecret returns a random signing secret
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// findOwnedWebhook loads one of u's webhooks. Other users' webhooks are
+// reported as missing so their IDs don't leak.
+func (app *application) findOwnedWebhook(ctx context.Context, id int, u *ent.User) (*ent.Webhook, error) {
+	hook, err := app.DB.Webhook.Query().
+		Where(webhook.IDEQ(id)).
+		Where(webhook.HasOwnerWith(user.IDEQ(u.ID))).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, newRequestError(http.StatusNotFound, errors.New("webhook not found"))
+		}
+		return nil, err
+	}
+	return hook, nil
+}
+
+// ListWebhooks returns the authenticated user's webhooks
+func (app *application) ListWebhooks(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	hooks, err := app.contextGetUser(r).QueryWebhooks().
+		Order(ent.Asc(webhook.FieldID)).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, hooks)
+}
+
+// CreateWebhook subscribes a URL to events on the authenticated user's
+// polls. The signing secret is generated unless one is supplied, and is
+// only ever returned in this response.
+func (app *application) CreateWebhook(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var createReq struct {
+		URL    string   `json:"url"`
+		Events []string `json:"events"`
+		Secret string   `json:"secret"`
+	}
+
+	err := app.readJSON(w, r, &createReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	createReq.URL = strings.TrimSpace(createReq.URL)
+	if err := app.validateWebhookURL(r.Context(), createReq.URL); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := validateWebhookEvents(createReq.Events); err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if createReq.Secret == "" {
+		createReq.Secret, err = generateWebhookSecret()
+		if err != nil {
+			app.errorJSON(w, err)
+			return
+		}
+	} else if len(createReq.Secret) < minWebhookSecretLen {
+		app.errorJSON(w, fmt.Errorf("secret must be at least %d characters", minWebhookSecretLen), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.DB.Webhook.Create().
+		SetURL(createReq.URL).
+		SetEvents(createReq.Events).
+		SetSecret(createReq.Secret).
+		SetOwner(app.contextGetUser(r)).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusCreated, JSONResponse{
+		Error:   false,
+		Message: "Webhook created",
+		Data: struct {
+			*ent.Webhook
+			Secret string `json:"secret"`
+		}{hook, hook.Secret},
+	})
+}
+
+// GetWebhook returns one of the authenticated user's webhooks
+func (app *application) GetWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, hook)
+}
+
+// UpdateWebhook changes a webhook's URL, event filter or active flag.
+// Fields left out of the request are unchanged.
+func (app *application) UpdateWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+
+	var updateReq struct {
+		URL    *string  `json:"url"`
+		Events []string `json:"events"`
+		Active *bool    `json:"active"`
+	}
+
+	err = app.readJSON(w, r, &updateReq)
+	if err != nil {
+		app.errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	update := hook.Update()
+	if updateReq.URL != nil {
+		u := strings.TrimSpace(*updateReq.URL)
+		if err := app.validateWebhookURL(r.Context(), u); err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		update.SetURL(u)
+	}
+	if updateReq.Events != nil {
+		if err := validateWebhookEvents(updateReq.Events); err != nil {
+			app.errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		update.SetEvents(updateReq.Events)
+	}
+	if updateReq.Active != nil {
+		update.SetActive(*updateReq.Active)
+	}
+
+	hook, err = update.Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Webhook updated",
+		Data:    hook,
+	})
+}
+
+// DeleteWebhook removes a webhook along with its delivery log
+func (app *application) DeleteWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	if err := app.DB.Webhook.DeleteOne(hook).Exec(r.Context()); err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, JSONResponse{
+		Error:   false,
+		Message: "Webhook deleted",
+	})
+}
+
+// WebhookDeliveries returns a webhook's delivery log, newest first.
+// Query parameters: status (pending, succeeded or failed), limit.
+func (app *application) WebhookDeliveries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+
+	qs := r.URL.Query()
+
+	limit := defaultPageSize
+	if v := qs.Get("limit"); v != "" {
+		limit, err = strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			app.errorJSON(w, fmt.Errorf("limit must be between 1 and %d", maxPageSize), http.StatusBadRequest)
+			return
+		}
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	query := hook.QueryDeliveries()
+	switch status := qs.Get("status"); status {
+	case "":
+	case deliveryPending, deliverySucceeded, deliveryFailed:
+		query = query.Where(webhookdelivery.StatusEQ(status))
+	default:
+		app.errorJSON(w, errors.New("status must be 'pending', 'succeeded' or 'failed'"), http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := query.
+		Order(ent.Desc(webhookdelivery.FieldID)).
+		Limit(limit).
+		All(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	app.writeJSON(w, http.StatusOK, deliveries)
+}
+
+// RedeliverWebhook queues a fresh copy of a logged delivery, whatever its
+// outcome was. The original entry stays in the log untouched.
+func (app *application) RedeliverWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+	deliveryID, err := strconv.Atoi(ps.ByName("delivery_id"))
+	if err != nil || deliveryID < 1 {
+		app.errorJSON(w, errors.New("invalid delivery ID"), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	original, err := hook.QueryDeliveries().
+		Where(webhookdelivery.IDEQ(deliveryID)).
+		Only(r.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			app.errorJSON(w, errors.New("delivery not found"), http.StatusNotFound)
+		} else {
+			app.errorJSON(w, err)
+		}
+		return
+	}
+
+	delivery, err := app.DB.WebhookDelivery.Create().
+		SetWebhook(hook).
+		SetEvent(original.Event).
+		SetPayload(original.Payload).
+		SetNextAttemptAt(time.Now()).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	app.Webhooks.notify()
+
+	app.writeJSON(w, http.StatusAccepted, JSONResponse{
+		Error:   false,
+		Message: "Delivery queued",
+		Data:    delivery,
+	})
+}
+
+// PingWebhook queues a "ping" event, to check the endpoint receives and
+// verifies deliveries
+func (app *application) PingWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := app.readIDParam(ps)
+	if err != nil {
+		app.errorJSON(w, errors.New("invalid webhook ID"), http.StatusBadRequest)
+		return
+	}
+
+	hook, err := app.findOwnedWebhook(r.Context(), id, app.contextGetUser(r))
+	if err != nil {
+		app.errorJSON(w, err, statusFromError(err))
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		Event:      eventPing,
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+
+	delivery, err := app.DB.WebhookDelivery.Create().
+		SetWebhook(hook).
+		SetEvent(eventPing).
+		SetPayload(string(payload)).
+		SetNextAttemptAt(time.Now()).
+		Save(r.Context())
+	if err != nil {
+		app.errorJSON(w, err)
+		return
+	}
+	app.Webhooks.notify()
+
+	app.writeJSON(w, http.StatusAccepted, JSONResponse{
+		Error:   false,
+		Message: "Ping queued",
+		Data:    delivery,
+	})
+}
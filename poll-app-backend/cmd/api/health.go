@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Build metadata, set at link time:
+//
+//	go build -ldflags "-X main.version=1.4.0 -X main.commit=$(git rev-parse HEAD) \
+//		-X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/api
+//
+// When they aren't set, the commit and date the Go toolchain stamps into
+// binaries built from a git checkout are used instead.
+var (
+	version   = "dev"
+	commit    = ""
+	buildDate = ""
+)
+
+// buildInfo describes the running binary
+type buildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // built from a checkout with uncommitted changes
+}
+
+// currentBuild returns the metadata of the running binary
+func currentBuild() buildInfo {
+	info := buildInfo{
+		Version:   version,
+		Commit:    commit,
+		BuildDate: buildDate,
+		GoVersion: runtime.Version(),
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildDate == "" {
+					info.BuildDate = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+	return info
+}
+
+// readinessTimeout bounds the database checks of one /readyz request, so
+// a hung database fails the probe instead of hanging it
+const readinessTimeout = 2 * time.Second
+
+// Healthz reports that the process is up and serving requests. It checks
+// nothing else, so a database outage doesn't get healthy instances
+// restarted; that is what Readyz is for.
+func (app *application) Healthz(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	_ = app.writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz reports whether this instance should get traffic: it isn't
+// draining for shutdown, the database answers, and every migration the
+// binary ships with has been applied. It answers 503 when any check fails,
+// naming the ones that did; details go to the log rather than to callers.
+func (app *application) Readyz(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]string{
+		"serving":    "ok",
+		"database":   "ok",
+		"migrations": "ok",
+	}
+	ready := true
+	fail := func(check, status string) {
+		checks[check] = status
+		ready = false
+	}
+
+	if !app.ready.Load() {
+		fail("serving", "draining")
+	}
+
+	if _, err := app.DB.ExecContext(ctx, "SELECT 1"); err != nil {
+		log.Printf("Warning: readiness check: database unreachable: %v", err)
+		fail("database", "unreachable")
+		fail("migrations", "unknown")
+	} else if pending, err := app.pendingMigrations(ctx); err != nil {
+		log.Printf("Warning: readiness check: reading migrations: %v", err)
+		fail("migrations", "unknown")
+	} else if pending > 0 {
+		fail("migrations", fmt.Sprintf("%d pending", pending))
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	_ = app.writeJSON(w, code, struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks"`
+	}{status, checks})
+}
+
+// pendingMigrations counts the migrations this binary ships with that the
+// database hasn't had
+func (app *application) pendingMigrations(ctx context.Context) (int, error) {
+	files, err := embeddedMigrations()
+	if err != nil {
+		return 0, err
+	}
+	applied, err := appliedVersions(ctx, app.DB)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(unapplied(files, applied)), nil
+}
+
+// Version returns the build metadata of the running binary
+func (app *application) Version(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	_ = app.writeJSON(w, http.StatusOK, currentBuild())
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/enttest"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestApp returns an application backed by a fresh SQLite database with
+// the ent schema. The database is a file rather than in memory so
+// concurrent transactions serialize on SQLite's write lock instead of
+// failing, the way row locks make them wait on PostgreSQL.
+func newTestApp(t *testing.T) *application {
+	t.Helper()
+
+	dsn := fmt.Sprintf("file:%s?_fk=1&_busy_timeout=5000&_txlock=immediate",
+		filepath.Join(t.TempDir(), "polls.db"))
+	client := enttest.Open(t, dialect.SQLite, dsn)
+	t.Cleanup(func() { client.Close() })
+
+	// Deliveries may reach loopback, where test receivers listen; webhook
+	// URLs are still validated as in production
+	app := &application{
+		DB:          client,
+		Dialect:     dialect.SQLite,
+		TokenSecret: []byte("test-secret"),
+		Searcher:    newPollSearcher(dialect.SQLite, client),
+		Results:     newMemoryBroker(),
+		Webhooks:    newWebhookDispatcher(client, dialect.SQLite, true),
+	}
+	t.Cleanup(app.Results.Close)
+	return app
+}
+
+// createTestUser adds a creator account with the given email
+func createTestUser(t *testing.T, app *application, email string) *ent.User {
+	t.Helper()
+
+	u, err := app.DB.User.Create().
+		SetEmail(email).
+		SetPassword("unused").
+		SetRole(roleCreator).
+		Save(t.Context())
+	if err != nil {
+		t.Fatalf("creating user %s: %v", email, err)
+	}
+	return u
+}
+
+// deleteTestAccount deletes u's account through DeleteAccount
+func deleteTestAccount(t *testing.T, app *application, u *ent.User) {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodDelete, "/account", strings.NewReader(`{"password": "unused"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.DeleteAccount(rec, app.contextSetUser(req, u), nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("deleting account %s got status %d: %s", u.Email, rec.Code, rec.Body)
+	}
+}
+
+// createTestPoll adds an open public poll of pollType owned by owner, with
+// one option per text
+func createTestPoll(t *testing.T, app *application, owner *ent.User, pollType string, texts ...string) (*ent.Poll, []*ent.PollOption) {
+	t.Helper()
+	ctx := t.Context()
+
+	p, err := app.DB.Poll.Create().
+		SetTitle("Test poll").
+		SetPollType(pollType).
+		SetMaxVotesPerUser(len(texts)).
+		SetCreatedBy(owner.Email).
+		SetOwner(owner).
+		Save(ctx)
+	if err != nil {
+		t.Fatalf("creating poll: %v", err)
+	}
+
+	builders := make([]*ent.PollOptionCreate, 0, len(texts))
+	for _, text := range texts {
+		builders = append(builders, app.DB.PollOption.Create().SetOptionText(text).SetPoll(p))
+	}
+	options, err := app.DB.PollOption.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		t.Fatalf("creating options: %v", err)
+	}
+	return p, options
+}
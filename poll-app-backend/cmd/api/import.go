@@ -0,0 +1,384 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/user"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// Import file formats
+const (
+	importCSV  = "csv"
+	importJSON = "json"
+)
+
+// maxImportRows caps how many polls one import may create
+const maxImportRows = 1000
+
+// importOptionSeparator splits the "options" and "invited_emails" columns of
+// a CSV import
+const importOptionSeparator = "|"
+
+// importRow is one poll read from an import file. Row is the CSV line or
+// the 1-based position in the JSON array, for the report.
+type importRow struct {
+	Row  int
+	Poll pollInput
+	Err  error
+}
+
+// importRowError says why one row of an import was rejected
+type importRowError struct {
+	Row   int    `json:"row"`
+	Title string `json:"title,omitempty"`
+	Error string `json:"error"`
+}
+
+// importReport is the outcome of an import. Imports are all or nothing: if
+// any row fails, Created is 0 and Errors lists every failing row.
+type importReport struct {
+	DryRun  bool             `json:"dry_run"`
+	Total   int              `json:"total"`
+	Valid   int              `json:"valid"`
+	Created int              `json:"created"`
+	PollIDs []int            `json:"poll_ids,omitempty"`
+	Errors  []importRowError `json:"errors"`
+}
+
+// readImportFile parses polls from a CSV or JSON import file.
+//
+// JSON files hold an array of objects shaped like the POST /polls body.
+// CSV files have a header row naming the columns title, description,
+// poll_type, max_votes_per_user, opens_at, expires_at, allow_vote_changes,
+// rating_min, rating_max, visibility, results_visibility, invited_emails,
+// recurrence, recurrence_timezone and options, in any order; only title
+// and options are required. Options and invited emails are separated by
+// "|", and options may instead be spread across extra columns named
+// option_1, option_2, and so on.
+func readImportFile(r io.Reader, format string) ([]importRow, error) {
+	var rows []importRow
+	var err error
+	switch format {
+	case importJSON:
+		rows, err = readImportJSON(r)
+	case importCSV:
+		rows, err = readImportCSV(r)
+	default:
+		return nil, errors.New("format must be 'csv' or 'json'")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("the file contains no polls")
+	}
+	if len(rows) > maxImportRows {
+		return nil, fmt.Errorf("an import can hold at most %d polls", maxImportRows)
+	}
+	return rows, nil
+}
+
+func readImportJSON(r io.Reader) ([]importRow, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("the file must be a JSON array of polls: %w", err)
+	}
+
+	rows := make([]importRow, 0, len(raw))
+	for i, item := range raw {
+		row := importRow{Row: i + 1}
+		dec := json.NewDecoder(bytes.NewReader(item))
+		dec.DisallowUnknownFields()
+		row.Err = dec.Decode(&row.Poll)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readImportCSV(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the file is empty")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == "title", name == "description", name == "poll_type",
+			name == "max_votes_per_user", name == "opens_at", name == "expires_at", name == "allow_vote_changes",
+			name == "rating_min", name == "rating_max", name == "visibility",
+			name == "results_visibility", name == "invited_emails", name == "options",
+			name == "recurrence", name == "recurrence_timezone",
+			strings.HasPrefix(name, "option_"):
+		default:
+			return nil, fmt.Errorf("unknown column %q", header[i])
+		}
+		if _, dup := columns[name]; dup {
+			return nil, fmt.Errorf("column %q appears twice", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("the header must have a title column")
+	}
+
+	var rows []importRow
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := importRow{Row: line}
+		row.Poll, row.Err = pollFromCSV(header, columns, record)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// pollFromCSV builds a poll definition from one CSV record
+func pollFromCSV(header []string, columns map[string]int, record []string) (pollInput, error) {
+	var in pollInput
+
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	getInt := func(name string) (*int, error) {
+		v := get(name)
+		if v == "" {
+			return nil, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number", name)
+		}
+		return &n, nil
+	}
+
+	in.Title = get("title")
+	in.Description = get("description")
+	in.PollType = get("poll_type")
+	in.Visibility = get("visibility")
+	in.ResultsVisibility = get("results_visibility")
+	in.Recurrence = get("recurrence")
+	in.RecurrenceTZ = get("recurrence_timezone")
+
+	maxVotes, err := getInt("max_votes_per_user")
+	if err != nil {
+		return in, err
+	}
+	if maxVotes != nil {
+		in.MaxVotesPerUser = *maxVotes
+	}
+
+	if v := get("opens_at"); v != "" {
+		in.OpensAt = &v
+	}
+	if v := get("expires_at"); v != "" {
+		in.ExpiresAt = &v
+	}
+
+	if v := get("allow_vote_changes"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return in, errors.New("allow_vote_changes must be true or false")
+		}
+		in.AllowVoteChanges = &allow
+	}
+
+	if in.RatingMin, err = getInt("rating_min"); err != nil {
+		return in, err
+	}
+	if in.RatingMax, err = getInt("rating_max"); err != nil {
+		return in, err
+	}
+
+	if v := get("invited_emails"); v != "" {
+		for _, email := range strings.Split(v, importOptionSeparator) {
+			if email = strings.TrimSpace(email); email != "" {
+				in.InvitedEmails = append(in.InvitedEmails, email)
+			}
+		}
+	}
+
+	if v := get("options"); v != "" {
+		for _, option := range strings.Split(v, importOptionSeparator) {
+			if option = strings.TrimSpace(option); option != "" {
+				in.Options = append(in.Options, option)
+			}
+		}
+	}
+	// option_N columns, in header order
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if strings.HasPrefix(name, "option_") && i < len(record) {
+			if option := strings.TrimSpace(record[i]); option != "" {
+				in.Options = append(in.Options, option)
+			}
+		}
+	}
+
+	return in, nil
+}
+
+// importPolls validates every row with the rules CreatePoll applies and,
+// unless dryRun, creates all the polls in a single transaction. Rows that
+// fail are reported, not returned as an error; the returned error is for
+// failures that aren't the file's fault.
+func (app *application) importPolls(ctx context.Context, rows []importRow, owner *ent.User, dryRun bool) (*importReport, error) {
+	report := &importReport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Errors: []importRowError{},
+	}
+
+	now := time.Now()
+	for i := range rows {
+		row := &rows[i]
+		if row.Err == nil {
+			row.Err = row.Poll.validate(now)
+		}
+		if row.Err != nil {
+			report.Errors = append(report.Errors, importRowError{
+				Row:   row.Row,
+				Title: row.Poll.Title,
+				Error: row.Err.Error(),
+			})
+			continue
+		}
+		report.Valid++
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	err := withTx(ctx, app.DB, func(tx *ent.Tx) error {
+		for i := range rows {
+			created, err := createPoll(ctx, tx.Client(), &rows[i].Poll, owner)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", rows[i].Row, err)
+			}
+			report.PollIDs = append(report.PollIDs, created.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Created = len(report.PollIDs)
+	pollsCreated.WithLabelValues(pollSourceImport).Add(float64(report.Created))
+
+	for _, id := range report.PollIDs {
+		app.notifyPollEvent(ctx, id, eventPollCreated, "")
+	}
+
+	return report, nil
+}
+
+// runImportCommand implements "api import": it imports polls from a file
+// into the database, printing the report as JSON. It exits non-zero if any
+// row was rejected.
+func runImportCommand(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dsn := fs.String("dsn", envDSN(), "PostgreSQL connection string (defaults to $POLLS_DSN)")
+	ownerEmail := fs.String("owner", "", "Email of the user who will own the imported polls (required)")
+	format := fs.String("format", "", "File format, csv or json (defaults to the file extension)")
+	dryRun := fs.Bool("dry-run", false, "Validate the file without creating anything")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: api import -owner EMAIL [-dry-run] [-format csv|json] [-dsn DSN] FILE")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 || *ownerEmail == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed opening import file: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := readImportFile(f, *format)
+	if err != nil {
+		log.Fatalf("failed reading %s: %v", path, err)
+	}
+
+	ctx := context.Background()
+	if err := requireSchemaCurrent(ctx, *dsn); err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := ent.Open(dialect.Postgres, *dsn)
+	if err != nil {
+		log.Fatalf("failed opening connection to postgres: %v", err)
+	}
+	defer client.Close()
+
+	owner, err := client.User.Query().
+		Where(user.EmailEqualFold(strings.TrimSpace(*ownerEmail))).
+		Only(ctx)
+	if err != nil {
+		log.Fatalf("failed finding owner %s: %v", *ownerEmail, err)
+	}
+	if !canCreatePolls(owner) {
+		log.Fatalf("%s has the %s role, which can't own polls", owner.Email, owner.Role)
+	}
+
+	// Deliveries for poll.created are queued here and sent by the server
+	app := &application{
+		DB:       client,
+		Dialect:  dialect.Postgres,
+		Results:  newMemoryBroker(),
+		Webhooks: newWebhookDispatcher(client, dialect.Postgres, false),
+	}
+
+	report, err := app.importPolls(ctx, rows, owner, *dryRun)
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+
+	out, _ := json.MarshalIndent(report, "", "  ")
+	fmt.Println(string(out))
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
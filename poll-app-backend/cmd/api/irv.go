@@ -0,0 +1,159 @@
+package main
+
+import "sort"
+
+// irvTally is one option's count in an instant-runoff round
+type irvTally struct {
+	OptionID   int    `json:"option_id"`
+	OptionText string `json:"option_text"`
+	Votes      int    `json:"votes"`
+}
+
+// irvRound is the state of the count after one round of instant-runoff
+type irvRound struct {
+	Round      int        `json:"round"`
+	Tallies    []irvTally `json:"tallies"`
+	Exhausted  int        `json:"exhausted"`
+	Eliminated *int       `json:"eliminated,omitempty"`
+	TieBroken  bool       `json:"tie_broken"`
+	Winner     *int       `json:"winner,omitempty"`
+}
+
+// irvResult is the full outcome of an instant-runoff count
+type irvResult struct {
+	Ballots int        `json:"ballots"`
+	Rounds  []irvRound `json:"rounds"`
+	Winner  *int       `json:"winner"`
+}
+
+// runInstantRunoff tabulates ranked ballots (each an ordered list of option
+// IDs, most preferred first) over the given options.
+//
+// Each round every ballot counts for its highest-ranked option still in the
+// race. An option with more than half of the non-exhausted ballots wins; if
+// only one option is left it wins. Otherwise the option with the fewest votes
+// is eliminated, one per round.
+//
+// Ties for last place are broken deterministically: the tied option that had
+// fewer votes in the most recent earlier round where they differed is
+// eliminated, and if they were level in every round the one listed last in
+// the poll (the highest option ID) goes.
+func runInstantRunoff(options []irvTally, ballots [][]int) irvResult {
+	result := irvResult{Ballots: len(ballots), Rounds: []irvRound{}}
+	if len(options) == 0 || len(ballots) == 0 {
+		return result
+	}
+
+	// Options compete in ID order so output and tie-breaks are stable
+	order := make([]irvTally, len(options))
+	copy(order, options)
+	sort.Slice(order, func(i, j int) bool { return order[i].OptionID < order[j].OptionID })
+
+	continuing := make(map[int]bool, len(order))
+	for _, option := range order {
+		continuing[option.OptionID] = true
+	}
+
+	// history[r][optionID] holds the votes each option got in round r
+	var history []map[int]int
+
+	for roundNum := 1; ; roundNum++ {
+		counts := make(map[int]int, len(continuing))
+		exhausted := 0
+		for _, ballot := range ballots {
+			counted := false
+			for _, optionID := range ballot {
+				if continuing[optionID] {
+					counts[optionID]++
+					counted = true
+					break
+				}
+			}
+			if !counted {
+				exhausted++
+			}
+		}
+		history = append(history, counts)
+
+		round := irvRound{Round: roundNum, Exhausted: exhausted}
+		active := 0
+		for _, option := range order {
+			if !continuing[option.OptionID] {
+				continue
+			}
+			round.Tallies = append(round.Tallies, irvTally{
+				OptionID:   option.OptionID,
+				OptionText: option.OptionText,
+				Votes:      counts[option.OptionID],
+			})
+			active += counts[option.OptionID]
+		}
+
+		// Majority of ballots still in play, or last option standing
+		for _, tally := range round.Tallies {
+			if (active > 0 && tally.Votes*2 > active) || len(round.Tallies) == 1 {
+				winner := tally.OptionID
+				round.Winner = &winner
+				result.Winner = &winner
+				break
+			}
+		}
+		if round.Winner != nil {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+
+		loser, tieBroken := irvPickLoser(round.Tallies, history)
+		round.Eliminated = &loser
+		round.TieBroken = tieBroken
+		delete(continuing, loser)
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+// irvPickLoser chooses which option to eliminate from this round's tallies,
+// applying the tie-break rule described on runInstantRunoff
+func irvPickLoser(tallies []irvTally, history []map[int]int) (int, bool) {
+	lowest := tallies[0].Votes
+	for _, tally := range tallies[1:] {
+		if tally.Votes < lowest {
+			lowest = tally.Votes
+		}
+	}
+
+	var tied []int
+	for _, tally := range tallies {
+		if tally.Votes == lowest {
+			tied = append(tied, tally.OptionID)
+		}
+	}
+	if len(tied) == 1 {
+		return tied[0], false
+	}
+
+	// Walk back through earlier rounds, keeping only the options that did worst
+	for r := len(history) - 2; r >= 0 && len(tied) > 1; r-- {
+		fewest := history[r][tied[0]]
+		for _, optionID := range tied[1:] {
+			if history[r][optionID] < fewest {
+				fewest = history[r][optionID]
+			}
+		}
+		var worst []int
+		for _, optionID := range tied {
+			if history[r][optionID] == fewest {
+				worst = append(worst, optionID)
+			}
+		}
+		tied = worst
+	}
+
+	// Still level: eliminate the option added to the poll last
+	loser := tied[0]
+	for _, optionID := range tied[1:] {
+		if optionID > loser {
+			loser = optionID
+		}
+	}
+	return loser, true
+}
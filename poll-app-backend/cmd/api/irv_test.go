@@ -0,0 +1,186 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// repeatBallot returns n copies of ballot
+func repeatBallot(n int, ballot ...int) [][]int {
+	ballots := make([][]int, n)
+	for i := range ballots {
+		ballots[i] = ballot
+	}
+	return ballots
+}
+
+func TestRunInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []int
+		ballots [][]int
+
+		winner     int // 0 for no winner
+		eliminated []int
+		tieBroken  []bool
+		exhausted  []int
+	}{
+		{
+			name:    "no ballots",
+			options: []int{1, 2},
+		},
+		{
+			name:      "single option wins outright",
+			options:   []int{1},
+			ballots:   repeatBallot(1, 1),
+			winner:    1,
+			exhausted: []int{0},
+		},
+		{
+			name:      "first round majority",
+			options:   []int{1, 2, 3},
+			ballots:   slices.Concat(repeatBallot(2, 1), repeatBallot(1, 2)),
+			winner:    1,
+			exhausted: []int{0},
+		},
+		{
+			name:       "exactly half is not a majority",
+			options:    []int{1, 2},
+			ballots:    slices.Concat(repeatBallot(1, 1), repeatBallot(1, 2)),
+			winner:     1,
+			eliminated: []int{2},
+			tieBroken:  []bool{true},
+			exhausted:  []int{0, 1},
+		},
+		{
+			name:    "transfers overtake the first round leader",
+			options: []int{1, 2, 3},
+			ballots: slices.Concat(
+				repeatBallot(4, 1),
+				repeatBallot(3, 2),
+				repeatBallot(2, 3, 2),
+			),
+			winner:     2,
+			eliminated: []int{3},
+			tieBroken:  []bool{false},
+			exhausted:  []int{0, 0},
+		},
+		{
+			name:    "exhausted ballots lower the majority threshold",
+			options: []int{1, 2, 3},
+			ballots: slices.Concat(
+				repeatBallot(3, 1),
+				repeatBallot(2, 2),
+				repeatBallot(2, 3),
+			),
+			// 3 of 7 isn't a majority; once 3's ballots exhaust, 3 of 5 is
+			winner:     1,
+			eliminated: []int{3},
+			tieBroken:  []bool{true},
+			exhausted:  []int{0, 2},
+		},
+		{
+			name:    "tie broken by the most recent round where the options differed",
+			options: []int{1, 2, 3, 4},
+			ballots: slices.Concat(
+				repeatBallot(5, 1),
+				repeatBallot(3, 2),
+				repeatBallot(2, 3),
+				repeatBallot(1, 4, 3),
+			),
+			// 2 and 3 tie on 3 in round 2, but 3 had fewer in round 1
+			winner:     1,
+			eliminated: []int{4, 3},
+			tieBroken:  []bool{false, true},
+			exhausted:  []int{0, 0, 3},
+		},
+		{
+			name:    "tie level in every round eliminates the highest option ID",
+			options: []int{3, 1, 2},
+			ballots: slices.Concat(
+				repeatBallot(2, 1),
+				repeatBallot(1, 2, 1),
+				repeatBallot(1, 3, 1),
+			),
+			winner:     1,
+			eliminated: []int{3},
+			tieBroken:  []bool{true},
+			exhausted:  []int{0, 0},
+		},
+		{
+			name:      "ballots naming no option are exhausted from the start",
+			options:   []int{1, 2},
+			ballots:   slices.Concat(repeatBallot(2, 99), repeatBallot(1, 2)),
+			winner:    2,
+			exhausted: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := make([]irvTally, len(tt.options))
+			for i, id := range tt.options {
+				options[i] = irvTally{OptionID: id}
+			}
+
+			result := runInstantRunoff(options, tt.ballots)
+
+			if result.Ballots != len(tt.ballots) {
+				t.Errorf("Ballots = %d, want %d", result.Ballots, len(tt.ballots))
+			}
+			winner := 0
+			if result.Winner != nil {
+				winner = *result.Winner
+			}
+			if winner != tt.winner {
+				t.Errorf("winner = %d, want %d", winner, tt.winner)
+			}
+
+			var eliminated []int
+			var tieBroken []bool
+			var exhausted []int
+			for i, round := range result.Rounds {
+				if round.Round != i+1 {
+					t.Errorf("round %d is numbered %d", i+1, round.Round)
+				}
+				exhausted = append(exhausted, round.Exhausted)
+				if round.Eliminated != nil {
+					eliminated = append(eliminated, *round.Eliminated)
+					tieBroken = append(tieBroken, round.TieBroken)
+				}
+			}
+			if !slices.Equal(eliminated, tt.eliminated) {
+				t.Errorf("eliminated %v, want %v", eliminated, tt.eliminated)
+			}
+			if !slices.Equal(tieBroken, tt.tieBroken) {
+				t.Errorf("tie broken %v, want %v", tieBroken, tt.tieBroken)
+			}
+			if !slices.Equal(exhausted, tt.exhausted) {
+				t.Errorf("exhausted per round %v, want %v", exhausted, tt.exhausted)
+			}
+
+			// Only the last round may name a winner
+			for _, round := range result.Rounds[:max(len(result.Rounds)-1, 0)] {
+				if round.Winner != nil {
+					t.Errorf("round %d names a winner before the last round", round.Round)
+				}
+			}
+		})
+	}
+}
+
+// TestIRVPickLoserWalksBack checks a three-way tie narrows round by round:
+// the options level in an earlier round stay tied until one falls behind
+func TestIRVPickLoserWalksBack(t *testing.T) {
+	tallies := []irvTally{{OptionID: 1, Votes: 2}, {OptionID: 2, Votes: 2}, {OptionID: 3, Votes: 2}}
+	history := []map[int]int{
+		{1: 1, 2: 3, 3: 1}, // round 1: 1 and 3 did worst
+		{1: 2, 2: 2, 3: 2}, // round 2: all level
+		{1: 2, 2: 2, 3: 2}, // this round
+	}
+
+	loser, tieBroken := irvPickLoser(tallies, history)
+	if loser != 3 || !tieBroken {
+		t.Errorf("irvPickLoser = %d, %v; want 3, true", loser, tieBroken)
+	}
+}
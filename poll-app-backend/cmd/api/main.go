@@ -2,71 +2,163 @@ package main
 
 import (
 	"backend/ent"
+	"backend/ent/user"
 	"context"
+	crand "crypto/rand"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"math/rand"
-	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	_ "github.com/lib/pq"
 )
 
-// import (
-//
-//	"fmt"
-//
-// )
-const port = 8080
+// defaultDSN is the database used when -dsn isn't given
+const defaultDSN = "host=localhost port=5432 user=postgres password=postgres dbname=polls_new sslmode=disable connect_timeout=5"
 
 type application struct {
-	DSN    string
-	Domain string
-	DB     *ent.Client
+	Config      config
+	DB          *ent.Client
+	Dialect     string
+	TokenSecret []byte
+	Searcher    pollSearcher
+	Results     resultsBroker
+	Webhooks    *webhookDispatcher
+
+	// ready is whether this instance should get traffic: true once it is
+	// serving, false again while it drains for shutdown
+	ready atomic.Bool
 }
 
 func main() {
+	// Subcommands run instead of the server
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			runImportCommand(os.Args[2:])
+			return
+		case "migrate":
+			runMigrateCommand(os.Args[2:])
+			return
+		case "grant-admin":
+			runGrantAdminCommand(os.Args[2:])
+			return
+		}
+	}
+
+	cfg, err := loadConfig(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		log.Fatal(err)
+	}
+
 	app := application{
-		Domain: "example.com",
+		Config:  *cfg,
+		Dialect: dialect.Postgres,
 	}
-	flag.StringVar(&app.DSN, "dsn", "host=localhost port=5432 user=postgres password=postgres dbname=polls_new sslmode=disable connect_timeout=5", "PostgreSQL connection string")
 
-	flag.Parse()
+	if cfg.WebhookAllowPrivate {
+		log.Println("Warning: -webhook-allow-private is set, webhooks can reach this server's network")
+	}
+
+	// Without a configured secret, sign with a random one so tokens at least
+	// can't be forged - they just won't survive a restart
+	if cfg.TokenSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := crand.Read(secret); err != nil {
+			log.Fatalf("failed generating token secret: %v", err)
+		}
+		app.TokenSecret = secret
+		log.Println("Warning: no -token-secret set, using a random secret (sessions reset on restart)")
+	} else {
+		app.TokenSecret = []byte(cfg.TokenSecret)
+	}
 
 	// Create Ent client
-	client, err := ent.Open("postgres", app.DSN)
+	drv, err := entsql.Open(app.Dialect, cfg.DSN)
 	if err != nil {
 		log.Fatalf("failed opening connection to postgres: %v", err)
 	}
-	defer client.Close()
-
-	// Run database migrations
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	db := drv.DB()
+	db.SetMaxOpenConns(cfg.DB.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.DB.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DB.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DB.ConnMaxIdleTime)
+	registerDBMetrics(db)
+	client := ent.NewClient(ent.Driver(drv))
+
+	// The schema is changed by "api migrate up", never at startup; refuse to
+	// serve against a database that hasn't had this release's migrations
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.StartupTimeout)
 	defer cancel()
 
-	if err := client.Schema.Create(ctx); err != nil {
-		log.Fatalf("failed creating schema resources: %v", err)
+	if err := requireSchemaCurrent(ctx, cfg.DSN); err != nil {
+		log.Fatal(err)
 	}
 
 	// Seed database with sample data
-	if err := seedDatabase(ctx, client); err != nil {
-		log.Printf("Warning: Could not seed database: %v", err)
+	if cfg.Features.Seed {
+		if err := seedDatabase(ctx, client); err != nil {
+			log.Printf("Warning: Could not seed database: %v", err)
+		}
 	}
 
 	app.DB = client
+	app.Searcher = newPollSearcher(app.Dialect, client)
+	app.Results = newMemoryBroker()
+	app.Webhooks = newWebhookDispatcher(client, app.Dialect, cfg.WebhookAllowPrivate)
+
+	// Background work: webhook deliveries and opening and closing
+	// scheduled polls. Either can be left to other instances; deliveries
+	// are still queued here.
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	startWorker := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(workerCtx)
+		}()
+	}
+	if cfg.Features.WebhookDelivery {
+		startWorker(app.Webhooks.Run)
+	}
+	if cfg.Features.Scheduler {
+		startWorker(func(ctx context.Context) { app.runScheduler(ctx, cfg.SchedulerInterval) })
+	}
 
 	log.Println("Connected to database successfully")
-	log.Println("Database schema created/updated")
-	log.Println("Starting application on port", port)
-	fmt.Println("DOMAIN NAME:", app.Domain)
-
-	err = http.ListenAndServe(fmt.Sprintf(":%d", port), app.routes())
-	if err != nil {
-		log.Fatal(err)
+	log.Println("Database schema is up to date")
+	build := currentBuild()
+	log.Printf("Starting application %s (%s) on %s", build.Version, build.Commit, cfg.Addr)
+	fmt.Println("DOMAIN NAME:", cfg.Domain)
+
+	// Shut down in order: stop taking requests and let the ones in flight
+	// finish, then stop the workers, then close the database
+	serveErr := app.serve(app.newServer(cfg), cfg.HTTP.ShutdownDelay, cfg.HTTP.ShutdownTimeout)
+	stopWorkers()
+	workers.Wait()
+	if err := client.Close(); err != nil {
+		log.Printf("Warning: failed closing the database: %v", err)
 	}
+	if serveErr != nil {
+		log.Fatal(serveErr)
+	}
+	log.Println("Shutdown complete")
 }
 
+// seedAdminEmail is the admin account created by seedDatabase
+const seedAdminEmail = "admin@example.com"
+
 // seedDatabase creates sample users, polls, and votes if they don't exist
 func seedDatabase(ctx context.Context, client *ent.Client) error {
 	// Check if we already have data
@@ -86,28 +178,35 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 	users := []struct {
 		email    string
 		password string
+		role     user.Role
 	}{
-		{"admin@example.com", "admin123"},
-		{"john@email.com", "password123"},
-		{"sarah@email.com", "password123"},
-		{"mike@email.com", "password123"},
-		{"emma@email.com", "password123"},
-		{"alex@email.com", "password123"},
-		{"lisa@email.com", "password123"},
-		{"david@email.com", "password123"},
+		{seedAdminEmail, "admin123", roleAdmin},
+		{"john@email.com", "password123", roleCreator},
+		{"sarah@email.com", "password123", roleCreator},
+		{"mike@email.com", "password123", roleCreator},
+		{"emma@email.com", "password123", roleCreator},
+		{"alex@email.com", "password123", roleCreator},
+		{"lisa@email.com", "password123", roleCreator},
+		{"david@email.com", "password123", roleCreator},
 	}
 
 	var createdUsers []*ent.User
 	for _, userData := range users {
-		user, err := client.User.Create().
+		passwordHash, err := hashPassword(userData.password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password for %s: %w", userData.email, err)
+		}
+
+		createdUser, err := client.User.Create().
 			SetEmail(userData.email).
-			SetPassword(userData.password).
+			SetPassword(passwordHash).
+			SetRole(userData.role).
 			Save(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to create user %s: %w", userData.email, err)
 		}
-		createdUsers = append(createdUsers, user)
-		log.Printf("Created user: %s", user.Email)
+		createdUsers = append(createdUsers, createdUser)
+		log.Printf("Created user: %s", createdUser.Email)
 	}
 
 	// Create polls with options
@@ -171,8 +270,10 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 			SetDescription(pollData.description).
 			SetPollType(pollData.pollType).
 			SetCreatedBy(createdUsers[0].Email). // Created by admin
+			SetOwner(createdUsers[0]).
 			SetMaxVotesPerUser(pollData.maxVotes).
 			SetExpiresAt(time.Now().Add(30 * 24 * time.Hour)). // Expires in 30 days
+			SetSearchText(pollSearchText(pollData.options)).
 			Save(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to create poll %s: %w", pollData.title, err)
@@ -221,17 +322,19 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 			return fmt.Errorf("failed to get options for poll %d: %w", poll.ID, err)
 		}
 
-		// Create votes for each user
+		// Create votes for each user, keeping the poll's total to set at the end
+		totalVotes := 0
 		for _, user := range createdUsers {
 			// Not every user votes on every poll (more realistic)
 			if rand.Float64() < 0.85 { // 85% chance user votes on this poll
 
-				if poll.PollType == "single_choice" {
+				if poll.PollType == pollTypeSingleChoice {
 					// Single choice: pick one option based on distribution
 					optionIndex := chooseOptionByDistribution(pattern.distributions)
 					if optionIndex < len(pollOptions) {
 						_, err := client.Vote.Create().
 							SetVoterIdentifier(user.Email).
+							SetVoter(user).
 							SetPoll(poll).
 							SetOption(pollOptions[optionIndex]).
 							Save(ctx)
@@ -246,7 +349,9 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 							Save(ctx)
 						if err != nil {
 							log.Printf("Warning: Failed to update vote count: %v", err)
+							continue
 						}
+						totalVotes++
 					}
 				} else {
 					// Multiple choice: choose multiple options
@@ -261,6 +366,7 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 
 							_, err := client.Vote.Create().
 								SetVoterIdentifier(user.Email).
+								SetVoter(user).
 								SetPoll(poll).
 								SetOption(pollOptions[optionIndex]).
 								Save(ctx)
@@ -275,13 +381,19 @@ func seedDatabase(ctx context.Context, client *ent.Client) error {
 								Save(ctx)
 							if err != nil {
 								log.Printf("Warning: Failed to update vote count: %v", err)
+								continue
 							}
+							totalVotes++
 						}
 					}
 				}
 			}
 		}
 
+		if err := poll.Update().SetTotalVotes(totalVotes).Exec(ctx); err != nil {
+			log.Printf("Warning: Failed to update vote total: %v", err)
+		}
+
 		log.Printf("Created votes for poll: %s", poll.Title)
 	}
 
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsRegistry holds every metric served on /metrics. It is separate
+// from prometheus.DefaultRegisterer so nothing registers into it by
+// accident.
+var metricsRegistry = prometheus.NewRegistry()
+
+var newMetric = promauto.With(metricsRegistry)
+
+// HTTP traffic, labelled by route pattern, method and status code
+var (
+	httpRequests = newMetric.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	httpDuration = newMetric.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests, by route, method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+)
+
+// Poll and vote activity
+var (
+	pollsCreated = newMetric.NewCounterVec(prometheus.CounterOpts{
+		Name: "polls_created_total",
+		Help: "Polls created, by source: api, import or recurrence.",
+	}, []string{"source"})
+
+	votesCast = newMetric.NewCounterVec(prometheus.CounterOpts{
+		Name: "votes_cast_total",
+		Help: "Ballots cast, by poll type. A ballot naming several options counts once.",
+	}, []string{"poll_type"})
+
+	votesRejected = newMetric.NewCounterVec(prometheus.CounterOpts{
+		Name: "votes_rejected_total",
+		Help: "Ballots rejected before being recorded, by reason.",
+	}, []string{"reason"})
+)
+
+// Values of the "source" label of polls_created_total
+const (
+	pollSourceAPI        = "api"
+	pollSourceImport     = "import"
+	pollSourceRecurrence = "recurrence"
+)
+
+// Values of the "reason" label of votes_rejected_total
+const (
+	voteRejectInvalid      = "invalid_ballot"
+	voteRejectPollNotFound = "poll_not_found"
+	voteRejectNoAccess     = "no_access"
+	voteRejectNotOpen      = "not_open"
+	voteRejectExpired      = "expired"
+	voteRejectClosed       = "closed"
+	voteRejectAlreadyVoted = "already_voted"
+	voteRejectLimit        = "vote_limit"
+	voteRejectNoChanges    = "changes_not_allowed"
+	voteRejectNotVoted     = "not_voted"
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// registerDBMetrics exposes the connection pool statistics of db
+func registerDBMetrics(db *sql.DB) {
+	metricsRegistry.MustRegister(collectors.NewDBStatsCollector(db, "polls"))
+}
+
+// recordVoteRejection counts a ballot the caller got an error for. Server
+// failures aren't rejections and aren't counted; client errors without a
+// more specific reason count as invalid ballots.
+func recordVoteRejection(err error) {
+	if statusFromError(err) >= http.StatusInternalServerError {
+		return
+	}
+	reason := voteRejectInvalid
+	var rejection *voteRejection
+	if errors.As(err, &rejection) {
+		reason = rejection.reason
+	}
+	votesRejected.WithLabelValues(reason).Inc()
+}
+
+var metricsHandler = promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{})
+
+// Metrics serves the metrics in the Prometheus text format
+func (app *application) Metrics(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	metricsHandler.ServeHTTP(w, r)
+}
+
+// unmatchedRoute is the route label of requests that never reached a
+// handler: unknown paths, and requests authenticate turned away
+const unmatchedRoute = "unmatched"
+
+const routeContextKey = contextKey("route")
+
+// matchedRoute is filled in by instrumentedRouter with the pattern of the
+// route a request matched, for instrumentHTTP to label it with
+type matchedRoute struct {
+	pattern string
+}
+
+// instrumentedRouter is an httprouter.Router whose routes label their
+// requests for the HTTP metrics, so registering a route is all it takes
+// to get it measured
+type instrumentedRouter struct {
+	*httprouter.Router
+}
+
+func (rt instrumentedRouter) Handle(method, path string, handle httprouter.Handle) {
+	rt.Router.Handle(method, path, func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if route, ok := r.Context().Value(routeContextKey).(*matchedRoute); ok {
+			route.pattern = path
+		}
+		handle(w, r, ps)
+	})
+}
+
+func (rt instrumentedRouter) GET(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodGet, path, handle)
+}
+
+func (rt instrumentedRouter) POST(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodPost, path, handle)
+}
+
+func (rt instrumentedRouter) PUT(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodPut, path, handle)
+}
+
+func (rt instrumentedRouter) PATCH(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodPatch, path, handle)
+}
+
+func (rt instrumentedRouter) DELETE(path string, handle httprouter.Handle) {
+	rt.Handle(http.MethodDelete, path, handle)
+}
+
+// statusRecorder remembers the status code a handler wrote
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the connection, which results
+// streams need to flush and extend their write deadline
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// instrumentHTTP counts and times every request. It wraps the whole
+// middleware chain, so requests rejected before routing are measured too.
+func (app *application) instrumentHTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := &matchedRoute{pattern: unmatchedRoute}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), routeContextKey, route)))
+
+		// Methods come from the client, so only known ones get their own series
+		method := r.Method
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut,
+			http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		default:
+			method = "other"
+		}
+		code := strconv.Itoa(rec.status)
+		httpRequests.WithLabelValues(route.pattern, method, code).Inc()
+		httpDuration.WithLabelValues(route.pattern, method, code).Observe(time.Since(start).Seconds())
+	})
+}
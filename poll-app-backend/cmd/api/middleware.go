@@ -1,13 +1,32 @@
 package main
 
-import "net/http"
+import (
+	"backend/ent"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// contextKey is used for values stored on the request context
+type contextKey string
+
+const userContextKey = contextKey("user")
+
+// errAccountDisabled is returned to users whose account an admin disabled
+var errAccountDisabled = errors.New("this account has been disabled")
 
 func (app *application) enableCORS(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Always set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "http://localhost:3000")
+		// Only configured origins get CORS headers; browsers block the rest
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); app.Config.allowsOrigin(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, PATCH, DELETE")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-CSRF-Token, Accept")
 
 		// Preflight check
@@ -21,6 +40,74 @@ func (app *application) enableCORS(next http.Handler) http.Handler {
 	})
 }
 
+// authenticate resolves a "Bearer" token in the Authorization header into a
+// user and stores it on the request context. Requests without a token pass
+// through anonymously; requests with a bad token are rejected.
+func (app *application) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Authorization")
+
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" || r.Method == "OPTIONS" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, token, found := strings.Cut(authHeader, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			app.errorJSON(w, errors.New("invalid authorization header"), http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := app.parseToken(strings.TrimSpace(token))
+		if err != nil {
+			app.errorJSON(w, err, http.StatusUnauthorized)
+			return
+		}
+
+		// Load the user so deleted accounts lose access immediately
+		userData, err := app.DB.User.Get(r.Context(), claims.UserID)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				app.errorJSON(w, errors.New("account no longer exists"), http.StatusUnauthorized)
+			} else {
+				app.errorJSON(w, err)
+			}
+			return
+		}
+		if userData.DisabledAt != nil {
+			app.errorJSON(w, errAccountDisabled, http.StatusForbidden)
+			return
+		}
+		// Tokens issued before the last password change no longer count
+		if claims.Version != userData.TokenVersion {
+			app.errorJSON(w, errRevokedToken, http.StatusUnauthorized)
+			return
+		}
 
+		next.ServeHTTP(w, app.contextSetUser(r, userData))
+	})
+}
 
+// requireAuth wraps a route handler so it only runs for authenticated users
+func (app *application) requireAuth(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if app.contextGetUser(r) == nil {
+			app.errorJSON(w, errors.New("you must be logged in to access this resource"), http.StatusUnauthorized)
+			return
+		}
+		next(w, r, ps)
+	}
+}
 
+// contextSetUser returns a copy of the request carrying the authenticated user
+func (app *application) contextSetUser(r *http.Request, u *ent.User) *http.Request {
+	ctx := context.WithValue(r.Context(), userContextKey, u)
+	return r.WithContext(ctx)
+}
+
+// contextGetUser returns the authenticated user, or nil for anonymous requests
+func (app *application) contextGetUser(r *http.Request) *ent.User {
+	u, _ := r.Context().Value(userContextKey).(*ent.User)
+	return u
+}
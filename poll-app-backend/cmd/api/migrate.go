@@ -0,0 +1,508 @@
+package main
+
+import (
+	"backend/ent/migrate"
+	"backend/migrations"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// schemaMigrationsTable records which migration versions have been applied
+const schemaMigrationsTable = "schema_migrations"
+
+// migrationLockKey identifies the PostgreSQL advisory lock held while
+// migrations run, so two deploys can't apply the same file at once
+const migrationLockKey int64 = 0x706f6c6c6d696772 // "pollmigr"
+
+// migrationFile is one version of the schema: the SQL that moves the
+// database to it and the SQL that moves it back
+type migrationFile struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// File returns the migration's file name without the .up/.down suffix
+func (m migrationFile) File() string {
+	return fmt.Sprintf("%d_%s", m.Version, m.Name)
+}
+
+// loadMigrations reads <version>_<name>.up.sql and .down.sql pairs from
+// fsys, oldest first. Every version needs both files.
+func loadMigrations(fsys fs.FS) ([]migrationFile, error) {
+	paths, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*migrationFile)
+	for _, path := range paths {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(path, ".sql"), ".")
+		if !ok || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("migration %s: name must end in .up.sql or .down.sql", path)
+		}
+		versionPart, name, _ := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: name must start with a numeric version", path)
+		}
+
+		body, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migrationFile{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, m.Name, name)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	files := make([]migrationFile, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s needs both an up and a down file", m.File())
+		}
+		files = append(files, *m)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Version < files[j].Version })
+	return files, nil
+}
+
+// migrator applies migration files to a PostgreSQL database and records
+// each applied version in schemaMigrationsTable. Every file runs in its own
+// transaction together with its record, so a failed migration leaves the
+// database at the previous version.
+type migrator struct {
+	db    *sql.DB
+	files []migrationFile
+}
+
+// embeddedMigrations are the migration files compiled into the binary
+var embeddedMigrations = sync.OnceValues(func() ([]migrationFile, error) {
+	return loadMigrations(migrations.Files)
+})
+
+func newMigrator(db *sql.DB) (*migrator, error) {
+	files, err := embeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{db: db, files: files}, nil
+}
+
+// ensureTable creates schemaMigrationsTable if it doesn't exist yet
+func (m *migrator) ensureTable(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+schemaMigrationsTable+` (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	return err
+}
+
+// applied returns when each applied version was applied
+func (m *migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+	return appliedVersions(ctx, m.db)
+}
+
+// appliedVersions reads schemaMigrationsTable through db, which may be a
+// *sql.DB or the ent client. Unlike migrator.applied it never creates the
+// table, so it fails on a database that has never been migrated.
+func appliedVersions(ctx context.Context, db interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}) (map[int64]time.Time, error) {
+	rows, err := db.QueryContext(ctx, `SELECT version, applied_at FROM `+schemaMigrationsTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]time.Time)
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		versions[version] = at
+	}
+	return versions, rows.Err()
+}
+
+// unapplied returns the files whose versions aren't in applied, in order
+func unapplied(files []migrationFile, applied map[int64]time.Time) []migrationFile {
+	var pending []migrationFile
+	for _, f := range files {
+		if _, ok := applied[f.Version]; !ok {
+			pending = append(pending, f)
+		}
+	}
+	return pending
+}
+
+// pending returns the migration files not applied yet, oldest first
+func (m *migrator) pending(ctx context.Context) ([]migrationFile, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return unapplied(m.files, applied), nil
+}
+
+// withLock runs fn while holding the migration lock, waiting for any other
+// instance that is migrating to finish first
+func (m *migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("taking the migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			log.Printf("Warning: failed to release the migration lock: %v", err)
+		}
+	}()
+	return fn(conn)
+}
+
+// run executes one migration's SQL and updates its record, in a single
+// transaction
+func (m *migrator) run(ctx context.Context, conn *sql.Conn, f migrationFile, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	body, record := f.Down, `DELETE FROM `+schemaMigrationsTable+` WHERE version = $1`
+	if up {
+		body, record = f.Up, `INSERT INTO `+schemaMigrationsTable+` (version, name) VALUES ($1, $2)`
+	}
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migration %s: %w", f.File(), err)
+	}
+	args := []any{f.Version}
+	if up {
+		args = append(args, f.Name)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("recording migration %s: %w", f.File(), err)
+	}
+	return tx.Commit()
+}
+
+// Up applies up to n pending migrations (all of them when n is 0), oldest
+// first. With dryRun the SQL is written to out instead of being run.
+func (m *migrator) Up(ctx context.Context, n int, dryRun bool, out io.Writer) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pending(ctx)
+		if err != nil {
+			return err
+		}
+		if n > 0 && n < len(pending) {
+			pending = pending[:n]
+		}
+		if len(pending) == 0 {
+			fmt.Fprintln(out, "Database schema is up to date")
+			return nil
+		}
+
+		for _, f := range pending {
+			if dryRun {
+				fmt.Fprintf(out, "-- %s.up.sql\n%s\n", f.File(), f.Up)
+				continue
+			}
+			if err := m.run(ctx, conn, f, true); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Applied %s\n", f.File())
+		}
+		return nil
+	})
+}
+
+// Down reverts the n most recently applied migrations, newest first. With
+// dryRun the SQL is written to out instead of being run.
+func (m *migrator) Down(ctx context.Context, n int, dryRun bool, out io.Writer) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		var reverting []migrationFile
+		for i := len(m.files) - 1; i >= 0 && len(reverting) < n; i-- {
+			if _, ok := applied[m.files[i].Version]; ok {
+				reverting = append(reverting, m.files[i])
+			}
+		}
+		if len(reverting) == 0 {
+			fmt.Fprintln(out, "No migrations to revert")
+			return nil
+		}
+
+		for _, f := range reverting {
+			if dryRun {
+				fmt.Fprintf(out, "-- %s.down.sql\n%s\n", f.File(), f.Down)
+				continue
+			}
+			if err := m.run(ctx, conn, f, false); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Reverted %s\n", f.File())
+		}
+		return nil
+	})
+}
+
+// Status writes every migration file and whether it has been applied, and
+// any applied version this binary has no file for
+func (m *migrator) Status(ctx context.Context, out io.Writer) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int64]bool, len(m.files))
+	for _, f := range m.files {
+		known[f.Version] = true
+		if at, ok := applied[f.Version]; ok {
+			fmt.Fprintf(out, "applied  %s  %s\n", at.UTC().Format(time.RFC3339), f.File())
+		} else {
+			fmt.Fprintf(out, "pending  %-20s  %s\n", "", f.File())
+		}
+	}
+	for version := range applied {
+		if !known[version] {
+			fmt.Fprintf(out, "unknown  %-20s  %d (applied by a newer release)\n", "", version)
+		}
+	}
+	return nil
+}
+
+// Baseline records migrations up to and including version as applied
+// without running them. It is for databases whose schema was created by
+// Schema.Create before migrations existed: those match the first
+// migration, so they are baselined at its version and "up" applies the
+// rest, data migrations included.
+func (m *migrator) Baseline(ctx context.Context, version int64, out io.Writer) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		pending, err := m.pending(ctx)
+		if err != nil {
+			return err
+		}
+		for _, f := range pending {
+			if f.Version > version {
+				break
+			}
+			_, err := conn.ExecContext(ctx,
+				`INSERT INTO `+schemaMigrationsTable+` (version, name) VALUES ($1, $2)`, f.Version, f.Name)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Marked %s as applied\n", f.File())
+		}
+		return nil
+	})
+}
+
+// requireSchemaCurrent returns an error if the database at dsn is missing
+// any migration this binary ships with. Versions the binary doesn't know
+// are allowed, so older instances keep serving while a newer release rolls
+// out. It only reads; a database that has never been migrated is an error.
+func requireSchemaCurrent(ctx context.Context, dsn string) error {
+	db, err := openDB(dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	files, err := embeddedMigrations()
+	if err != nil {
+		return err
+	}
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return fmt.Errorf("checking migrations: %w; run \"api migrate up\" on a new database", err)
+	}
+	pending := unapplied(files, applied)
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind: %d migration(s) pending, starting with %s; run \"api migrate up\"",
+			len(pending), pending[0].File())
+	}
+	return nil
+}
+
+// diffMigration writes the migration that brings the files in dir up to
+// the ent schema, named name. The files are replayed on devURL, an empty
+// scratch database, to work out the current state. With dryRun the new
+// files are printed to out instead of being added to dir.
+func diffMigration(ctx context.Context, dir, devURL, name string, dryRun bool, out io.Writer) error {
+	target := dir
+	if dryRun {
+		tmp, err := os.MkdirTemp("", "migrations")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		if err := os.CopyFS(tmp, os.DirFS(dir)); err != nil {
+			return err
+		}
+		target = tmp
+	}
+
+	before, err := filepath.Glob(filepath.Join(target, "*.sql"))
+	if err != nil {
+		return err
+	}
+
+	migrationDir, err := sqltool.NewGolangMigrateDir(target)
+	if err != nil {
+		return err
+	}
+	m, err := schema.NewMigrateURL(devURL,
+		schema.WithDir(migrationDir),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(dialect.Postgres),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+	)
+	if err != nil {
+		return err
+	}
+	if err := m.NamedDiff(ctx, name, migrate.Tables...); err != nil {
+		return err
+	}
+
+	after, err := filepath.Glob(filepath.Join(target, "*.sql"))
+	if err != nil {
+		return err
+	}
+	if len(after) == len(before) {
+		fmt.Fprintln(out, "The migrations already match the ent schema")
+		return nil
+	}
+	for _, path := range after[len(before):] {
+		if !dryRun {
+			fmt.Fprintf(out, "Wrote %s\n", path)
+			continue
+		}
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "-- %s\n%s\n", filepath.Base(path), body)
+	}
+	return nil
+}
+
+// runMigrateCommand implements "api migrate", which manages the database
+// schema:
+//
+//	up [-n N] [-dry-run]          apply pending migrations
+//	down [-n N] [-dry-run]        revert the last N migrations (default 1)
+//	status                        list migrations and whether they're applied
+//	baseline -version V           mark migrations up to V as applied without running them
+//	diff [-dry-run] -dev-url URL NAME
+//	                              write a migration from the ent schema's changes
+func runMigrateCommand(args []string) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: api migrate up|down|status|baseline|diff [flags]")
+		fmt.Fprintln(os.Stderr, "Run \"api migrate <command> -h\" for a command's flags.")
+		os.Exit(2)
+	}
+	if len(args) == 0 {
+		usage()
+	}
+
+	command := args[0]
+	fs := flag.NewFlagSet("migrate "+command, flag.ExitOnError)
+	dsn := fs.String("dsn", envDSN(), "PostgreSQL connection string (defaults to $POLLS_DSN)")
+	dryRun := fs.Bool("dry-run", false, "Print the SQL instead of running it (or, for diff, instead of writing files)")
+	n := fs.Int("n", 0, "Number of migrations to apply or revert (up defaults to all, down to 1)")
+	version := fs.Int64("version", 0, "Last migration version to mark as applied (baseline)")
+	devURL := fs.String("dev-url", "", "URL of an empty scratch PostgreSQL database to replay migrations on (diff)")
+	dir := fs.String("dir", "migrations", "Migration directory to write to (diff)")
+
+	switch command {
+	case "up", "down", "status", "baseline", "diff":
+	default:
+		usage()
+	}
+	fs.Parse(args[1:])
+
+	ctx := context.Background()
+	if command == "diff" {
+		if *devURL == "" || fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "Usage: api migrate diff [-dry-run] [-dir DIR] -dev-url URL NAME")
+			os.Exit(2)
+		}
+		if err := diffMigration(ctx, *dir, *devURL, fs.Arg(0), *dryRun, os.Stdout); err != nil {
+			log.Fatalf("diff failed: %v", err)
+		}
+		return
+	}
+
+	db, err := openDB(*dsn)
+	if err != nil {
+		log.Fatalf("failed opening connection to postgres: %v", err)
+	}
+	defer db.Close()
+
+	m, err := newMigrator(db)
+	if err != nil {
+		log.Fatalf("failed loading migrations: %v", err)
+	}
+
+	switch command {
+	case "up":
+		err = m.Up(ctx, *n, *dryRun, os.Stdout)
+	case "down":
+		if *n == 0 {
+			*n = 1
+		}
+		err = m.Down(ctx, *n, *dryRun, os.Stdout)
+	case "status":
+		err = m.Status(ctx, os.Stdout)
+	case "baseline":
+		if *version == 0 {
+			log.Fatal("baseline needs -version")
+		}
+		err = m.Baseline(ctx, *version, os.Stdout)
+	}
+	if err != nil {
+		log.Fatalf("migrate %s failed: %v", command, err)
+	}
+}
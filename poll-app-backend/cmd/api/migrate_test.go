@@ -0,0 +1,163 @@
+package main
+
+import (
+	"backend/ent/user"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// openTestPostgres returns a connection to a new, empty schema of the
+// PostgreSQL database named by $POLLS_TEST_DSN, dropped when the test
+// ends. Tests that need it are skipped when the variable isn't set.
+func openTestPostgres(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("POLLS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POLLS_TEST_DSN is not set")
+	}
+	admin, err := openDB(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	name := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(`CREATE SCHEMA ` + name); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(`DROP SCHEMA ` + name + ` CASCADE`); err != nil {
+			t.Errorf("dropping schema %s: %v", name, err)
+		}
+	})
+
+	// Every pooled connection has to see the schema, so it goes in the DSN
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q := u.Query()
+		q.Set("search_path", name)
+		u.RawQuery = q.Encode()
+		dsn = u.String()
+	} else {
+		dsn += " search_path=" + name
+	}
+	db, err := openDB(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestMigrateUpgradesLegacyDatabase builds the schema Schema.Create made
+// before migrations existed, fills it the way the old code did, then
+// baselines and upgrades it. Every schema change and data migration must
+// apply to the existing rows.
+func TestMigrateUpgradesLegacyDatabase(t *testing.T) {
+	db := openTestPostgres(t)
+	ctx := t.Context()
+
+	m, err := newMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial := m.files[0]
+	if _, err := db.ExecContext(ctx, initial.Up); err != nil {
+		t.Fatalf("creating the legacy schema: %v", err)
+	}
+
+	legacy := []string{
+		`INSERT INTO users (email, password, created_at) VALUES ('alice@example.com', 'secret', now())`,
+		`INSERT INTO polls (title, created_by, max_votes_per_user, created_at, updated_at)
+			VALUES ('Lunch?', 'Alice@Example.com', 1, now(), now())`,
+		`INSERT INTO poll_options (option_text, vote_count, created_at, poll_options)
+			SELECT o, 2, now(), id FROM polls, unnest(ARRAY['Pizza', 'Salad']) AS o`,
+		// Pizza has a repeated ballot, which the unique index can't hold
+		`INSERT INTO votes (voter_identifier, created_at, poll_votes, poll_option_votes)
+			SELECT v, now(), poll_options, id FROM poll_options, unnest(ARRAY['alice@example.com', '203.0.113.9']) AS v`,
+		`INSERT INTO votes (voter_identifier, created_at, poll_votes, poll_option_votes)
+			SELECT '203.0.113.9', now(), poll_options, id FROM poll_options WHERE option_text = 'Pizza'`,
+		`UPDATE poll_options SET vote_count = 3 WHERE option_text = 'Pizza'`,
+	}
+	for _, stmt := range legacy {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("adding legacy rows: %v\n%s", err, stmt)
+		}
+	}
+
+	if err := m.Baseline(ctx, initial.Version, io.Discard); err != nil {
+		t.Fatalf("baseline: %v", err)
+	}
+	if err := m.Up(ctx, 0, false, io.Discard); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if pending, err := m.pending(ctx); err != nil || len(pending) > 0 {
+		t.Fatalf("after up: %d pending (%v)", len(pending), err)
+	}
+
+	var userID int
+	var role user.Role
+	if err := db.QueryRowContext(ctx, `SELECT id, role FROM users`).Scan(&userID, &role); err != nil {
+		t.Fatal(err)
+	}
+	if role != roleCreator {
+		t.Errorf("existing user's role = %q, want %q", role, roleCreator)
+	}
+
+	var owner sql.NullInt64
+	var total int
+	var searchText, state string
+	err = db.QueryRowContext(ctx, `SELECT user_polls, total_votes, search_text, state FROM polls`).
+		Scan(&owner, &total, &searchText, &state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !owner.Valid || int(owner.Int64) != userID {
+		t.Errorf("poll owner = %v, want user %d", owner, userID)
+	}
+	if total != 4 {
+		t.Errorf("total_votes = %d, want 4", total)
+	}
+	if searchText != "Pizza\nSalad" {
+		t.Errorf("search_text = %q, want %q", searchText, "Pizza\nSalad")
+	}
+	if state != pollStateOpen {
+		t.Errorf("state = %q, want %q", state, pollStateOpen)
+	}
+
+	var pizzaVotes int
+	if err := db.QueryRowContext(ctx, `SELECT vote_count FROM poll_options WHERE option_text = 'Pizza'`).Scan(&pizzaVotes); err != nil {
+		t.Fatal(err)
+	}
+	if pizzaVotes != 2 {
+		t.Errorf("Pizza vote_count = %d after dropping the repeated ballot, want 2", pizzaVotes)
+	}
+
+	var linked, unlinked int
+	err = db.QueryRowContext(ctx, `SELECT count(user_votes), count(*) - count(user_votes) FROM votes`).
+		Scan(&linked, &unlinked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked != 2 || unlinked != 2 {
+		t.Errorf("votes linked to users = %d, unlinked = %d; want 2 and 2", linked, unlinked)
+	}
+
+	// Every down file has to undo its up file on the way back
+	if err := m.Down(ctx, len(m.files), false, io.Discard); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if err := m.Up(ctx, 0, false, io.Discard); err != nil {
+		t.Fatalf("up after down: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/predicate"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Page size limits for list endpoints
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Sort orders accepted by GET /polls
+const (
+	sortNewest      = "newest"
+	sortMostVotes   = "most_votes"
+	sortClosingSoon = "closing_soon"
+)
+
+// Status filters accepted by GET /polls
+const (
+	pollStatusDraft    = "draft"
+	pollStatusOpen     = "open"
+	pollStatusClosed   = "closed"
+	pollStatusExpired  = "expired"
+	pollStatusTemplate = "template"
+)
+
+// pollListParams holds the parsed query string of GET /polls
+type pollListParams struct {
+	Limit         int
+	Cursor        *pollCursor
+	Sort          string
+	Status        string
+	PollType      string
+	CreatedBy     string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// pollCursor marks the last poll of a page. Value holds that poll's sort key
+// (created_at, total_votes or expires_at) so the next page can continue
+// after it without an OFFSET.
+type pollCursor struct {
+	Sort  string          `json:"s"`
+	Value json.RawMessage `json:"v"`
+	ID    int             `json:"id"`
+}
+
+// readPollListParams parses and validates the GET /polls query string
+func readPollListParams(qs url.Values) (*pollListParams, error) {
+	params := &pollListParams{
+		Limit:     defaultPageSize,
+		Sort:      qs.Get("sort"),
+		Status:    qs.Get("status"),
+		PollType:  qs.Get("poll_type"),
+		CreatedBy: qs.Get("created_by"),
+	}
+
+	if v := qs.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return nil, fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+		params.Limit = limit
+	}
+
+	switch params.Sort {
+	case "":
+		params.Sort = sortNewest
+	case sortNewest, sortMostVotes, sortClosingSoon:
+	default:
+		return nil, errors.New("sort must be one of 'newest', 'most_votes', 'closing_soon'")
+	}
+
+	switch params.Status {
+	case "", pollStatusDraft, pollStatusOpen, pollStatusClosed, pollStatusExpired, pollStatusTemplate:
+	default:
+		return nil, errors.New("status must be 'draft', 'open', 'closed', 'expired' or 'template'")
+	}
+
+	if params.PollType != "" {
+		if err := validatePollType(params.PollType); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, dst := range map[string]**time.Time{
+		"created_after":  &params.CreatedAfter,
+		"created_before": &params.CreatedBefore,
+	} {
+		if v := qs.Get(name); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s format, use RFC3339", name)
+			}
+			*dst = &t
+		}
+	}
+
+	if v := qs.Get("cursor"); v != "" {
+		cursor, err := decodePollCursor(v)
+		if err != nil || cursor.Sort != params.Sort {
+			return nil, errors.New("invalid cursor")
+		}
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
+// filters returns the predicates selecting the polls to list, before paging.
+// Only public polls are ever listed.
+func (p *pollListParams) filters(now time.Time) []predicate.Poll {
+	preds := []predicate.Poll{poll.VisibilityEQ(visibilityPublic)}
+
+	switch p.Status {
+	case pollStatusDraft:
+		preds = append(preds, poll.StateEQ(pollStateDraft))
+	case pollStatusOpen:
+		preds = append(preds, poll.StateEQ(pollStateOpen), poll.Or(poll.ExpiresAtIsNil(), poll.ExpiresAtGT(now)))
+	case pollStatusClosed:
+		preds = append(preds, poll.StateEQ(pollStateClosed))
+	case pollStatusExpired:
+		preds = append(preds, poll.ExpiresAtLTE(now))
+	case pollStatusTemplate:
+		preds = append(preds, poll.StateEQ(pollStateTemplate))
+	}
+
+	if p.PollType != "" {
+		preds = append(preds, poll.PollTypeEQ(p.PollType))
+	}
+	if p.CreatedBy != "" {
+		preds = append(preds, poll.CreatedByEqualFold(p.CreatedBy))
+	}
+	if p.CreatedAfter != nil {
+		preds = append(preds, poll.CreatedAtGTE(*p.CreatedAfter))
+	}
+	if p.CreatedBefore != nil {
+		preds = append(preds, poll.CreatedAtLT(*p.CreatedBefore))
+	}
+
+	// Polls without an expiry never close, so they have no place in this
+	// order, and ones that already expired aren't closing soon - unless
+	// expired polls are what was asked for
+	if p.Sort == sortClosingSoon {
+		preds = append(preds, poll.ExpiresAtNotNil())
+		if p.Status != pollStatusExpired {
+			preds = append(preds, poll.ExpiresAtGT(now))
+		}
+	}
+
+	return preds
+}
+
+// after returns the predicate selecting polls that come after the cursor in
+// the chosen sort order
+func (p *pollListParams) after() (predicate.Poll, error) {
+	c := p.Cursor
+	switch p.Sort {
+	case sortMostVotes:
+		var votes int
+		if err := json.Unmarshal(c.Value, &votes); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		return poll.Or(
+			poll.TotalVotesLT(votes),
+			poll.And(poll.TotalVotesEQ(votes), poll.IDLT(c.ID)),
+		), nil
+	case sortClosingSoon:
+		var expiresAt time.Time
+		if err := json.Unmarshal(c.Value, &expiresAt); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		return poll.Or(
+			poll.ExpiresAtGT(expiresAt),
+			poll.And(poll.ExpiresAtEQ(expiresAt), poll.IDGT(c.ID)),
+		), nil
+	default:
+		var createdAt time.Time
+		if err := json.Unmarshal(c.Value, &createdAt); err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		return poll.Or(
+			poll.CreatedAtLT(createdAt),
+			poll.And(poll.CreatedAtEQ(createdAt), poll.IDLT(c.ID)),
+		), nil
+	}
+}
+
+// order returns the ORDER BY terms for the chosen sort, with the ID as a
+// tie-breaker so the order is total
+func (p *pollListParams) order() []poll.OrderOption {
+	switch p.Sort {
+	case sortMostVotes:
+		return []poll.OrderOption{poll.ByTotalVotes(sql.OrderDesc()), poll.ByID(sql.OrderDesc())}
+	case sortClosingSoon:
+		return []poll.OrderOption{poll.ByExpiresAt(), poll.ByID()}
+	default:
+		return []poll.OrderOption{poll.ByCreatedAt(sql.OrderDesc()), poll.ByID(sql.OrderDesc())}
+	}
+}
+
+// cursorFor builds the cursor pointing just after the given poll
+func (p *pollListParams) cursorFor(last *ent.Poll) (string, error) {
+	var value any
+	switch p.Sort {
+	case sortMostVotes:
+		value = last.TotalVotes
+	case sortClosingSoon:
+		value = last.ExpiresAt
+	default:
+		value = last.CreatedAt
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(pollCursor{Sort: p.Sort, Value: raw, ID: last.ID})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(out), nil
+}
+
+// decodePollCursor reverses cursorFor
+func decodePollCursor(s string) (*pollCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	var c pollCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, err
+	}
+	if c.ID < 1 || len(c.Value) == 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &c, nil
+}
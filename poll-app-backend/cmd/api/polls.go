@@ -0,0 +1,315 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/user"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// findManagedPoll loads a poll and checks that u may manage it, being its
+// creator or an admin
+func (app *application) findManagedPoll(ctx context.Context, pollID int, u *ent.User) (*ent.Poll, error) {
+	pollData, err := app.DB.Poll.Query().
+		Where(poll.IDEQ(pollID)).
+		WithOwner(func(q *ent.UserQuery) {
+			q.Select(user.FieldID)
+		}).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, newRequestError(http.StatusNotFound, errors.New("poll not found"))
+		}
+		return nil, err
+	}
+
+	if !canManagePoll(pollData, u) {
+		return nil, errNotPollManager
+	}
+
+	return pollData, nil
+}
+
+// lockManagedPoll is findManagedPoll inside a transaction, holding the
+// poll's row lock until it ends
+func (app *application) lockManagedPoll(ctx context.Context, tx *ent.Tx, pollID int, u *ent.User) (*ent.Poll, error) {
+	pollData, err := app.lockPoll(ctx, tx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadPollOwner(ctx, tx.Client(), pollData); err != nil {
+		return nil, err
+	}
+
+	if !canManagePoll(pollData, u) {
+		return nil, errNotPollManager
+	}
+
+	return pollData, nil
+}
+
+// errNotPollManager is returned when someone other than a poll's creator or
+// an admin tries to change it
+var errNotPollManager = newRequestError(http.StatusForbidden, errors.New("only the poll's creator or an admin can do that"))
+
+// loadPollOwner loads the poll's owner edge, with only the owner's ID,
+// unless it is loaded already. It stays empty once the owner's account is
+// deleted.
+func loadPollOwner(ctx context.Context, client *ent.Client, pollData *ent.Poll) error {
+	if pollData.Edges.Owner != nil {
+		return nil
+	}
+	owner, err := client.Poll.QueryOwner(pollData).Select(user.FieldID).Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return err
+	}
+	pollData.Edges.Owner = owner
+	return nil
+}
+
+// isPollOwner reports whether u created the poll (loaded with its owner edge).
+// Only the edge counts: created_by is a label, and its email may have been
+// registered again by someone else after the creator deleted their account.
+func isPollOwner(pollData *ent.Poll, u *ent.User) bool {
+	return u != nil && pollData.Edges.Owner != nil && pollData.Edges.Owner.ID == u.ID
+}
+
+// canManagePoll reports whether u may edit, close or delete the poll
+// (loaded with its owner edge)
+func canManagePoll(pollData *ent.Poll, u *ent.User) bool {
+	return isPollOwner(pollData, u) || isAdmin(u)
+}
+
+// pollInput is a poll definition as clients submit it, to POST /polls or in
+// an import file
+type pollInput struct {
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	PollType          string   `json:"poll_type"`
+	MaxVotesPerUser   int      `json:"max_votes_per_user"`
+	OpensAt           *string  `json:"opens_at"`   // null or absent opens the poll straight away
+	ExpiresAt         *string  `json:"expires_at"` // pointer to handle null
+	AllowVoteChanges  *bool    `json:"allow_vote_changes"`
+	RatingMin         *int     `json:"rating_min"`
+	RatingMax         *int     `json:"rating_max"`
+	Visibility        string   `json:"visibility"`
+	ResultsVisibility string   `json:"results_visibility"`
+	InvitedEmails     []string `json:"invited_emails"`
+	Recurrence        string   `json:"recurrence"`
+	RecurrenceTZ      string   `json:"recurrence_timezone"`
+	Options           []string `json:"options"`
+
+	// opensAt, expiresAt and schedule are OpensAt, ExpiresAt and
+	// Recurrence parsed by validate
+	opensAt   *time.Time
+	expiresAt *time.Time
+	schedule  *cronSchedule
+
+	// template is set on occurrences of a recurring poll
+	template *ent.Poll
+}
+
+// validate checks a poll definition and fills in the defaults for its type.
+// Every error it returns is the client's fault.
+func (in *pollInput) validate(now time.Time) error {
+	// Validate required fields
+	if in.Title == "" {
+		return errors.New("poll title is required")
+	}
+
+	if len(in.Options) < 2 {
+		return errors.New("at least 2 options are required")
+	}
+
+	// Validate poll type
+	if in.PollType == "" {
+		in.PollType = pollTypeSingleChoice
+	}
+	if err := validatePollType(in.PollType); err != nil {
+		return err
+	}
+
+	// Validate max votes for multiple choice polls
+	if in.PollType == pollTypeMultipleChoice && in.MaxVotesPerUser < 1 {
+		return errors.New("max_votes_per_user must be at least 1 for multiple choice polls")
+	}
+
+	// Ranked ballots may rank every option unless the creator caps it
+	if in.PollType == pollTypeRankedChoice {
+		if in.MaxVotesPerUser < 0 {
+			return errors.New("max_votes_per_user can't be negative")
+		}
+		if in.MaxVotesPerUser == 0 || in.MaxVotesPerUser > len(in.Options) {
+			in.MaxVotesPerUser = len(in.Options)
+		}
+	}
+
+	// Rating polls score every option on the creator's scale
+	if in.PollType == pollTypeRating {
+		if in.RatingMin == nil {
+			in.RatingMin = intPtr(defaultRatingMin)
+		}
+		if in.RatingMax == nil {
+			in.RatingMax = intPtr(defaultRatingMax)
+		}
+		if *in.RatingMin >= *in.RatingMax {
+			return errors.New("rating_min must be less than rating_max")
+		}
+		if *in.RatingMax-*in.RatingMin > maxRatingSpan {
+			return fmt.Errorf("rating scale can span at most %d points", maxRatingSpan)
+		}
+		in.MaxVotesPerUser = len(in.Options)
+	} else if in.RatingMin != nil || in.RatingMax != nil {
+		return errors.New("rating_min and rating_max only apply to rating polls")
+	}
+
+	// Parse expiry date if provided
+	in.expiresAt = nil
+	if in.ExpiresAt != nil && *in.ExpiresAt != "" {
+		parsedTime, err := time.Parse(time.RFC3339, *in.ExpiresAt)
+		if err != nil {
+			return errors.New("invalid expires_at format, use RFC3339")
+		}
+
+		// Check if expiry date is in the future
+		if parsedTime.Before(now) {
+			return errors.New("expires_at must be in the future")
+		}
+
+		in.expiresAt = &parsedTime
+	}
+
+	// A future opens_at makes the poll a draft until then
+	in.opensAt = nil
+	if in.OpensAt != nil && *in.OpensAt != "" {
+		parsedTime, err := time.Parse(time.RFC3339, *in.OpensAt)
+		if err != nil {
+			return errors.New("invalid opens_at format, use RFC3339")
+		}
+		if parsedTime.Before(now) {
+			return errors.New("opens_at must be in the future")
+		}
+		if in.expiresAt != nil && !in.expiresAt.After(parsedTime) {
+			return errors.New("expires_at must be after opens_at")
+		}
+		in.opensAt = &parsedTime
+	}
+
+	// A recurrence makes the poll a template that starts a fresh poll at
+	// each occurrence
+	in.schedule = nil
+	if in.Recurrence != "" {
+		if in.opensAt != nil || in.expiresAt != nil {
+			return errors.New("recurring polls can't have opens_at or expires_at; each occurrence closes when the next one starts")
+		}
+		schedule, err := parseRecurrence(in.Recurrence, in.RecurrenceTZ, now)
+		if err != nil {
+			return err
+		}
+		in.schedule = schedule
+	} else if in.RecurrenceTZ != "" {
+		return errors.New("recurrence_timezone needs a recurrence")
+	}
+
+	// Polls are public unless the creator says otherwise
+	if in.Visibility == "" {
+		in.Visibility = visibilityPublic
+	}
+	if err := validateVisibility(in.Visibility); err != nil {
+		return err
+	}
+	if in.ResultsVisibility == "" {
+		in.ResultsVisibility = resultsAlways
+	}
+	if err := validateResultsVisibility(in.ResultsVisibility); err != nil {
+		return err
+	}
+	invited, err := normalizeInvitedEmails(in.InvitedEmails)
+	if err != nil {
+		return err
+	}
+	in.InvitedEmails = invited
+
+	// Set default values
+	if in.MaxVotesPerUser == 0 {
+		in.MaxVotesPerUser = 1
+	}
+
+	return nil
+}
+
+// createPoll stores a validated poll definition and its options, owned by
+// creator. Pass a transactional client so a failed option doesn't leave a
+// half-made poll behind.
+func createPoll(ctx context.Context, client *ent.Client, in *pollInput, creator *ent.User) (*ent.Poll, error) {
+	pollBuilder := client.Poll.Create().
+		SetTitle(in.Title).
+		SetSearchText(pollSearchText(in.Options)).
+		SetPollType(in.PollType).
+		SetCreatedBy(creator.Email).
+		SetOwner(creator).
+		SetMaxVotesPerUser(in.MaxVotesPerUser).
+		SetVisibility(in.Visibility).
+		SetResultsVisibility(in.ResultsVisibility)
+
+	// Add optional fields
+	if in.Description != "" {
+		pollBuilder = pollBuilder.SetDescription(in.Description)
+	}
+	if in.opensAt != nil {
+		pollBuilder = pollBuilder.
+			SetOpensAt(*in.opensAt).
+			SetState(pollStateDraft)
+	}
+	if in.expiresAt != nil {
+		pollBuilder = pollBuilder.SetExpiresAt(*in.expiresAt)
+	}
+	if in.schedule != nil {
+		pollBuilder = pollBuilder.
+			SetRecurrence(in.Recurrence).
+			SetRecurrenceTimezone(in.RecurrenceTZ).
+			SetNextOccurrenceAt(in.schedule.next(time.Now())).
+			SetState(pollStateTemplate)
+	}
+	if in.template != nil {
+		pollBuilder = pollBuilder.SetTemplate(in.template)
+	}
+	if len(in.InvitedEmails) > 0 {
+		pollBuilder = pollBuilder.SetInvitedEmails(in.InvitedEmails)
+	}
+	if in.AllowVoteChanges != nil {
+		pollBuilder = pollBuilder.SetAllowVoteChanges(*in.AllowVoteChanges)
+	}
+	if in.PollType == pollTypeRating {
+		pollBuilder = pollBuilder.
+			SetRatingMin(*in.RatingMin).
+			SetRatingMax(*in.RatingMax)
+	}
+
+	createdPoll, err := pollBuilder.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating poll %q: %w", in.Title, err)
+	}
+
+	// Create poll options
+	for _, optionText := range in.Options {
+		if optionText == "" { // Skip empty options
+			continue
+		}
+		_, err := client.PollOption.Create().
+			SetOptionText(optionText).
+			SetVoteCount(0).
+			SetPoll(createdPoll).
+			Save(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating option %q: %w", optionText, err)
+		}
+	}
+
+	return createdPoll, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestDeletedOwnerEmailGrantsNothing deletes a poll creator's account and
+// registers its email again. The new account must not manage the old
+// polls, which no longer name the email either.
+func TestDeletedOwnerEmailGrantsNothing(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	creator := createTestUser(t, app, "creator@example.com")
+	p, _ := createTestPoll(t, app, creator, pollTypeSingleChoice, "Yes", "No")
+	if _, err := app.findManagedPoll(ctx, p.ID, creator); err != nil {
+		t.Fatalf("creator can't manage their poll: %v", err)
+	}
+
+	deleteTestAccount(t, app, creator)
+
+	reused := createTestUser(t, app, "creator@example.com")
+	if _, err := app.findManagedPoll(ctx, p.ID, reused); !errors.Is(err, errNotPollManager) {
+		t.Errorf("account reusing the creator's email got %v managing the poll, want %v", err, errNotPollManager)
+	}
+
+	orphan, err := app.DB.Poll.Get(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if orphan.CreatedBy != "" {
+		t.Errorf("created_by = %q after the creator's account was deleted, want it cleared", orphan.CreatedBy)
+	}
+}
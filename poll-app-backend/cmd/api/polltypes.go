@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Accepted values of Poll.poll_type
+const (
+	pollTypeSingleChoice   = "single_choice"
+	pollTypeMultipleChoice = "multiple_choice"
+	pollTypeRankedChoice   = "ranked_choice"
+	pollTypeRating         = "rating"
+)
+
+// Default and widest allowed scale for rating polls
+const (
+	defaultRatingMin = 1
+	defaultRatingMax = 5
+	maxRatingSpan    = 100
+)
+
+// pollTypes lists every supported poll type in the order shown to clients
+var pollTypes = []string{
+	pollTypeSingleChoice,
+	pollTypeMultipleChoice,
+	pollTypeRankedChoice,
+	pollTypeRating,
+}
+
+// validatePollType returns an error naming the supported types if t isn't one
+func validatePollType(t string) error {
+	for _, known := range pollTypes {
+		if t == known {
+			return nil
+		}
+	}
+
+	quoted := make([]string, len(pollTypes))
+	for i, known := range pollTypes {
+		quoted[i] = "'" + known + "'"
+	}
+	return fmt.Errorf("poll_type must be one of %s", strings.Join(quoted, ", "))
+}
+
+// isSingleBallotType reports whether a voter submits their whole ballot in
+// one go, so a second POST /vote on the same poll is rejected
+func isSingleBallotType(t string) bool {
+	return t == pollTypeSingleChoice || t == pollTypeRankedChoice || t == pollTypeRating
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// minRecurrenceGap is the shortest time allowed between two occurrences of
+// a recurring poll, so a typo like "* * * * *" doesn't start a poll a minute
+const minRecurrenceGap = time.Hour
+
+// parseRecurrence parses and checks the schedule of a recurring poll
+func parseRecurrence(spec, tz string, now time.Time) (*cronSchedule, error) {
+	schedule, err := parseCronSchedule(spec, tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence: %w", err)
+	}
+
+	// Look at the first few occurrences for ones that are too close
+	prev := schedule.next(now)
+	if prev.IsZero() {
+		return nil, errors.New("recurrence never occurs")
+	}
+	for range 3 {
+		next := schedule.next(prev)
+		if next.IsZero() {
+			break
+		}
+		if next.Sub(prev) < minRecurrenceGap {
+			return nil, fmt.Errorf("occurrences must be at least %v apart", minRecurrenceGap)
+		}
+		prev = next
+	}
+	return schedule, nil
+}
+
+// occurrenceInput is the definition of a new occurrence of a recurring
+// template: everything but the schedule, as the template reads now
+func occurrenceInput(template *ent.Poll) pollInput {
+	in := pollInput{
+		Title:             template.Title,
+		Description:       template.Description,
+		PollType:          template.PollType,
+		MaxVotesPerUser:   template.MaxVotesPerUser,
+		AllowVoteChanges:  &template.AllowVoteChanges,
+		RatingMin:         template.RatingMin,
+		RatingMax:         template.RatingMax,
+		Visibility:        template.Visibility,
+		ResultsVisibility: template.ResultsVisibility,
+		InvitedEmails:     template.InvitedEmails,
+		template:          template,
+	}
+	for _, option := range template.Edges.Options {
+		in.Options = append(in.Options, option.OptionText)
+	}
+	return in
+}
+
+// createOccurrences starts the next occurrence of every recurring template
+// that is due, closing the occurrence it replaces. It runs inside the
+// scheduler's transaction; the returned transitions are announced once it
+// commits. Occurrences missed while no instance was running are skipped
+// rather than created in a burst.
+func createOccurrences(ctx context.Context, tx *ent.Tx, now time.Time) ([]pollTransition, error) {
+	templates, err := tx.Poll.Query().
+		Where(poll.StateEQ(pollStateTemplate)).
+		Where(poll.NextOccurrenceAtLTE(now)).
+		WithOwner().
+		WithOptions(func(q *ent.PollOptionQuery) {
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []pollTransition
+	for _, template := range templates {
+		// The schedule was checked when it was saved, so this only fails if
+		// the owner has gone or the time zone data changed; stop the series
+		// instead of failing every run
+		schedule, err := parseCronSchedule(template.Recurrence, template.RecurrenceTimezone)
+		if err != nil || template.Edges.Owner == nil {
+			log.Printf("Warning: stopping recurring poll %d: no valid schedule or owner", template.ID)
+			if err := tx.Poll.UpdateOne(template).ClearNextOccurrenceAt().Exec(ctx); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		previous, err := tx.Poll.Query().
+			Where(poll.HasTemplateWith(poll.IDEQ(template.ID))).
+			Where(poll.StateIn(pollStateDraft, pollStateOpen)).
+			IDs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range previous {
+			n, err := tx.Poll.Update().
+				Where(poll.IDEQ(id)).
+				Where(poll.StateIn(pollStateDraft, pollStateOpen)).
+				SetState(pollStateClosed).
+				SetClosedAt(now).
+				Save(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if n == 1 {
+				transitions = append(transitions, pollTransition{id, eventPollClosed})
+			}
+		}
+
+		in := occurrenceInput(template)
+		occurrence, err := createPoll(ctx, tx.Client(), &in, template.Edges.Owner)
+		if err != nil {
+			return nil, fmt.Errorf("recurring poll %d: %w", template.ID, err)
+		}
+		transitions = append(transitions, pollTransition{occurrence.ID, eventPollCreated})
+
+		// A schedule that has run out (a one-off date) simply stops
+		update := tx.Poll.UpdateOne(template)
+		if next := schedule.next(now); !next.IsZero() {
+			update.SetNextOccurrenceAt(next)
+		} else {
+			update.ClearNextOccurrenceAt()
+		}
+		if err := update.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return transitions, nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/user"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Accepted values of User.role. Voters can vote; creators can also create
+// and manage their own polls; admins can manage every poll and account.
+// Accounts start as voters.
+const (
+	roleAdmin   = user.RoleAdmin
+	roleCreator = user.RoleCreator
+	roleVoter   = user.RoleVoter
+)
+
+// userRoles lists every role, for validation
+var userRoles = []user.Role{roleAdmin, roleCreator, roleVoter}
+
+// isAdmin reports whether u may manage everything, not just their own polls
+func isAdmin(u *ent.User) bool {
+	return u != nil && u.Role == roleAdmin
+}
+
+// canCreatePolls reports whether u's role lets them create polls
+func canCreatePolls(u *ent.User) bool {
+	return u != nil && (u.Role == roleCreator || u.Role == roleAdmin)
+}
+
+// requireRole wraps a route handler so it only runs for authenticated users
+// holding one of the given roles
+func (app *application) requireRole(next httprouter.Handle, roles ...user.Role) httprouter.Handle {
+	return app.requireAuth(func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if !slices.Contains(roles, app.contextGetUser(r).Role) {
+			app.errorJSON(w, errors.New("your account's role doesn't allow this"), http.StatusForbidden)
+			return
+		}
+		next(w, r, ps)
+	})
+}
+
+// requireAdmin wraps a route handler so it only runs for admins
+func (app *application) requireAdmin(next httprouter.Handle) httprouter.Handle {
+	return app.requireRole(next, roleAdmin)
+}
@@ -7,24 +7,74 @@ import (
 )
 
 func (app *application) routes() http.Handler {
-	router := httprouter.New()
+	router := instrumentedRouter{httprouter.New()}
 	router.GET("/", app.Home)
 	router.GET("/about", app.About)
 
+	// Probes and build info
+	router.GET("/healthz", app.Healthz)
+	router.GET("/readyz", app.Readyz)
+	router.GET("/version", app.Version)
+	if app.Config.Features.Metrics {
+		router.GET("/metrics", app.Metrics)
+	}
+
 	// Poll routes
 	router.GET("/polls", app.AllPolls)
-	router.POST("/polls", app.CreatePoll)
+	router.POST("/polls", app.requireRole(app.CreatePoll, roleCreator, roleAdmin))
+	router.POST("/polls/import", app.requireRole(app.ImportPolls, roleCreator, roleAdmin))
+	router.GET("/polls/search", app.SearchPolls)
 	router.GET("/poll/:id", app.GetPoll)
+	router.GET("/poll/:id/results", app.PollResults)
+	router.GET("/poll/:id/stream", app.StreamResults)
+	router.GET("/poll/:id/export", app.ExportPoll)
+	router.GET("/poll/:id/series", app.PollSeries)
+	router.PUT("/poll/:id", app.requireAuth(app.UpdatePoll))
+	router.PATCH("/poll/:id", app.requireAuth(app.UpdatePoll))
+	router.DELETE("/poll/:id", app.requireAuth(app.DeletePoll))
+	router.PATCH("/poll/:id/settings", app.requireAuth(app.UpdatePollSettings))
+	router.POST("/poll/:id/close", app.requireAuth(app.ClosePoll))
+	router.POST("/poll/:id/reopen", app.requireAuth(app.ReopenPoll))
+	router.GET("/poll/:id/invites", app.requireAuth(app.ListPollInvites))
+	router.POST("/poll/:id/invites", app.requireAuth(app.CreatePollInvite))
+	router.DELETE("/poll/:id/invites/:invite_id", app.requireAuth(app.RevokePollInvite))
 
-	// Voting route
+	// Voting routes; anonymous voters are told apart by a cookie
 	router.POST("/vote", app.VoteOnPoll)
+	router.PUT("/vote", app.ChangeVote)
+	router.DELETE("/poll/:id/vote", app.RetractVote)
 
-	// Authentication route
+	// Authentication routes
 	router.POST("/login", app.Login)
+	router.POST("/register", app.Register)
+
+	// Account management routes
+	router.GET("/account", app.requireAuth(app.GetAccount))
+	router.PATCH("/account", app.requireAuth(app.UpdateAccount))
+	router.PUT("/account/password", app.requireAuth(app.ChangePassword))
+	router.DELETE("/account", app.requireAuth(app.DeleteAccount))
+	router.GET("/account/polls", app.requireAuth(app.MyPolls))
+	router.GET("/account/votes", app.requireAuth(app.MyVotedPolls))
+
+	// Webhook routes
+	router.GET("/webhooks", app.requireAuth(app.ListWebhooks))
+	router.POST("/webhooks", app.requireAuth(app.CreateWebhook))
+	router.GET("/webhooks/:id", app.requireAuth(app.GetWebhook))
+	router.PATCH("/webhooks/:id", app.requireAuth(app.UpdateWebhook))
+	router.DELETE("/webhooks/:id", app.requireAuth(app.DeleteWebhook))
+	router.POST("/webhooks/:id/ping", app.requireAuth(app.PingWebhook))
+	router.GET("/webhooks/:id/deliveries", app.requireAuth(app.WebhookDeliveries))
+	router.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", app.requireAuth(app.RedeliverWebhook))
+
+	// Admin moderation routes
+	router.GET("/admin/users", app.requireAdmin(app.AdminListUsers))
+	router.PATCH("/admin/users/:id", app.requireAdmin(app.AdminUpdateUser))
+	router.DELETE("/admin/polls/:id", app.requireAdmin(app.DeletePoll))
+	router.GET("/admin/audit", app.requireAdmin(app.AdminAuditLog))
 
 	router.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Not Found", http.StatusNotFound)
 	})
 
-	return app.enableCORS(router)
+	return app.instrumentHTTP(app.enableCORS(app.authenticate(router)))
 }
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"context"
+	"log"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// Values of Poll.state. A poll created with a future opens_at starts as a
+// draft; the scheduler opens it once that time passes and closes it again
+// at its expiry. Creators can also close and reopen polls by hand.
+// Recurring templates stay in the template state and never take votes
+// themselves; closing one pauses its series.
+const (
+	pollStateDraft    = "draft"
+	pollStateOpen     = "open"
+	pollStateClosed   = "closed"
+	pollStateTemplate = "template"
+)
+
+// pollActionReopened is the action of a poll.opened event sent when a
+// closed poll is reopened rather than opened for the first time
+const pollActionReopened = "reopened"
+
+// schedulerInterval is how often the scheduler looks for polls that are
+// due to open or close
+const schedulerInterval = 15 * time.Second
+
+// schedulerLockKey identifies the PostgreSQL advisory lock an instance
+// holds while it moves polls, so only one instance does it at a time
+const schedulerLockKey int64 = 0x706f6c6c73636864 // "pollschd"
+
+// pollTransition is a state change made by the scheduler, announced once
+// it has been committed
+type pollTransition struct {
+	pollID int
+	event  string
+}
+
+// advancePolls opens draft polls whose opens_at has passed, closes open
+// polls whose expiry has passed and starts the next occurrence of recurring
+// polls that are due, sending the matching events. It returns how many
+// polls it moved or created. If another instance holds the
+// scheduler lock it does nothing; that instance does the work instead.
+func (app *application) advancePolls(ctx context.Context, now time.Time) (int, error) {
+	var transitions []pollTransition
+	err := withTx(ctx, app.DB, func(tx *ent.Tx) error {
+		locked, err := app.trySchedulerLock(ctx, tx)
+		if err != nil || !locked {
+			return err
+		}
+
+		dueToOpen, err := tx.Poll.Query().
+			Where(poll.StateEQ(pollStateDraft)).
+			Where(poll.OpensAtLTE(now)).
+			IDs(ctx)
+		if err != nil {
+			return err
+		}
+		// Each update re-checks the state, so a poll its creator closed
+		// in the meantime stays closed
+		for _, id := range dueToOpen {
+			n, err := tx.Poll.Update().
+				Where(poll.IDEQ(id)).
+				Where(poll.StateEQ(pollStateDraft)).
+				SetState(pollStateOpen).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			if n == 1 {
+				transitions = append(transitions, pollTransition{id, eventPollOpened})
+			}
+		}
+
+		dueToClose, err := tx.Poll.Query().
+			Where(poll.StateEQ(pollStateOpen)).
+			Where(poll.ExpiresAtNotNil()).
+			Where(poll.ExpiresAtLTE(now)).
+			Select(poll.FieldID, poll.FieldExpiresAt).
+			All(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range dueToClose {
+			n, err := tx.Poll.Update().
+				Where(poll.IDEQ(p.ID)).
+				Where(poll.StateEQ(pollStateOpen)).
+				SetState(pollStateClosed).
+				SetClosedAt(p.ExpiresAt).
+				Save(ctx)
+			if err != nil {
+				return err
+			}
+			if n == 1 {
+				transitions = append(transitions, pollTransition{p.ID, eventPollExpired})
+			}
+		}
+
+		recurred, err := createOccurrences(ctx, tx, now)
+		if err != nil {
+			return err
+		}
+		transitions = append(transitions, recurred...)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, t := range transitions {
+		if t.event == eventPollCreated {
+			pollsCreated.WithLabelValues(pollSourceRecurrence).Inc()
+		}
+		app.notifyPollEvent(ctx, t.pollID, t.event, "")
+	}
+	return len(transitions), nil
+}
+
+// trySchedulerLock takes the scheduler lock for the rest of tx, reporting
+// false if another instance already holds it. Only PostgreSQL has advisory
+// locks; other databases (SQLite in development) are used by a single
+// instance, which always gets the lock.
+func (app *application) trySchedulerLock(ctx context.Context, tx *ent.Tx) (bool, error) {
+	if app.Dialect != dialect.Postgres {
+		return true, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", schedulerLockKey)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var locked bool
+	if rows.Next() {
+		if err := rows.Scan(&locked); err != nil {
+			return false, err
+		}
+	}
+	return locked, rows.Err()
+}
+
+// runScheduler runs advancePolls every interval until ctx is cancelled
+func (app *application) runScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := app.advancePolls(ctx, time.Now()); err != nil {
+			log.Printf("Warning: failed to open and close scheduled polls: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
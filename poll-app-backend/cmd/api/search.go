@@ -0,0 +1,228 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/polloption"
+	"backend/ent/predicate"
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+
+	"entgo.io/ent/dialect"
+)
+
+// Markers wrapped around matched terms in search highlights. Everything
+// outside the markers is HTML-escaped, so highlights are safe to render.
+const (
+	highlightStart = "<mark>"
+	highlightStop  = "</mark>"
+)
+
+// searchHit is one poll matched by a search, with its relevance and the
+// matching fragments of each searched field
+type searchHit struct {
+	PollID      int     `json:"poll_id"`
+	Rank        float64 `json:"rank"`
+	Title       string  `json:"title_highlight"`
+	Description string  `json:"description_highlight,omitempty"`
+	Options     string  `json:"options_highlight,omitempty"`
+}
+
+// pollSearcher runs full-text searches over public polls' titles,
+// descriptions and option texts, returning one page of hits ranked best
+// first and the total number of matches
+type pollSearcher interface {
+	Search(ctx context.Context, query string, limit, offset int) ([]searchHit, int, error)
+}
+
+// newPollSearcher picks the search implementation for a database dialect.
+// PostgreSQL gets real full-text search; anything else (SQLite in tests)
+// gets a simple substring matcher with the same interface.
+func newPollSearcher(driver string, client *ent.Client) pollSearcher {
+	if driver == dialect.Postgres {
+		return &postgresSearcher{client: client}
+	}
+	return &fallbackSearcher{client: client}
+}
+
+// pollSearchDocument is the weighted tsvector searched on PostgreSQL: title
+// ranks above description, which ranks above option text. The GIN index
+// polls_search_idx, created in the poll_search migration because ent can't
+// describe expression indexes, is built on exactly this expression.
+const pollSearchDocument = `setweight(to_tsvector('english', coalesce(title, '')), 'A') || ` +
+	`setweight(to_tsvector('english', coalesce(description, '')), 'B') || ` +
+	`setweight(to_tsvector('english', coalesce(search_text, '')), 'C')`
+
+// postgresSearcher searches with tsvector/tsquery, ranks with ts_rank and
+// highlights with ts_headline
+type postgresSearcher struct {
+	client *ent.Client
+}
+
+func (s *postgresSearcher) Search(ctx context.Context, query string, limit, offset int) ([]searchHit, int, error) {
+	// Escape the stored text before ts_headline adds its markers
+	headline := func(column string) string {
+		return fmt.Sprintf(`ts_headline('english', replace(replace(replace(coalesce(%s, ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), q, `+
+			`'StartSel=%s, StopSel=%s, MaxFragments=2, MinWords=5, MaxWords=20, FragmentDelimiter=" … "')`,
+			column, highlightStart, highlightStop)
+	}
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT id, ts_rank(%[1]s, q) AS rank, %[2]s, %[3]s, %[4]s, count(*) OVER () AS total
+		FROM %[5]s, websearch_to_tsquery('english', $1) AS q
+		WHERE (%[1]s) @@ q AND %[6]s = '%[7]s'
+		ORDER BY rank DESC, id DESC
+		LIMIT $2 OFFSET $3`,
+		pollSearchDocument, headline("title"), headline("description"), headline("search_text"), poll.Table,
+		poll.FieldVisibility, visibilityPublic)
+
+	rows, err := s.client.QueryContext(ctx, sqlQuery, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	hits := []searchHit{}
+	total := 0
+	for rows.Next() {
+		var hit searchHit
+		if err := rows.Scan(&hit.PollID, &hit.Rank, &hit.Title, &hit.Description, &hit.Options, &total); err != nil {
+			return nil, 0, err
+		}
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	// A page past the end has no rows to carry the total
+	if len(hits) == 0 && offset > 0 {
+		countQuery := fmt.Sprintf(`SELECT count(*) FROM %s, websearch_to_tsquery('english', $1) AS q WHERE (%s) @@ q AND %s = '%s'`,
+			poll.Table, pollSearchDocument, poll.FieldVisibility, visibilityPublic)
+		countRows, err := s.client.QueryContext(ctx, countQuery, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer countRows.Close()
+		if countRows.Next() {
+			if err := countRows.Scan(&total); err != nil {
+				return nil, 0, err
+			}
+		}
+		if err := countRows.Err(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return hits, total, nil
+}
+
+// fallbackSearcher matches every search term case-insensitively as a
+// substring, for databases without full-text search. Ranking counts matched
+// terms with the same field weights as PostgreSQL (title 3, description 2,
+// options 1). It loads every matching poll, so it is only meant for tests
+// and small datasets.
+type fallbackSearcher struct {
+	client *ent.Client
+}
+
+func (s *fallbackSearcher) Search(ctx context.Context, query string, limit, offset int) ([]searchHit, int, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return []searchHit{}, 0, nil
+	}
+
+	// Every term has to appear somewhere in a public poll
+	preds := []predicate.Poll{poll.VisibilityEQ(visibilityPublic)}
+	for _, term := range terms {
+		preds = append(preds, poll.Or(
+			poll.TitleContainsFold(term),
+			poll.DescriptionContainsFold(term),
+			poll.HasOptionsWith(polloption.OptionTextContainsFold(term)),
+		))
+	}
+
+	polls, err := s.client.Poll.Query().
+		Where(preds...).
+		WithOptions().
+		All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]searchHit, 0, len(polls))
+	for _, p := range polls {
+		optionTexts := make([]string, 0, len(p.Edges.Options))
+		for _, option := range p.Edges.Options {
+			optionTexts = append(optionTexts, option.OptionText)
+		}
+		options := strings.Join(optionTexts, "\n")
+
+		var rank float64
+		for _, term := range terms {
+			rank += 3 * float64(strings.Count(strings.ToLower(p.Title), term))
+			rank += 2 * float64(strings.Count(strings.ToLower(p.Description), term))
+			rank += float64(strings.Count(strings.ToLower(options), term))
+		}
+
+		hits = append(hits, searchHit{
+			PollID:      p.ID,
+			Rank:        rank,
+			Title:       highlightTerms(p.Title, terms),
+			Description: highlightTerms(p.Description, terms),
+			Options:     highlightTerms(options, terms),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].PollID > hits[j].PollID
+	})
+
+	total := len(hits)
+	if offset >= total {
+		return []searchHit{}, total, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return hits[offset:end], total, nil
+}
+
+// highlightTerms HTML-escapes text and wraps every case-insensitive
+// occurrence of the terms in highlight markers
+func highlightTerms(text string, terms []string) string {
+	if text == "" {
+		return ""
+	}
+
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = regexp.QuoteMeta(term)
+	}
+	re := regexp.MustCompile("(?i)" + strings.Join(quoted, "|"))
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		b.WriteString(highlightStart)
+		b.WriteString(html.EscapeString(text[loc[0]:loc[1]]))
+		b.WriteString(highlightStop)
+		last = loc[1]
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// pollSearchText builds the value of Poll.search_text from option texts
+func pollSearchText(options []string) string {
+	return strings.Join(options, "\n")
+}
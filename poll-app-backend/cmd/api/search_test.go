@@ -0,0 +1,98 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFallbackSearcher(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+	owner := createTestUser(t, app, "owner@example.com")
+
+	newPoll := func(title, description string, options ...string) int {
+		p, _ := createTestPoll(t, app, owner, pollTypeMultipleChoice, options...)
+		p, err := p.Update().SetTitle(title).SetDescription(description).Save(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p.ID
+	}
+
+	inTitle := newPoll("Best pizza topping", "Pick one", "Cheese", "Ham")
+	inOptions := newPoll("Friday lunch", "Team lunch order", "Pizza", "Sushi")
+	inDescription := newPoll("Dinner plans", "Pizza or pasta?", "Yes", "No")
+	newPoll("Editor wars", "Settle it", "Vim", "Emacs")
+
+	hidden := newPoll("Secret pizza", "", "A", "B")
+	if err := app.DB.Poll.UpdateOneID(hidden).SetVisibility(visibilityPrivate).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	search := func(query string, limit, offset int) ([]searchHit, int) {
+		t.Helper()
+		hits, total, err := app.Searcher.Search(ctx, query, limit, offset)
+		if err != nil {
+			t.Fatalf("Search(%q): %v", query, err)
+		}
+		return hits, total
+	}
+	ids := func(hits []searchHit) []int {
+		out := make([]int, len(hits))
+		for i, hit := range hits {
+			out[i] = hit.PollID
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"title outranks description outranks options", "PIZZA", []int{inTitle, inDescription, inOptions}},
+		{"every term must match", "pizza lunch", []int{inOptions}},
+		{"no match", "tacos", []int{}},
+		{"blank query", "   ", []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, total := search(tt.query, 10, 0)
+			if got := ids(hits); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = polls %v, want %v", tt.query, got, tt.want)
+			}
+			if total != len(tt.want) {
+				t.Errorf("Search(%q) total = %d, want %d", tt.query, total, len(tt.want))
+			}
+		})
+	}
+
+	t.Run("pages keep the total", func(t *testing.T) {
+		hits, total := search("pizza", 2, 2)
+		if got := ids(hits); !slices.Equal(got, []int{inOptions}) || total != 3 {
+			t.Errorf("second page = polls %v of %d, want [%d] of 3", got, total, inOptions)
+		}
+		hits, total = search("pizza", 2, 10)
+		if len(hits) != 0 || total != 3 {
+			t.Errorf("page past the end = %d hits of %d, want 0 of 3", len(hits), total)
+		}
+	})
+
+	t.Run("highlights", func(t *testing.T) {
+		hits, _ := search("pizza", 10, 0)
+		if want := "Best <mark>pizza</mark> topping"; hits[0].Title != want {
+			t.Errorf("title highlight = %q, want %q", hits[0].Title, want)
+		}
+		if want := "<mark>Pizza</mark>\nSushi"; hits[2].Options != want {
+			t.Errorf("options highlight = %q, want %q", hits[2].Options, want)
+		}
+	})
+}
+
+func TestHighlightTermsEscapesHTML(t *testing.T) {
+	got := highlightTerms(`<b>Tom & "Jerry"</b>`, []string{"jerry", "b"})
+	want := `&lt;<mark>b</mark>&gt;Tom &amp; &#34;<mark>Jerry</mark>&#34;&lt;/<mark>b</mark>&gt;`
+	if got != want {
+		t.Errorf("highlightTerms = %q, want %q", got, want)
+	}
+}
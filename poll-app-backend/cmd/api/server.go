@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// newServer builds the HTTP server with the configured limits. Result
+// streams extend their own write deadline, so WriteTimeout doesn't cut
+// them off.
+func (app *application) newServer(cfg *config) *http.Server {
+	srv := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           app.routes(),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		MaxHeaderBytes:    cfg.HTTP.MaxHeaderBytes,
+	}
+	// Shutdown waits for active connections, which results streams never
+	// stop being on their own
+	srv.RegisterOnShutdown(app.Results.Close)
+	return srv
+}
+
+// serve runs srv until the process gets SIGINT or SIGTERM, then drains it.
+// Readiness fails first and the server keeps serving for delay, so load
+// balancers stop sending requests before the listener closes; in-flight
+// requests then get up to timeout to finish before their connections are
+// cut. A second signal during the drain kills the process.
+func (app *application) serve(srv *http.Server, delay, timeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	app.ready.Store(true)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Printf("Shutting down: draining for %s", delay)
+	app.ready.Store(false)
+	time.Sleep(delay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		srv.Close()
+		return fmt.Errorf("requests still running after %s were cut off: %w", timeout, err)
+	}
+
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
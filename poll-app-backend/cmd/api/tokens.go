@@ -0,0 +1,87 @@
+package main
+
+import (
+	"backend/ent"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// tokenTTL is how long a session token issued by Login stays valid
+const tokenTTL = 24 * time.Hour
+
+var (
+	errInvalidToken = errors.New("invalid or malformed token")
+	errExpiredToken = errors.New("token has expired")
+	errRevokedToken = errors.New("session has ended, please sign in again")
+)
+
+// tokenClaims is the payload carried inside a session token
+type tokenClaims struct {
+	UserID    int    `json:"uid"`
+	Email     string `json:"email"`
+	Version   int    `json:"ver"` // the user's token_version when the token was issued
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// generateToken issues a signed session token for a user.
+// The format is base64url(payload) + "." + base64url(HMAC-SHA256(payload)).
+func (app *application) generateToken(u *ent.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(tokenTTL)
+
+	payload, err := json.Marshal(tokenClaims{
+		UserID:    u.ID,
+		Email:     u.Email,
+		Version:   u.TokenVersion,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + app.signToken(encoded), expiresAt, nil
+}
+
+// parseToken verifies a session token's signature and expiry and returns its claims
+func (app *application) parseToken(token string) (*tokenClaims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || encoded == "" || signature == "" {
+		return nil, errInvalidToken
+	}
+
+	// Compare signatures in constant time
+	if !hmac.Equal([]byte(signature), []byte(app.signToken(encoded))) {
+		return nil, errInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, errInvalidToken
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, errExpiredToken
+	}
+
+	return &claims, nil
+}
+
+// signToken returns the base64url HMAC-SHA256 signature of an encoded payload
+func (app *application) signToken(encoded string) string {
+	mac := hmac.New(sha256.New, app.TokenSecret)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -5,6 +5,9 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
 )
 
 type JSONResponse struct {
@@ -62,4 +65,43 @@ func (app *application) errorJSON(w http.ResponseWriter, err error, status ...in
 	}
 
 	return app.writeJSON(w, statusCode, JSONResponse{Error: true, Message: err.Error()})
-}
\ No newline at end of file
+}
+
+// requestError is an error that should be reported to the client with a
+// specific HTTP status, for use where the handler isn't the one detecting it
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e *requestError) Error() string { return e.err.Error() }
+func (e *requestError) Unwrap() error { return e.err }
+
+// newRequestError wraps err so statusFromError reports it with status
+func newRequestError(status int, err error) error {
+	return &requestError{status: status, err: err}
+}
+
+// statusFromError returns the HTTP status carried by a requestError,
+// or 500 for anything else
+func statusFromError(err error) int {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return reqErr.status
+	}
+	return http.StatusInternalServerError
+}
+
+// readIDParam parses the ":id" route parameter as a positive integer
+func (app *application) readIDParam(ps httprouter.Params) (int, error) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil || id < 1 {
+		return 0, errors.New("invalid ID parameter")
+	}
+	return id, nil
+}
+
+// intPtr returns a pointer to v, for optional request fields
+func intPtr(v int) *int {
+	return &v
+}
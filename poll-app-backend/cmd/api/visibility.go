@@ -0,0 +1,331 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/pollinvite"
+	"backend/ent/user"
+	"backend/ent/vote"
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Accepted values of Poll.visibility. Public polls are listed and searchable;
+// unlisted ones work the same but only for people who have the link; private
+// ones are limited to the creator, admins, invited emails and holders of an
+// invite link.
+const (
+	visibilityPublic   = "public"
+	visibilityUnlisted = "unlisted"
+	visibilityPrivate  = "private"
+)
+
+// pollVisibilities lists every visibility, for validation
+var pollVisibilities = []string{visibilityPublic, visibilityUnlisted, visibilityPrivate}
+
+// maxInvitedEmails caps the invite list of one poll
+const maxInvitedEmails = 500
+
+// inviteSigningPrefix keeps invite tokens and session tokens from being
+// accepted in place of each other, though both are signed with TokenSecret
+const inviteSigningPrefix = "invite."
+
+// errPollHidden is returned to people without access to a private poll. It
+// reads the same as a missing poll so private polls don't reveal themselves.
+var errPollHidden = newRequestError(http.StatusNotFound, errors.New("poll not found"))
+
+// inviteClaims is the payload carried inside an invite token
+type inviteClaims struct {
+	PollID   int `json:"pid"`
+	InviteID int `json:"iid"`
+}
+
+// validateVisibility checks a visibility value from a request
+func validateVisibility(v string) error {
+	if !slices.Contains(pollVisibilities, v) {
+		return errors.New("visibility must be 'public', 'unlisted' or 'private'")
+	}
+	return nil
+}
+
+// normalizeInvitedEmails validates an invite list, returning it lower-cased
+// and without duplicates
+func normalizeInvitedEmails(emails []string) ([]string, error) {
+	if len(emails) > maxInvitedEmails {
+		return nil, fmt.Errorf("at most %d emails can be invited", maxInvitedEmails)
+	}
+	out := make([]string, 0, len(emails))
+	for _, raw := range emails {
+		email, err := normalizeEmail(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invited email %q: %w", raw, err)
+		}
+		if !slices.Contains(out, email) {
+			out = append(out, email)
+		}
+	}
+	return out, nil
+}
+
+// generateInviteToken returns the signed token for an invite link. The
+// token only names the invite, so it is the same every time it is generated
+// and is checked against the stored invite when used.
+func (app *application) generateInviteToken(pollID, inviteID int) (string, error) {
+	payload, err := json.Marshal(inviteClaims{PollID: pollID, InviteID: inviteID})
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + app.signToken(inviteSigningPrefix+encoded), nil
+}
+
+// parseInviteToken verifies an invite token's signature and returns its claims
+func (app *application) parseInviteToken(token string) (*inviteClaims, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found || encoded == "" || signature == "" {
+		return nil, errInvalidToken
+	}
+	if !hmac.Equal([]byte(signature), []byte(app.signToken(inviteSigningPrefix+encoded))) {
+		return nil, errInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	var claims inviteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.PollID < 1 || claims.InviteID < 1 {
+		return nil, errInvalidToken
+	}
+	return &claims, nil
+}
+
+// inviteActive reports whether an invite link still grants access
+func inviteActive(invite *ent.PollInvite, now time.Time) bool {
+	return invite.RevokedAt == nil && (invite.ExpiresAt == nil || now.Before(*invite.ExpiresAt))
+}
+
+// checkPollAccess returns errPollHidden unless u (nil when anonymous) or the
+// holder of inviteToken may see and vote on the poll. Only private polls
+// restrict anyone.
+func (app *application) checkPollAccess(ctx context.Context, client *ent.Client, pollData *ent.Poll, u *ent.User, inviteToken string) error {
+	if pollData.Visibility != visibilityPrivate {
+		return nil
+	}
+
+	if u != nil {
+		if err := loadPollOwner(ctx, client, pollData); err != nil {
+			return err
+		}
+		if canManagePoll(pollData, u) {
+			return nil
+		}
+		for _, email := range pollData.InvitedEmails {
+			if strings.EqualFold(email, u.Email) {
+				return nil
+			}
+		}
+	}
+
+	if inviteToken != "" {
+		claims, err := app.parseInviteToken(inviteToken)
+		if err != nil || claims.PollID != pollData.ID {
+			return errPollHidden
+		}
+		invite, err := client.PollInvite.Query().
+			Where(pollinvite.IDEQ(claims.InviteID)).
+			Where(pollinvite.HasPollWith(poll.IDEQ(pollData.ID))).
+			Only(ctx)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return errPollHidden
+			}
+			return err
+		}
+		if inviteActive(invite, time.Now()) {
+			return nil
+		}
+	}
+
+	return errPollHidden
+}
+
+// requestPollAccess is checkPollAccess for the caller of a read-only
+// request, who may pass an invite token as ?invite=
+func (app *application) requestPollAccess(r *http.Request, pollData *ent.Poll) error {
+	return app.checkPollAccess(r.Context(), app.DB, pollData, app.contextGetUser(r), r.URL.Query().Get("invite"))
+}
+
+// Accepted values of Poll.results_visibility: when people other than the
+// poll's creator and admins may see its vote counts
+const (
+	resultsAlways     = "always"
+	resultsAfterVote  = "after_vote"
+	resultsAfterClose = "after_close"
+	resultsNever      = "never"
+)
+
+// resultsVisibilities lists every results visibility, for validation
+var resultsVisibilities = []string{resultsAlways, resultsAfterVote, resultsAfterClose, resultsNever}
+
+// validateResultsVisibility checks a results visibility value from a request
+func validateResultsVisibility(v string) error {
+	if !slices.Contains(resultsVisibilities, v) {
+		return errors.New("results_visibility must be 'always', 'after_vote', 'after_close' or 'never'")
+	}
+	return nil
+}
+
+// pollView is a poll as shown to one caller. When its results are hidden
+// from them the options' vote counts are left out and ResultsHidden is set;
+// the poll's total vote count stays visible.
+type pollView struct {
+	*ent.Poll
+	ResultsHidden bool `json:"results_hidden,omitempty"`
+}
+
+// pollEnded reports whether a poll was closed or has expired
+func pollEnded(pollData *ent.Poll, now time.Time) bool {
+	return pollData.ClosedAt != nil || (!pollData.ExpiresAt.IsZero() && now.After(pollData.ExpiresAt))
+}
+
+// pollViews works out which of the polls' results viewer may see, and
+// redacts the rest. Polls are changed in place.
+func (app *application) pollViews(ctx context.Context, viewer voterRef, polls []*ent.Poll) ([]pollView, error) {
+	views := make([]pollView, len(polls))
+
+	// One query finds which of the restricted polls a signed-in viewer
+	// owns; admins manage them all
+	owned := make(map[int]bool)
+	if u := viewer.user; u != nil && !isAdmin(u) {
+		var restricted []int
+		for _, p := range polls {
+			if p.ResultsVisibility != resultsAlways {
+				restricted = append(restricted, p.ID)
+			}
+		}
+		if len(restricted) > 0 {
+			ids, err := app.DB.Poll.Query().
+				Where(poll.IDIn(restricted...)).
+				Where(poll.HasOwnerWith(user.IDEQ(u.ID))).
+				IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				owned[id] = true
+			}
+		}
+	}
+
+	// One query finds which after_vote polls the viewer has voted on
+	voted := make(map[int]bool)
+	if viewer.known() {
+		var afterVote []int
+		for _, p := range polls {
+			if p.ResultsVisibility == resultsAfterVote {
+				afterVote = append(afterVote, p.ID)
+			}
+		}
+		if len(afterVote) > 0 {
+			ids, err := app.DB.Vote.Query().
+				Where(viewer.votes()).
+				Where(vote.HasPollWith(poll.IDIn(afterVote...))).
+				QueryPoll().
+				IDs(ctx)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				voted[id] = true
+			}
+		}
+	}
+
+	now := time.Now()
+	for i, p := range polls {
+		views[i] = pollView{Poll: p}
+		manager := isAdmin(viewer.user) || owned[p.ID]
+		if resultsHiddenReason(p, manager, voted[p.ID], now) == nil {
+			continue
+		}
+		views[i].ResultsHidden = true
+		for _, option := range p.Edges.Options {
+			option.VoteCount = 0
+		}
+		// Votes loaded with their options carry the counts too
+		for _, v := range p.Edges.Votes {
+			if v.Edges.Option != nil {
+				v.Edges.Option.VoteCount = 0
+			}
+		}
+	}
+	return views, nil
+}
+
+// pollViewFor is pollViews for a single poll
+func (app *application) pollViewFor(ctx context.Context, viewer voterRef, pollData *ent.Poll) (pollView, error) {
+	views, err := app.pollViews(ctx, viewer, []*ent.Poll{pollData})
+	if err != nil {
+		return pollView{}, err
+	}
+	return views[0], nil
+}
+
+// resultsHiddenReason returns nil if a caller may see the poll's results,
+// or the error explaining when they will. manager says whether the caller
+// may manage the poll, and hasVoted whether they voted on it.
+func resultsHiddenReason(pollData *ent.Poll, manager, hasVoted bool, now time.Time) error {
+	if manager {
+		return nil
+	}
+	switch pollData.ResultsVisibility {
+	case resultsAfterVote:
+		if !hasVoted {
+			return newRequestError(http.StatusForbidden, errors.New("results of this poll are shown once you have voted"))
+		}
+	case resultsAfterClose:
+		if !pollEnded(pollData, now) {
+			return newRequestError(http.StatusForbidden, errors.New("results of this poll are shown once it closes"))
+		}
+	case resultsNever:
+		return newRequestError(http.StatusForbidden, errors.New("results of this poll are only visible to its creator"))
+	}
+	return nil
+}
+
+// requestResultsAccess returns an error unless the caller of r may see the
+// poll's results
+func (app *application) requestResultsAccess(r *http.Request, pollData *ent.Poll) error {
+	viewer := app.requestVoter(r)
+
+	manager := false
+	if viewer.user != nil {
+		if err := loadPollOwner(r.Context(), app.DB, pollData); err != nil {
+			return err
+		}
+		manager = canManagePoll(pollData, viewer.user)
+	}
+
+	hasVoted := false
+	if viewer.known() && pollData.ResultsVisibility == resultsAfterVote {
+		var err error
+		hasVoted, err = app.DB.Vote.Query().
+			Where(viewer.votes()).
+			Where(vote.HasPollWith(poll.IDEQ(pollData.ID))).
+			Exist(r.Context())
+		if err != nil {
+			return err
+		}
+	}
+
+	return resultsHiddenReason(pollData, manager, hasVoted, time.Now())
+}
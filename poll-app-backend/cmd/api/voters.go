@@ -0,0 +1,96 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/predicate"
+	"backend/ent/user"
+	"backend/ent/vote"
+	"crypto/hmac"
+	"crypto/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// voterCookieName is the cookie carrying an anonymous voter's signed ID
+const voterCookieName = "poll_voter"
+
+// voterCookieTTL is how long a browser keeps its anonymous voter ID
+const voterCookieTTL = 365 * 24 * time.Hour
+
+// voterSigningPrefix keeps voter IDs, session tokens and invite tokens from
+// being accepted in place of each other, though all are signed with
+// TokenSecret
+const voterSigningPrefix = "voter."
+
+// anonymousVoterPrefix starts the voter_identifier of anonymous votes.
+// Signed-in votes store the user's email, which never starts with it.
+const anonymousVoterPrefix = "anon:"
+
+// voterRef is whoever casts a ballot: a signed-in user, or an anonymous
+// visitor known only by the ID in their voter cookie. The zero value is an
+// anonymous caller without a cookie, who has no votes.
+type voterRef struct {
+	user        *ent.User
+	anonymousID string
+}
+
+// known reports whether the voter can have votes at all
+func (v voterRef) known() bool {
+	return v.user != nil || v.anonymousID != ""
+}
+
+// identifier is what the voter's votes store in voter_identifier
+func (v voterRef) identifier() string {
+	if v.user != nil {
+		return v.user.Email
+	}
+	return anonymousVoterPrefix + v.anonymousID
+}
+
+// votes matches the voter's votes. A user's are found by the voter edge,
+// so an account that reuses a deleted one's email doesn't inherit them;
+// anonymous votes never have a voter edge.
+func (v voterRef) votes() predicate.Vote {
+	if v.user != nil {
+		return vote.HasVoterWith(user.IDEQ(v.user.ID))
+	}
+	return vote.And(vote.VoterIdentifierEQ(v.identifier()), vote.Not(vote.HasVoter()))
+}
+
+// requestVoter returns who is voting in r: the signed-in user, or else the
+// anonymous voter named by a valid voter cookie
+func (app *application) requestVoter(r *http.Request) voterRef {
+	if u := app.contextGetUser(r); u != nil {
+		return voterRef{user: u}
+	}
+	if c, err := r.Cookie(voterCookieName); err == nil {
+		if id, signature, found := strings.Cut(c.Value, "."); found && id != "" &&
+			hmac.Equal([]byte(signature), []byte(app.signToken(voterSigningPrefix+id))) {
+			return voterRef{anonymousID: id}
+		}
+	}
+	return voterRef{}
+}
+
+// requestOrNewVoter is requestVoter for casting a ballot: an anonymous
+// caller without a valid cookie is given a new ID, set as a cookie on w.
+// Clearing the cookie makes a new anonymous voter; signed-in voting is the
+// way to hold voters to one ballot.
+func (app *application) requestOrNewVoter(w http.ResponseWriter, r *http.Request) voterRef {
+	if v := app.requestVoter(r); v.known() {
+		return v
+	}
+
+	id := rand.Text()
+	http.SetCookie(w, &http.Cookie{
+		Name:     voterCookieName,
+		Value:    id + "." + app.signToken(voterSigningPrefix+id),
+		Path:     "/",
+		MaxAge:   int(voterCookieTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return voterRef{anonymousID: id}
+}
@@ -0,0 +1,510 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/vote"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// Values of the "action" field of poll.voted webhook events
+const (
+	voteActionCast      = "cast"
+	voteActionChanged   = "changed"
+	voteActionWithdrawn = "withdrawn"
+)
+
+// voteRejection marks why a ballot was turned away, for votes_rejected_total.
+// It wraps the error the caller sees, which keeps its status.
+type voteRejection struct {
+	reason string
+	err    error
+}
+
+func (e *voteRejection) Error() string { return e.err.Error() }
+func (e *voteRejection) Unwrap() error { return e.err }
+
+// rejectVote tags err with the reason a ballot was rejected
+func rejectVote(reason string, err error) error {
+	return &voteRejection{reason: reason, err: err}
+}
+
+// optionScore is one entry of a rating ballot
+type optionScore struct {
+	OptionID int `json:"option_id"`
+	Score    int `json:"score"`
+}
+
+// ballotFromScores turns a rating ballot into the option IDs it covers and a
+// score per option. Ballots without scores pass through unchanged.
+func ballotFromScores(optionIDs []int, scores []optionScore) ([]int, map[int]int, error) {
+	if len(scores) == 0 {
+		return optionIDs, nil, nil
+	}
+	if len(optionIDs) > 0 {
+		return nil, nil, errors.New("send either option_ids or scores, not both")
+	}
+
+	ids := make([]int, 0, len(scores))
+	scoreByOption := make(map[int]int, len(scores))
+	for _, s := range scores {
+		ids = append(ids, s.OptionID)
+		scoreByOption[s.OptionID] = s.Score
+	}
+	return ids, scoreByOption, nil
+}
+
+// validateSelection checks a ballot's option IDs before touching the database
+func validateSelection(optionIDs []int) error {
+	if len(optionIDs) == 0 {
+		return errors.New("at least one option must be selected")
+	}
+
+	// 🚫 NO REPEATS IN ONE BALLOT: The same option can't be picked twice
+	seenOptionIDs := make(map[int]bool)
+	for _, optionID := range optionIDs {
+		if seenOptionIDs[optionID] {
+			return fmt.Errorf("option %d was selected more than once", optionID)
+		}
+		seenOptionIDs[optionID] = true
+	}
+	return nil
+}
+
+// castVotes records a voter's selected options on a poll. Every check and
+// write runs in one transaction that holds a row lock on the poll, so two
+// concurrent ballots from the same voter can't both pass the per-voter limit,
+// and a failed vote_count update rolls back the votes with it. The unique
+// (voter, poll, option) index on votes backs up the duplicate check.
+// inviteToken is the voter's invite link token for a private poll, if any.
+func (app *application) castVotes(ctx context.Context, pollID int, optionIDs []int, scores map[int]int, voter voterRef, inviteToken string) ([]*ent.Vote, error) {
+	var createdVotes []*ent.Vote
+	var pollType string
+
+	err := withTx(ctx, app.DB, func(tx *ent.Tx) error {
+		// 🔒 LOCK POLL: Ballots for the same poll queue up behind this one
+		pollData, err := app.lockVotablePoll(ctx, tx, pollID, voter.user, inviteToken)
+		if err != nil {
+			return err
+		}
+		pollType = pollData.PollType
+
+		// 🎯 VALIDATE OPTION IDS: Make sure all selected options belong to this poll
+		if err := checkOptionsBelong(ctx, pollData, optionIDs); err != nil {
+			return err
+		}
+
+		// ⭐ VALIDATE SCORES: Rating polls need a score in range for every option
+		if err := checkScores(ctx, pollData, optionIDs, scores); err != nil {
+			return err
+		}
+
+		// 🔍 CHECK EXISTING VOTES: See what this user already voted for
+		existingVotes, err := voterVotes(ctx, tx, pollID, voter)
+		if err != nil {
+			return err
+		}
+
+		// 🚫 PREVENT DUPLICATE VOTING: Single choice and ranked ballots are cast once
+		if isSingleBallotType(pollData.PollType) && len(existingVotes) > 0 {
+			return rejectVote(voteRejectAlreadyVoted,
+				newRequestError(http.StatusBadRequest, errors.New("you have already voted on this poll")))
+		}
+
+		// 🚫 CHECK FOR DUPLICATE VOTES: Make sure user isn't voting for same option twice
+		for _, existingVote := range existingVotes {
+			if existingVote.Edges.Option == nil {
+				continue
+			}
+			for _, optionID := range optionIDs {
+				if existingVote.Edges.Option.ID == optionID {
+					return rejectVote(voteRejectAlreadyVoted, newRequestError(http.StatusBadRequest,
+						fmt.Errorf("you have already voted for option %d", optionID)))
+				}
+			}
+		}
+
+		// 🔢 VALIDATE LIMITS: Check the user wouldn't exceed max votes
+		if err := checkVoteLimit(pollData, len(existingVotes)+len(optionIDs)); err != nil {
+			return err
+		}
+
+		// 🗳️ CREATE VOTES: All validation passed, now create the vote records
+		createdVotes, err = insertVotes(ctx, tx, pollData, optionIDs, scores, voter)
+		return err
+	})
+	if err != nil {
+		recordVoteRejection(err)
+		return nil, err
+	}
+	votesCast.WithLabelValues(pollType).Inc()
+
+	app.publishResults(ctx, pollID)
+	app.notifyPollEvent(ctx, pollID, eventPollVoted, voteActionCast)
+	return createdVotes, nil
+}
+
+// replaceVotes swaps a voter's current selection on a poll for a new one
+func (app *application) replaceVotes(ctx context.Context, pollID int, optionIDs []int, scores map[int]int, voter voterRef, inviteToken string) ([]*ent.Vote, error) {
+	var createdVotes []*ent.Vote
+
+	err := withTx(ctx, app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockVotablePoll(ctx, tx, pollID, voter.user, inviteToken)
+		if err != nil {
+			return err
+		}
+		if !pollData.AllowVoteChanges {
+			return rejectVote(voteRejectNoChanges,
+				newRequestError(http.StatusForbidden, errors.New("votes on this poll can't be changed")))
+		}
+
+		if err := checkOptionsBelong(ctx, pollData, optionIDs); err != nil {
+			return err
+		}
+
+		// ⭐ VALIDATE SCORES: Rating polls need a score in range for every option
+		if err := checkScores(ctx, pollData, optionIDs, scores); err != nil {
+			return err
+		}
+		if err := checkVoteLimit(pollData, len(optionIDs)); err != nil {
+			return err
+		}
+
+		existingVotes, err := voterVotes(ctx, tx, pollID, voter)
+		if err != nil {
+			return err
+		}
+		if len(existingVotes) == 0 {
+			return rejectVote(voteRejectNotVoted,
+				newRequestError(http.StatusBadRequest, errors.New("you haven't voted on this poll yet")))
+		}
+
+		// Remove the old selection before inserting the new one so the
+		// unique index doesn't trip on options that appear in both
+		if err := deleteVotes(ctx, tx, pollID, existingVotes); err != nil {
+			return err
+		}
+
+		createdVotes, err = insertVotes(ctx, tx, pollData, optionIDs, scores, voter)
+		return err
+	})
+	if err != nil {
+		recordVoteRejection(err)
+		return nil, err
+	}
+
+	app.publishResults(ctx, pollID)
+	app.notifyPollEvent(ctx, pollID, eventPollVoted, voteActionChanged)
+	return createdVotes, nil
+}
+
+// retractVotes withdraws all of a voter's votes on a poll and returns
+// how many were removed
+func (app *application) retractVotes(ctx context.Context, pollID int, voter voterRef) (int, error) {
+	var removed int
+
+	err := withTx(ctx, app.DB, func(tx *ent.Tx) error {
+		pollData, err := app.lockOpenPoll(ctx, tx, pollID)
+		if err != nil {
+			return err
+		}
+		if !pollData.AllowVoteChanges {
+			return rejectVote(voteRejectNoChanges,
+				newRequestError(http.StatusForbidden, errors.New("votes on this poll can't be withdrawn")))
+		}
+
+		existingVotes, err := voterVotes(ctx, tx, pollID, voter)
+		if err != nil {
+			return err
+		}
+		if len(existingVotes) == 0 {
+			return rejectVote(voteRejectNotVoted,
+				newRequestError(http.StatusNotFound, errors.New("you haven't voted on this poll")))
+		}
+
+		removed = len(existingVotes)
+		return deleteVotes(ctx, tx, pollID, existingVotes)
+	})
+	if err != nil {
+		recordVoteRejection(err)
+		return 0, err
+	}
+
+	app.publishResults(ctx, pollID)
+	app.notifyPollEvent(ctx, pollID, eventPollVoted, voteActionWithdrawn)
+	return removed, nil
+}
+
+// lockPoll loads a poll with a row lock held until the transaction ends.
+// SQLite has no row locks, but it only runs one write transaction at a
+// time, which gives the same guarantee.
+func (app *application) lockPoll(ctx context.Context, tx *ent.Tx, pollID int) (*ent.Poll, error) {
+	query := tx.Poll.Query().Where(poll.IDEQ(pollID))
+	if app.Dialect != dialect.SQLite {
+		query = query.ForUpdate()
+	}
+
+	pollData, err := query.Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, rejectVote(voteRejectPollNotFound,
+				newRequestError(http.StatusNotFound, errors.New("poll not found")))
+		}
+		return nil, err
+	}
+	return pollData, nil
+}
+
+// lockOpenPoll is lockPoll for a poll that must still be accepting votes
+func (app *application) lockOpenPoll(ctx context.Context, tx *ent.Tx, pollID int) (*ent.Poll, error) {
+	pollData, err := app.lockPoll(ctx, tx, pollID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPollOpen(pollData); err != nil {
+		return nil, err
+	}
+	return pollData, nil
+}
+
+// lockVotablePoll is lockOpenPoll for a voter who must also have access to
+// the poll, checked first so a private poll's state isn't revealed
+func (app *application) lockVotablePoll(ctx context.Context, tx *ent.Tx, pollID int, voter *ent.User, inviteToken string) (*ent.Poll, error) {
+	pollData, err := app.lockPoll(ctx, tx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🔐 CHECK ACCESS: Private polls only take invited voters
+	if err := app.checkPollAccess(ctx, tx.Client(), pollData, voter, inviteToken); err != nil {
+		return nil, rejectVote(voteRejectNoAccess, err)
+	}
+
+	if err := checkPollOpen(pollData); err != nil {
+		return nil, err
+	}
+	return pollData, nil
+}
+
+// checkPollOpen returns an error unless the poll is accepting votes
+func checkPollOpen(pollData *ent.Poll) error {
+	// 🗓️ CHECK OPENED: Draft polls wait for the scheduler to open them
+	if pollData.State == pollStateDraft {
+		return rejectVote(voteRejectNotOpen, newRequestError(http.StatusBadRequest, errors.New("poll hasn't opened yet")))
+	}
+
+	// 🔁 CHECK TEMPLATE: Recurring polls are voted on one occurrence at a time
+	if pollData.State == pollStateTemplate {
+		return rejectVote(voteRejectNotOpen,
+			newRequestError(http.StatusBadRequest, errors.New("poll is a recurring template; vote on its latest occurrence")))
+	}
+
+	// ⏰ CHECK EXPIRY: For optional time fields in Ent, zero time means "no expiry set".
+	// The scheduler closes expired polls, but may not have got to this one yet.
+	if !pollData.ExpiresAt.IsZero() && time.Now().After(pollData.ExpiresAt) {
+		return rejectVote(voteRejectExpired, newRequestError(http.StatusBadRequest, errors.New("poll has expired")))
+	}
+
+	// 🔒 CHECK CLOSED: The creator may have ended the poll early
+	if pollData.State == pollStateClosed {
+		return rejectVote(voteRejectClosed, newRequestError(http.StatusBadRequest, errors.New("poll is closed")))
+	}
+
+	return nil
+}
+
+// checkOptionsBelong makes sure every option ID is one of the poll's options
+func checkOptionsBelong(ctx context.Context, pollData *ent.Poll, optionIDs []int) error {
+	options, err := pollData.QueryOptions().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	validOptionIDs := make(map[int]bool)
+	for _, option := range options {
+		validOptionIDs[option.ID] = true
+	}
+	for _, optionID := range optionIDs {
+		if !validOptionIDs[optionID] {
+			return newRequestError(http.StatusBadRequest,
+				fmt.Errorf("option ID %d does not belong to poll %d", optionID, pollData.ID))
+		}
+	}
+
+	return nil
+}
+
+// checkScores validates the scores on a ballot against the poll's type and scale
+func checkScores(ctx context.Context, pollData *ent.Poll, optionIDs []int, scores map[int]int) error {
+	if pollData.PollType != pollTypeRating {
+		if len(scores) > 0 {
+			return newRequestError(http.StatusBadRequest, errors.New("scores are only accepted on rating polls"))
+		}
+		return nil
+	}
+
+	// Every option must be rated
+	optionCount, err := pollData.QueryOptions().Count(ctx)
+	if err != nil {
+		return err
+	}
+	if len(optionIDs) != optionCount {
+		return newRequestError(http.StatusBadRequest,
+			fmt.Errorf("rating polls need a score for all %d options", optionCount))
+	}
+
+	minScore, maxScore := ratingScale(pollData)
+	for _, optionID := range optionIDs {
+		score, ok := scores[optionID]
+		if !ok {
+			return newRequestError(http.StatusBadRequest, fmt.Errorf("missing score for option %d", optionID))
+		}
+		if score < minScore || score > maxScore {
+			return newRequestError(http.StatusBadRequest,
+				fmt.Errorf("score for option %d must be between %d and %d", optionID, minScore, maxScore))
+		}
+	}
+
+	return nil
+}
+
+// ratingScale returns a rating poll's score range, falling back to the default
+func ratingScale(pollData *ent.Poll) (int, int) {
+	minScore, maxScore := defaultRatingMin, defaultRatingMax
+	if pollData.RatingMin != nil {
+		minScore = *pollData.RatingMin
+	}
+	if pollData.RatingMax != nil {
+		maxScore = *pollData.RatingMax
+	}
+	return minScore, maxScore
+}
+
+// checkVoteLimit makes sure a voter would hold no more than the poll allows
+func checkVoteLimit(pollData *ent.Poll, total int) error {
+	maxVotes := pollData.MaxVotesPerUser
+	if pollData.PollType == pollTypeSingleChoice {
+		maxVotes = 1
+	}
+
+	if total > maxVotes {
+		return rejectVote(voteRejectLimit, newRequestError(http.StatusBadRequest,
+			fmt.Errorf("you can only vote for %d options total, but you're trying to vote for %d",
+				maxVotes, total)))
+	}
+	return nil
+}
+
+// voterVotes returns a voter's votes on a poll with their options loaded
+func voterVotes(ctx context.Context, tx *ent.Tx, pollID int, voter voterRef) ([]*ent.Vote, error) {
+	if !voter.known() {
+		return nil, nil
+	}
+	return tx.Vote.Query().
+		Where(voter.votes()).
+		Where(vote.HasPollWith(poll.IDEQ(pollID))).
+		WithOption().
+		All(ctx)
+}
+
+// insertVotes creates one vote per option and increments each option's count.
+// On ranked_choice polls the options are stored in preference order and only
+// the first choice counts towards vote_count. On rating polls each vote
+// carries the option's score.
+func insertVotes(ctx context.Context, tx *ent.Tx, pollData *ent.Poll, optionIDs []int, scores map[int]int, voter voterRef) ([]*ent.Vote, error) {
+	ranked := pollData.PollType == pollTypeRankedChoice
+
+	builders := make([]*ent.VoteCreate, 0, len(optionIDs))
+	for i, optionID := range optionIDs {
+		builder := tx.Vote.Create().
+			SetVoterIdentifier(voter.identifier()).
+			SetPollID(pollData.ID).
+			SetOptionID(optionID)
+		if voter.user != nil {
+			builder = builder.SetVoter(voter.user)
+		}
+		if ranked {
+			builder = builder.SetRank(i + 1)
+		}
+		if score, ok := scores[optionID]; ok {
+			builder = builder.SetScore(score)
+		}
+		builders = append(builders, builder)
+	}
+
+	createdVotes, err := tx.Vote.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, rejectVote(voteRejectAlreadyVoted,
+				newRequestError(http.StatusConflict, errors.New("you have already voted for one of these options")))
+		}
+		return nil, fmt.Errorf("failed to create votes: %w", err)
+	}
+
+	// ➕ UPDATE COUNTS: Increment each option's vote count in the same transaction
+	if ranked {
+		optionIDs = optionIDs[:1]
+	}
+	for _, optionID := range optionIDs {
+		err := tx.PollOption.UpdateOneID(optionID).
+			AddVoteCount(1).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update vote count for option %d: %w", optionID, err)
+		}
+	}
+
+	// Keep the poll's running total in step for sorting
+	err = tx.Poll.UpdateOneID(pollData.ID).
+		AddTotalVotes(len(optionIDs)).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update vote total for poll %d: %w", pollData.ID, err)
+	}
+
+	return createdVotes, nil
+}
+
+// deleteVotes removes votes (loaded with their options) from a poll and
+// decrements each option's count and the poll's total to match
+func deleteVotes(ctx context.Context, tx *ent.Tx, pollID int, votes []*ent.Vote) error {
+	voteIDs := make([]int, 0, len(votes))
+	for _, v := range votes {
+		voteIDs = append(voteIDs, v.ID)
+	}
+
+	_, err := tx.Vote.Delete().Where(vote.IDIn(voteIDs...)).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete votes: %w", err)
+	}
+
+	counted := 0
+	for _, v := range votes {
+		// Lower preferences on ranked ballots were never counted
+		if v.Edges.Option == nil || (v.Rank != nil && *v.Rank != 1) {
+			continue
+		}
+		err := tx.PollOption.UpdateOneID(v.Edges.Option.ID).
+			AddVoteCount(-1).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update vote count for option %d: %w", v.Edges.Option.ID, err)
+		}
+		counted++
+	}
+
+	err = tx.Poll.UpdateOneID(pollID).
+		AddTotalVotes(-counted).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update vote total for poll %d: %w", pollID, err)
+	}
+
+	return nil
+}
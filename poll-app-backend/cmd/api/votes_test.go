@@ -0,0 +1,284 @@
+package main
+
+import (
+	"backend/ent/poll"
+	"backend/ent/vote"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// TestCastVotesConcurrentSingleChoice sends the same voter's ballot at a
+// single choice poll many times at once. Exactly one may be recorded; the
+// rest must be turned away as duplicates rather than failing or slipping
+// through.
+func TestCastVotesConcurrentSingleChoice(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	u := createTestUser(t, app, "voter@example.com")
+	voter := voterRef{user: u}
+	p, options := createTestPoll(t, app, u, pollTypeSingleChoice, "Yes", "No")
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = app.castVotes(ctx, p.ID, []int{options[i%2].ID}, nil, voter, "")
+		}()
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, err := range errs {
+		if err == nil {
+			accepted++
+			continue
+		}
+		if status := statusFromError(err); status != http.StatusBadRequest && status != http.StatusConflict {
+			t.Errorf("castVotes failed with status %d, want a rejected duplicate: %v", status, err)
+		}
+		var rejection *voteRejection
+		if !errors.As(err, &rejection) || rejection.reason != voteRejectAlreadyVoted {
+			t.Errorf("castVotes error %v isn't an already-voted rejection", err)
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("%d ballots were accepted, want 1", accepted)
+	}
+
+	votes, err := app.DB.Vote.Query().Where(vote.HasPollWith(poll.IDEQ(p.ID))).WithOption().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 1 {
+		t.Fatalf("poll has %d vote rows, want 1", len(votes))
+	}
+
+	total := 0
+	for _, o := range options {
+		o, err := app.DB.PollOption.Get(ctx, o.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := 0
+		if o.ID == votes[0].Edges.Option.ID {
+			want = 1
+		}
+		if o.VoteCount != want {
+			t.Errorf("option %q has vote_count %d, want %d", o.OptionText, o.VoteCount, want)
+		}
+		total += o.VoteCount
+	}
+
+	p, err = app.DB.Poll.Get(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.TotalVotes != total {
+		t.Errorf("poll total_votes is %d, want %d", p.TotalVotes, total)
+	}
+}
+
+// TestCastVotesRejectsSecondBallot checks a voter who already voted on a
+// single choice poll is told so, and nothing about the poll changes
+func TestCastVotesRejectsSecondBallot(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	u := createTestUser(t, app, "voter@example.com")
+	voter := voterRef{user: u}
+	p, options := createTestPoll(t, app, u, pollTypeSingleChoice, "Yes", "No")
+
+	if _, err := app.castVotes(ctx, p.ID, []int{options[0].ID}, nil, voter, ""); err != nil {
+		t.Fatalf("first ballot: %v", err)
+	}
+	_, err := app.castVotes(ctx, p.ID, []int{options[1].ID}, nil, voter, "")
+	if status := statusFromError(err); status != http.StatusBadRequest {
+		t.Fatalf("second ballot got status %d (%v), want %d", status, err, http.StatusBadRequest)
+	}
+
+	n, err := app.DB.Vote.Query().Where(vote.HasPollWith(poll.IDEQ(p.ID))).Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("poll has %d vote rows, want 1", n)
+	}
+}
+
+// TestReplaceVotesRecordsRejections checks that turned away vote changes are
+// counted in votes_rejected_total just like turned away ballots.
+func TestReplaceVotesRecordsRejections(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	u := createTestUser(t, app, "voter@example.com")
+	voter := voterRef{user: u}
+	p, options := createTestPoll(t, app, u, pollTypeSingleChoice, "Yes", "No")
+
+	notVoted := votesRejected.WithLabelValues(voteRejectNotVoted)
+	before := testutil.ToFloat64(notVoted)
+	if _, err := app.replaceVotes(ctx, p.ID, []int{options[0].ID}, nil, voter, ""); err == nil {
+		t.Fatal("changing a vote that was never cast succeeded")
+	}
+	if got := testutil.ToFloat64(notVoted) - before; got != 1 {
+		t.Errorf("%s rejections went up by %v, want 1", voteRejectNotVoted, got)
+	}
+
+	if _, err := app.castVotes(ctx, p.ID, []int{options[0].ID}, nil, voter, ""); err != nil {
+		t.Fatalf("casting ballot: %v", err)
+	}
+	if err := app.DB.Poll.UpdateOneID(p.ID).SetAllowVoteChanges(false).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	noChanges := votesRejected.WithLabelValues(voteRejectNoChanges)
+	before = testutil.ToFloat64(noChanges)
+	_, err := app.replaceVotes(ctx, p.ID, []int{options[1].ID}, nil, voter, "")
+	if status := statusFromError(err); status != http.StatusForbidden {
+		t.Fatalf("change got status %d (%v), want %d", status, err, http.StatusForbidden)
+	}
+	if got := testutil.ToFloat64(noChanges) - before; got != 1 {
+		t.Errorf("%s rejections went up by %v, want 1", voteRejectNoChanges, got)
+	}
+}
+
+// TestRetractVotesRecordsRejections checks that turned away withdrawals are
+// counted in votes_rejected_total too.
+func TestRetractVotesRecordsRejections(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	u := createTestUser(t, app, "voter@example.com")
+	voter := voterRef{user: u}
+	p, options := createTestPoll(t, app, u, pollTypeSingleChoice, "Yes", "No")
+
+	notVoted := votesRejected.WithLabelValues(voteRejectNotVoted)
+	before := testutil.ToFloat64(notVoted)
+	_, err := app.retractVotes(ctx, p.ID, voter)
+	if status := statusFromError(err); status != http.StatusNotFound {
+		t.Fatalf("withdrawal got status %d (%v), want %d", status, err, http.StatusNotFound)
+	}
+	if got := testutil.ToFloat64(notVoted) - before; got != 1 {
+		t.Errorf("%s rejections went up by %v, want 1", voteRejectNotVoted, got)
+	}
+
+	if _, err := app.castVotes(ctx, p.ID, []int{options[0].ID}, nil, voter, ""); err != nil {
+		t.Fatalf("casting ballot: %v", err)
+	}
+	if err := app.DB.Poll.UpdateOneID(p.ID).SetAllowVoteChanges(false).Exec(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	noChanges := votesRejected.WithLabelValues(voteRejectNoChanges)
+	before = testutil.ToFloat64(noChanges)
+	_, err = app.retractVotes(ctx, p.ID, voter)
+	if status := statusFromError(err); status != http.StatusForbidden {
+		t.Fatalf("withdrawal got status %d (%v), want %d", status, err, http.StatusForbidden)
+	}
+	if got := testutil.ToFloat64(noChanges) - before; got != 1 {
+		t.Errorf("%s rejections went up by %v, want 1", voteRejectNoChanges, got)
+	}
+}
+
+// TestVoteOnPollAnonymous casts ballots without signing in. The first one
+// issues a voter cookie; the cookie holds its owner to one ballot, and its
+// votes aren't tied to any account.
+func TestVoteOnPollAnonymous(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	owner := createTestUser(t, app, "owner@example.com")
+	p, options := createTestPoll(t, app, owner, pollTypeSingleChoice, "Yes", "No")
+
+	cast := func(cookies ...*http.Cookie) *httptest.ResponseRecorder {
+		body := fmt.Sprintf(`{"poll_id": %d, "option_ids": [%d]}`, p.ID, options[0].ID)
+		req := httptest.NewRequest(http.MethodPost, "/vote", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		app.VoteOnPoll(rec, req, nil)
+		return rec
+	}
+
+	first := cast()
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first anonymous ballot got status %d: %s", first.Code, first.Body)
+	}
+	cookies := first.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != voterCookieName {
+		t.Fatalf("first anonymous ballot set cookies %v, want %s", cookies, voterCookieName)
+	}
+
+	if again := cast(cookies...); again.Code != http.StatusBadRequest {
+		t.Errorf("second ballot with the same cookie got status %d, want %d", again.Code, http.StatusBadRequest)
+	}
+
+	// A forged cookie is ignored and its holder treated as a new voter
+	forged := &http.Cookie{Name: voterCookieName, Value: "someone.else"}
+	if other := cast(forged); other.Code != http.StatusCreated {
+		t.Errorf("ballot with a forged cookie got status %d, want %d", other.Code, http.StatusCreated)
+	}
+
+	votes, err := app.DB.Vote.Query().WithVoter().All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 2 {
+		t.Fatalf("poll has %d vote rows, want 2", len(votes))
+	}
+	for _, v := range votes {
+		if v.Edges.Voter != nil {
+			t.Errorf("anonymous vote %d is linked to user %d", v.ID, v.Edges.Voter.ID)
+		}
+		if !strings.HasPrefix(v.VoterIdentifier, anonymousVoterPrefix) {
+			t.Errorf("anonymous vote %d has voter_identifier %q", v.ID, v.VoterIdentifier)
+		}
+	}
+}
+
+// TestDeletedAccountKeepsItsVotes deletes a voter's account and registers
+// its email again. The new account must start without votes and be able to
+// vote on the same options.
+func TestDeletedAccountKeepsItsVotes(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+
+	owner := createTestUser(t, app, "owner@example.com")
+	p, options := createTestPoll(t, app, owner, pollTypeSingleChoice, "Yes", "No")
+
+	old := createTestUser(t, app, "voter@example.com")
+	if _, err := app.castVotes(ctx, p.ID, []int{options[0].ID}, nil, voterRef{user: old}, ""); err != nil {
+		t.Fatalf("casting ballot: %v", err)
+	}
+
+	deleteTestAccount(t, app, old)
+
+	reused := voterRef{user: createTestUser(t, app, "voter@example.com")}
+	if _, err := app.retractVotes(ctx, p.ID, reused); statusFromError(err) != http.StatusNotFound {
+		t.Errorf("new account withdrew the deleted account's votes (err %v)", err)
+	}
+	if _, err := app.castVotes(ctx, p.ID, []int{options[0].ID}, nil, reused, ""); err != nil {
+		t.Errorf("new account couldn't vote: %v", err)
+	}
+
+	n, err := app.DB.Vote.Query().Where(vote.HasPollWith(poll.IDEQ(p.ID))).Count(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("poll has %d vote rows, want the deleted account's and the new one's", n)
+	}
+}
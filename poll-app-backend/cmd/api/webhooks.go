@@ -0,0 +1,437 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/poll"
+	"backend/ent/user"
+	"backend/ent/webhook"
+	"backend/ent/webhookdelivery"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Poll lifecycle events webhooks can subscribe to
+const (
+	eventPollCreated = "poll.created"
+	eventPollOpened  = "poll.opened"
+	eventPollVoted   = "poll.voted"
+	eventPollExpired = "poll.expired"
+	eventPollClosed  = "poll.closed"
+)
+
+// eventPing is sent on demand to check a webhook is reachable; every
+// webhook receives it regardless of its event filter
+const eventPing = "ping"
+
+// webhookEvents lists every event a subscription may filter on
+var webhookEvents = []string{
+	eventPollCreated,
+	eventPollOpened,
+	eventPollVoted,
+	eventPollExpired,
+	eventPollClosed,
+}
+
+// Values of WebhookDelivery.status
+const (
+	deliveryPending   = "pending"
+	deliverySucceeded = "succeeded"
+	deliveryFailed    = "failed"
+)
+
+// Delivery tuning. Failed attempts are retried after webhookBaseBackoff,
+// doubling each time, until webhookMaxAttempts have been made (about two
+// hours in all with the defaults).
+const (
+	webhookTimeout      = 10 * time.Second
+	webhookBaseBackoff  = 30 * time.Second
+	webhookMaxAttempts  = 8
+	webhookPollInterval = 5 * time.Second
+	webhookBatchSize    = 20
+)
+
+// Headers set on every delivery. The signature header reads
+// "t=<unix time>,v1=<hex HMAC-SHA256 of "<unix time>.<body>">", keyed with
+// the webhook's secret; receivers should recompute it and reject stale
+// timestamps.
+const (
+	webhookEventHeader     = "X-Webhook-Event"
+	webhookDeliveryHeader  = "X-Webhook-Delivery"
+	webhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// errWebhookAddress is returned for webhook hosts that are, or resolve to,
+// addresses inside the network the server runs in
+var errWebhookAddress = errors.New("webhook URLs must point to a public internet address")
+
+// reservedPrefixes are address ranges that netip's predicates don't cover
+// but that are never a public webhook receiver
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, which can reach private IPv4
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("2001:db8::/32"),  // documentation
+	netip.MustParsePrefix("2002::/16"),      // 6to4, which embeds any IPv4
+}
+
+// isPublicAddress reports whether webhooks may be delivered to ip: it must
+// be a global unicast address outside private and reserved ranges, so
+// subscriptions can't be used to reach the server's own network
+func isPublicAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// newWebhookHTTPClient returns the client deliveries are sent with. Unless
+// allowPrivate is set, its dialer refuses non-public addresses once the
+// host has been resolved, so a DNS record changed after validation, or a
+// redirect, can't point a delivery inside the network. Proxies from the
+// environment are ignored, as the check would only see the proxy.
+func newWebhookHTTPClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil || !isPublicAddress(ip) {
+				return fmt.Errorf("%w: %s", errWebhookAddress, host)
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: webhookTimeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   webhookTimeout,
+			ExpectContinueTimeout: time.Second,
+		},
+	}
+}
+
+// webhookPayload is the JSON body of a delivery
+type webhookPayload struct {
+	Event      string        `json:"event"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Action     string        `json:"action,omitempty"`
+	Poll       *ent.Poll     `json:"poll,omitempty"`
+	Results    *resultUpdate `json:"results,omitempty"`
+}
+
+// signWebhookPayload returns the value of the signature header for body
+func signWebhookPayload(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff is how long to wait before retrying a delivery that has
+// failed attempts times, with up to 10% jitter so retries spread out
+func webhookBackoff(base time.Duration, attempts int) time.Duration {
+	d := base << (attempts - 1)
+	return d + rand.N(d/10+1)
+}
+
+// notifyPollEvent queues an event for every active webhook of the poll's
+// owner that subscribes to it. It runs after the change has been committed;
+// a failure here must not undo that change, so it is logged, not returned.
+func (app *application) notifyPollEvent(ctx context.Context, pollID int, event, action string) {
+	hooks, err := app.DB.Webhook.Query().
+		Where(webhook.Active(true)).
+		Where(webhook.HasOwnerWith(user.HasPollsWith(poll.IDEQ(pollID)))).
+		All(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to find webhooks for poll %d: %v", pollID, err)
+		return
+	}
+
+	// The event filter is stored as a JSON list, so it is applied here
+	hooks = slices.DeleteFunc(hooks, func(h *ent.Webhook) bool {
+		return !slices.Contains(h.Events, event)
+	})
+	if len(hooks) == 0 {
+		return
+	}
+
+	pollData, err := app.DB.Poll.Get(ctx, pollID)
+	if err != nil {
+		log.Printf("Warning: failed to load poll %d for webhooks: %v", pollID, err)
+		return
+	}
+	results, err := app.resultSnapshot(ctx, pollID)
+	if err != nil {
+		log.Printf("Warning: failed to load results of poll %d for webhooks: %v", pollID, err)
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		Event:      event,
+		OccurredAt: time.Now().UTC(),
+		Action:     action,
+		Poll:       pollData,
+		Results:    &results,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to encode %s event for poll %d: %v", event, pollID, err)
+		return
+	}
+
+	if err := app.Webhooks.enqueue(ctx, hooks, event, string(payload)); err != nil {
+		log.Printf("Warning: failed to queue %s event for poll %d: %v", event, pollID, err)
+	}
+}
+
+// webhookDispatcher sends queued deliveries. Deliveries live in the
+// database, so nothing is lost across restarts, and several instances can
+// share the queue: each claims a batch by pushing its next_attempt_at past
+// the time a delivery can take, skipping rows another instance has locked.
+type webhookDispatcher struct {
+	client      *ent.Client
+	dialect     string
+	httpClient  *http.Client
+	wake        chan struct{}
+	baseBackoff time.Duration
+	maxAttempts int
+}
+
+// newWebhookDispatcher returns a dispatcher for the deliveries queued in
+// client. allowPrivate lets deliveries reach private and loopback
+// addresses, for development and tests.
+func newWebhookDispatcher(client *ent.Client, driver string, allowPrivate bool) *webhookDispatcher {
+	return &webhookDispatcher{
+		client:      client,
+		dialect:     driver,
+		httpClient:  newWebhookHTTPClient(allowPrivate),
+		wake:        make(chan struct{}, 1),
+		baseBackoff: webhookBaseBackoff,
+		maxAttempts: webhookMaxAttempts,
+	}
+}
+
+// enqueue stores one pending delivery of payload per webhook and wakes the
+// dispatcher
+func (d *webhookDispatcher) enqueue(ctx context.Context, hooks []*ent.Webhook, event, payload string) error {
+	now := time.Now()
+	builders := make([]*ent.WebhookDeliveryCreate, 0, len(hooks))
+	for _, h := range hooks {
+		builders = append(builders, d.client.WebhookDelivery.Create().
+			SetWebhook(h).
+			SetEvent(event).
+			SetPayload(payload).
+			SetNextAttemptAt(now))
+	}
+	if err := d.client.WebhookDelivery.CreateBulk(builders...).Exec(ctx); err != nil {
+		return err
+	}
+
+	d.notify()
+	return nil
+}
+
+// notify wakes Run without waiting for its next poll
+func (d *webhookDispatcher) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run delivers due webhooks until ctx is cancelled
+func (d *webhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Keep going while full batches come back, then wait
+		for {
+			n, err := d.deliverDue(ctx)
+			if err != nil {
+				log.Printf("Warning: webhook delivery failed: %v", err)
+				break
+			}
+			if n < webhookBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// deliverDue claims a batch of due deliveries, sends them in parallel and
+// returns how many it handled
+func (d *webhookDispatcher) deliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.claimDue(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.attempt(ctx, delivery); err != nil {
+				log.Printf("Warning: failed to record webhook delivery %d: %v", delivery.ID, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// claimDue picks pending deliveries whose time has come and leases them to
+// this dispatcher until their attempt has had time to finish
+func (d *webhookDispatcher) claimDue(ctx context.Context) ([]*ent.WebhookDelivery, error) {
+	var deliveries []*ent.WebhookDelivery
+
+	err := withTx(ctx, d.client, func(tx *ent.Tx) error {
+		now := time.Now()
+		query := tx.WebhookDelivery.Query().
+			Where(webhookdelivery.StatusEQ(deliveryPending)).
+			Where(webhookdelivery.NextAttemptAtLTE(now)).
+			Order(ent.Asc(webhookdelivery.FieldNextAttemptAt)).
+			Limit(webhookBatchSize).
+			// Loaded by a separate query, so the webhook rows aren't locked
+			WithWebhook()
+		if d.dialect != dialect.SQLite {
+			query = query.ForUpdate(sql.WithLockAction(sql.SkipLocked))
+		}
+
+		var err error
+		deliveries, err = query.All(ctx)
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int, len(deliveries))
+		for i, delivery := range deliveries {
+			ids[i] = delivery.ID
+		}
+		return tx.WebhookDelivery.Update().
+			Where(webhookdelivery.IDIn(ids...)).
+			SetNextAttemptAt(now.Add(2 * webhookTimeout)).
+			Exec(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// attempt sends one delivery and records the outcome
+func (d *webhookDispatcher) attempt(ctx context.Context, delivery *ent.WebhookDelivery) error {
+	hook := delivery.Edges.Webhook
+	update := d.client.WebhookDelivery.UpdateOne(delivery).AddAttempts(1)
+
+	if !hook.Active {
+		return update.
+			SetStatus(deliveryFailed).
+			SetLastError("webhook is disabled").
+			ClearNextAttemptAt().
+			Exec(ctx)
+	}
+
+	status, sendErr := d.send(ctx, hook, delivery)
+	if status != 0 {
+		update.SetResponseStatus(status)
+	}
+
+	if sendErr == nil {
+		return update.
+			SetStatus(deliverySucceeded).
+			SetDeliveredAt(time.Now()).
+			SetLastError("").
+			ClearNextAttemptAt().
+			Exec(ctx)
+	}
+
+	update.SetLastError(sendErr.Error())
+	attempts := delivery.Attempts + 1
+	if attempts >= d.maxAttempts {
+		return update.
+			SetStatus(deliveryFailed).
+			ClearNextAttemptAt().
+			Exec(ctx)
+	}
+	return update.
+		SetNextAttemptAt(time.Now().Add(webhookBackoff(d.baseBackoff, attempts))).
+		Exec(ctx)
+}
+
+// send POSTs a delivery's payload and returns the response status, if any.
+// Anything other than a 2xx response counts as a failure.
+func (d *webhookDispatcher) send(ctx context.Context, hook *ent.Webhook, delivery *ent.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PollApp-Webhooks/1.0")
+	req.Header.Set(webhookEventHeader, delivery.Event)
+	req.Header.Set(webhookDeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(webhookSignatureHeader, signWebhookPayload(hook.Secret, time.Now(), body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
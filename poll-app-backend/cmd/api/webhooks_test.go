@@ -0,0 +1,403 @@
+package main
+
+import (
+	"backend/ent"
+	"backend/ent/webhookdelivery"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// createTestWebhook subscribes a webhook at url to every event of owner's polls
+func createTestWebhook(t *testing.T, app *application, owner *ent.User, url string) *ent.Webhook {
+	t.Helper()
+
+	hook, err := app.DB.Webhook.Create().
+		SetURL(url).
+		SetSecret("test-webhook-secret").
+		SetEvents(webhookEvents).
+		SetOwner(owner).
+		Save(t.Context())
+	if err != nil {
+		t.Fatalf("creating webhook: %v", err)
+	}
+	return hook
+}
+
+// TestClaimDueLeasesDeliveries checks due deliveries are handed out once:
+// concurrent claims split them, and a claimed delivery isn't due again
+// until its lease runs out
+func TestClaimDueLeasesDeliveries(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+	owner := createTestUser(t, app, "owner@example.com")
+	hook := createTestWebhook(t, app, owner, "http://hooks.example.com/")
+
+	now := time.Now()
+	for _, at := range []time.Time{now.Add(-time.Minute), now.Add(-time.Second), now.Add(time.Hour)} {
+		err := app.DB.WebhookDelivery.Create().
+			SetWebhook(hook).
+			SetEvent(eventPing).
+			SetPayload("{}").
+			SetNextAttemptAt(at).
+			Exec(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	claimed := map[int]int{}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deliveries, err := app.Webhooks.claimDue(ctx)
+			if err != nil {
+				t.Errorf("claimDue: %v", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, delivery := range deliveries {
+				if delivery.Edges.Webhook == nil {
+					t.Errorf("delivery %d was claimed without its webhook", delivery.ID)
+				}
+				claimed[delivery.ID]++
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(claimed) != 2 {
+		t.Errorf("claimed %d distinct deliveries, want the 2 due ones", len(claimed))
+	}
+	for id, n := range claimed {
+		if n != 1 {
+			t.Errorf("delivery %d was claimed %d times", id, n)
+		}
+	}
+
+	deliveries, err := app.Webhooks.claimDue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deliveries) != 0 {
+		t.Errorf("claimDue handed out %d leased deliveries again", len(deliveries))
+	}
+}
+
+// webhookReceiver is a test endpoint that records the deliveries it gets
+// and answers each with the next status in statuses, then 200
+type webhookReceiver struct {
+	mu       sync.Mutex
+	requests []receivedDelivery
+	statuses []int
+}
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, receivedDelivery{header: r.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (rc *webhookReceiver) received() []receivedDelivery {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return slices.Clone(rc.requests)
+}
+
+// verifySignature checks a signature header the way receivers are told to
+func verifySignature(t *testing.T, header, secret string, body []byte) {
+	t.Helper()
+
+	ts, sig, ok := strings.Cut(header, ",v1=")
+	ts, tsOK := strings.CutPrefix(ts, "t=")
+	if !ok || !tsOK {
+		t.Fatalf("malformed signature header %q", header)
+	}
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		t.Fatalf("signature timestamp %q: %v", ts, err)
+	}
+	if age := time.Since(time.Unix(unix, 0)); age < -time.Minute || age > time.Minute {
+		t.Errorf("signature timestamp is %s off", age)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.%s", ts, body)
+	if want := hex.EncodeToString(mac.Sum(nil)); !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("signature %s doesn't match the body, want %s", sig, want)
+	}
+}
+
+// queueTestDelivery enqueues one ping delivery to hook
+func queueTestDelivery(t *testing.T, app *application, hook *ent.Webhook, payload string) *ent.WebhookDelivery {
+	t.Helper()
+	ctx := t.Context()
+
+	if err := app.Webhooks.enqueue(ctx, []*ent.Webhook{hook}, eventPing, payload); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	delivery, err := hook.QueryDeliveries().Order(ent.Desc(webhookdelivery.FieldID)).First(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return delivery
+}
+
+func TestWebhookDeliveryIsSigned(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+	receiver := &webhookReceiver{}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	owner := createTestUser(t, app, "owner@example.com")
+	hook := createTestWebhook(t, app, owner, srv.URL)
+	delivery := queueTestDelivery(t, app, hook, `{"event":"ping"}`)
+
+	if n, err := app.Webhooks.deliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("deliverDue = %d, %v; want 1 delivery", n, err)
+	}
+
+	got := receiver.received()
+	if len(got) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(got))
+	}
+	req := got[0]
+	if string(req.body) != `{"event":"ping"}` {
+		t.Errorf("body = %s", req.body)
+	}
+	if ev := req.header.Get(webhookEventHeader); ev != eventPing {
+		t.Errorf("%s = %q, want %q", webhookEventHeader, ev, eventPing)
+	}
+	if id := req.header.Get(webhookDeliveryHeader); id != strconv.Itoa(delivery.ID) {
+		t.Errorf("%s = %q, want %d", webhookDeliveryHeader, id, delivery.ID)
+	}
+	verifySignature(t, req.header.Get(webhookSignatureHeader), "test-webhook-secret", req.body)
+
+	delivery, err := app.DB.WebhookDelivery.Get(ctx, delivery.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != deliverySucceeded || delivery.Attempts != 1 || delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusOK ||
+		delivery.DeliveredAt == nil || delivery.NextAttemptAt != nil {
+		t.Errorf("delivery after success = %+v", delivery)
+	}
+}
+
+func TestWebhookDeliveryRetriesWithBackoff(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+	receiver := &webhookReceiver{statuses: []int{500, 503, 502}}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	app.Webhooks.baseBackoff = time.Minute
+	app.Webhooks.maxAttempts = 3
+
+	owner := createTestUser(t, app, "owner@example.com")
+	hook := createTestWebhook(t, app, owner, srv.URL)
+	delivery := queueTestDelivery(t, app, hook, "{}")
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		before := time.Now()
+		if _, err := app.Webhooks.deliverDue(ctx); err != nil {
+			t.Fatalf("attempt %d: %v", attempt, err)
+		}
+
+		d, err := app.DB.WebhookDelivery.Get(ctx, delivery.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Attempts != attempt || d.ResponseStatus == nil || *d.ResponseStatus < 500 || d.LastError == "" {
+			t.Fatalf("after attempt %d: %+v", attempt, d)
+		}
+
+		if attempt == 3 {
+			if d.Status != deliveryFailed || d.NextAttemptAt != nil {
+				t.Errorf("after the last attempt: status %s, next attempt %v; want failed and none", d.Status, d.NextAttemptAt)
+			}
+			break
+		}
+
+		// The wait doubles each time, plus at most 10% jitter
+		if d.Status != deliveryPending || d.NextAttemptAt == nil {
+			t.Fatalf("after attempt %d: status %s, next attempt %v; want a pending retry", attempt, d.Status, d.NextAttemptAt)
+		}
+		wait := d.NextAttemptAt.Sub(before)
+		base := time.Minute << (attempt - 1)
+		if wait < base-time.Second || wait > base+base/10+time.Second {
+			t.Errorf("retry %d is due in %s, want %s plus up to 10%%", attempt, wait, base)
+		}
+
+		// Nothing is sent before the retry is due
+		if n, err := app.Webhooks.deliverDue(ctx); err != nil || n != 0 {
+			t.Fatalf("deliverDue before the retry is due = %d, %v", n, err)
+		}
+		if err := d.Update().SetNextAttemptAt(time.Now()).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := len(receiver.received()); got != 3 {
+		t.Errorf("receiver got %d requests, want 3", got)
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 3: 2 * time.Minute, 8: 64 * time.Minute} {
+		for range 20 {
+			got := webhookBackoff(30*time.Second, attempts)
+			if got < want || got > want+want/10 {
+				t.Errorf("webhookBackoff(30s, %d) = %s, want %s plus up to 10%%", attempts, got, want)
+			}
+		}
+	}
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	app := newTestApp(t)
+	ctx := t.Context()
+	receiver := &webhookReceiver{statuses: []int{500}}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+	app.Webhooks.maxAttempts = 1
+	h := app.routes()
+
+	owner := createTestUser(t, app, "owner@example.com")
+	other := createTestUser(t, app, "other@example.com")
+	hook := createTestWebhook(t, app, owner, srv.URL)
+	original := queueTestDelivery(t, app, hook, `{"event":"ping","n":1}`)
+	if _, err := app.Webhooks.deliverDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	redeliver := func(u *ent.User) int {
+		token, _, err := app.generateToken(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodPost,
+			fmt.Sprintf("/webhooks/%d/deliveries/%d/redeliver", hook.ID, original.ID), nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := redeliver(other); code != http.StatusNotFound {
+		t.Errorf("redelivery by another user got %d, want %d", code, http.StatusNotFound)
+	}
+	if code := redeliver(owner); code != http.StatusAccepted {
+		t.Fatalf("redelivery got %d, want %d", code, http.StatusAccepted)
+	}
+	if n, err := app.Webhooks.deliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("deliverDue after redelivery = %d, %v; want 1 delivery", n, err)
+	}
+
+	got := receiver.received()
+	if len(got) != 2 || string(got[1].body) != string(got[0].body) {
+		t.Fatalf("receiver got %d requests; want the original payload twice", len(got))
+	}
+	if got[1].header.Get(webhookDeliveryHeader) == strconv.Itoa(original.ID) {
+		t.Error("redelivery reused the original delivery ID")
+	}
+	verifySignature(t, got[1].header.Get(webhookSignatureHeader), "test-webhook-secret", got[1].body)
+
+	// The original keeps its failure on record
+	original, err := app.DB.WebhookDelivery.Get(ctx, original.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.Status != deliveryFailed {
+		t.Errorf("original delivery is %s, want %s", original.Status, deliveryFailed)
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	app := newTestApp(t)
+
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://93.184.215.14/hooks", true},
+		{"http://[2606:4700::1111]/", true},
+		{"ftp://93.184.215.14/", false},
+		{"/relative", false},
+		{"http://127.0.0.1:8080/", false},
+		{"http://localhost/", false},
+		{"http://10.1.2.3/", false},
+		{"http://172.16.0.1/", false},
+		{"http://192.168.1.1/", false},
+		{"http://169.254.169.254/latest/meta-data/", false},
+		{"http://100.64.0.1/", false},
+		{"http://0.0.0.0/", false},
+		{"http://[::1]/", false},
+		{"http://[::ffff:127.0.0.1]/", false},
+		{"http://[fd00::1]/", false},
+		{"http://[fe80::1]/", false},
+		{"http://[64:ff9b::a00:1]/", false},
+	}
+	for _, tt := range tests {
+		err := app.validateWebhookURL(t.Context(), tt.url)
+		if (err == nil) != tt.ok {
+			t.Errorf("validateWebhookURL(%q) = %v, want ok %v", tt.url, err, tt.ok)
+		}
+	}
+
+	app.Config.WebhookAllowPrivate = true
+	if err := app.validateWebhookURL(t.Context(), "http://127.0.0.1:8080/"); err != nil {
+		t.Errorf("with private addresses allowed, loopback was rejected: %v", err)
+	}
+}
+
+// TestWebhookClientRefusesPrivateAddresses checks the dial-time guard, which
+// catches hosts that resolve to a private address after validation
+func TestWebhookClientRefusesPrivateAddresses(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer srv.Close()
+
+	_, err := newWebhookHTTPClient(false).Get(srv.URL)
+	if !errors.Is(err, errWebhookAddress) {
+		t.Errorf("request to %s got %v, want %v", srv.URL, err, errWebhookAddress)
+	}
+	if hits.Load() != 0 {
+		t.Error("the request reached the server")
+	}
+
+	resp, err := newWebhookHTTPClient(true).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("with private addresses allowed: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xlsxWriter writes a minimal Office Open XML workbook straight to an
+// io.Writer. Rows go out as they are written, one sheet after another, so
+// memory use doesn't grow with the size of the export. Strings are stored
+// inline and times as ISO 8601 text, which keeps the package free of shared
+// string and style tables.
+type xlsxWriter struct {
+	zw     *zip.Writer
+	sheets []string
+	sheet  io.Writer
+	row    int
+}
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	return &xlsxWriter{zw: zip.NewWriter(w)}
+}
+
+// StartSheet finishes the current sheet, if any, and begins a new one
+func (x *xlsxWriter) StartSheet(name string) error {
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	x.sheets = append(x.sheets, name)
+	sheet, err := x.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.sheets)))
+	if err != nil {
+		return err
+	}
+	x.sheet = sheet
+	x.row = 0
+
+	_, err = io.WriteString(x.sheet, xml.Header+
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	return err
+}
+
+// WriteRow appends a row to the current sheet. Cells may be strings, ints,
+// float64s, times or nil int pointers (left empty).
+func (x *xlsxWriter) WriteRow(cells ...any) error {
+	if x.sheet == nil {
+		return errors.New("xlsx: WriteRow called before StartSheet")
+	}
+
+	x.row++
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.row)
+	for i, cell := range cells {
+		ref := xlsxColumn(i) + strconv.Itoa(x.row)
+		switch v := cell.(type) {
+		case nil:
+		case *int:
+			if v != nil {
+				fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, *v)
+			}
+		case int:
+			fmt.Fprintf(&b, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case float64:
+			fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			writeXLSXString(&b, ref, v.UTC().Format(time.RFC3339))
+		case string:
+			writeXLSXString(&b, ref, v)
+		default:
+			return fmt.Errorf("xlsx: unsupported cell type %T", cell)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+// Close finishes the last sheet and writes the workbook parts that list
+// the sheets
+func (x *xlsxWriter) Close() error {
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	var types, workbook, rels strings.Builder
+	types.WriteString(xml.Header +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	workbook.WriteString(xml.Header +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(xml.Header +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, name := range x.sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" `+
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" `+
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" `+
+			`Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+
+	types.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(`</Relationships>`)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+	}
+	for _, part := range parts {
+		f, err := x.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+
+	return x.zw.Close()
+}
+
+// endSheet closes the XML of the current sheet
+func (x *xlsxWriter) endSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+	_, err := io.WriteString(x.sheet, `</sheetData></worksheet>`)
+	x.sheet = nil
+	return err
+}
+
+// writeXLSXString writes an inline string cell
+func writeXLSXString(b *strings.Builder, ref, s string) {
+	fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, xmlEscape(s))
+}
+
+// xmlEscape escapes s for XML text and attributes, replacing characters XML
+// can't carry
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// xlsxColumn returns the column letters for a zero-based index: A, B, ... Z, AA
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
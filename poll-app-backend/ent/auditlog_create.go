@@ -0,0 +1,306 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend/ent/auditlog"
+	"backend/ent/user"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuditLogCreate is the builder for creating a AuditLog entity.
+type AuditLogCreate struct {
+	config
+	mutation *AuditLogMutation
+	hooks    []Hook
+}
+
+// SetAction sets the "action" field.
+func (_c *AuditLogCreate) SetAction(v string) *AuditLogCreate {
+	_c.mutation.SetAction(v)
+	return _c
+}
+
+// SetActorEmail sets the "actor_email" field.
+func (_c *AuditLogCreate) SetActorEmail(v string) *AuditLogCreate {
+	_c.mutation.SetActorEmail(v)
+	return _c
+}
+
+// SetTargetType sets the "target_type" field.
+func (_c *AuditLogCreate) SetTargetType(v string) *AuditLogCreate {
+	_c.mutation.SetTargetType(v)
+	return _c
+}
+
+// SetTargetID sets the "target_id" field.
+func (_c *AuditLogCreate) SetTargetID(v int) *AuditLogCreate {
+	_c.mutation.SetTargetID(v)
+	return _c
+}
+
+// SetDetails sets the "details" field.
+func (_c *AuditLogCreate) SetDetails(v map[string]interface{}) *AuditLogCreate {
+	_c.mutation.SetDetails(v)
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AuditLogCreate) SetCreatedAt(v time.Time) *AuditLogCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AuditLogCreate) SetNillableCreatedAt(v *time.Time) *AuditLogCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetActorID sets the "actor" edge to the User entity by ID.
+func (_c *AuditLogCreate) SetActorID(id int) *AuditLogCreate {
+	_c.mutation.SetActorID(id)
+	return _c
+}
+
+// SetNillableActorID sets the "actor" edge to the User entity by ID if the given value is not nil.
+func (_c *AuditLogCreate) SetNillableActorID(id *int) *AuditLogCreate {
+	if id != nil {
+		_c = _c.SetActorID(*id)
+	}
+	return _c
+}
+
+// SetActor sets the "actor" edge to the User entity.
+func (_c *AuditLogCreate) SetActor(v *User) *AuditLogCreate {
+	return _c.SetActorID(v.ID)
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (_c *AuditLogCreate) Mutation() *AuditLogMutation {
+	return _c.mutation
+}
+
+// Save creates the AuditLog in the database.
+func (_c *AuditLogCreate) Save(ctx context.Context) (*AuditLog, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AuditLogCreate) SaveX(ctx context.Context) *AuditLog {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuditLogCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuditLogCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AuditLogCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := auditlog.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AuditLogCreate) check() error {
+	if _, ok := _c.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`ent: missing required field "AuditLog.action"`)}
+	}
+	if v, ok := _c.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.ActorEmail(); !ok {
+		return &ValidationError{Name: "actor_email", err: errors.New(`ent: missing required field "AuditLog.actor_email"`)}
+	}
+	if _, ok := _c.mutation.TargetType(); !ok {
+		return &ValidationError{Name: "target_type", err: errors.New(`ent: missing required field "AuditLog.target_type"`)}
+	}
+	if _, ok := _c.mutation.TargetID(); !ok {
+		return &ValidationError{Name: "target_id", err: errors.New(`ent: missing required field "AuditLog.target_id"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuditLog.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *AuditLogCreate) sqlSave(ctx context.Context) (*AuditLog, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AuditLogCreate) createSpec() (*AuditLog, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditLog{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(auditlog.Table, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+		_node.Action = value
+	}
+	if value, ok := _c.mutation.ActorEmail(); ok {
+		_spec.SetField(auditlog.FieldActorEmail, field.TypeString, value)
+		_node.ActorEmail = value
+	}
+	if value, ok := _c.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+		_node.TargetType = value
+	}
+	if value, ok := _c.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeInt, value)
+		_node.TargetID = value
+	}
+	if value, ok := _c.mutation.Details(); ok {
+		_spec.SetField(auditlog.FieldDetails, field.TypeJSON, value)
+		_node.Details = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(auditlog.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.ActorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   auditlog.ActorTable,
+			Columns: []string{auditlog.ActorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_audit_logs = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// AuditLogCreateBulk is the builder for creating many AuditLog entities in bulk.
+type AuditLogCreateBulk struct {
+	config
+	err      error
+	builders []*AuditLogCreate
+}
+
+// Save creates the AuditLog entities in the database.
+func (_c *AuditLogCreateBulk) Save(ctx context.Context) ([]*AuditLog, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AuditLog, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditLogMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AuditLogCreateBulk) SaveX(ctx context.Context) []*AuditLog {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AuditLogCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AuditLogCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
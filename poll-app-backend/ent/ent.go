@@ -3,10 +3,14 @@
 package ent
 
 import (
+	"backend/ent/auditlog"
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
 	"backend/ent/user"
 	"backend/ent/vote"
+	"backend/ent/webhook"
+	"backend/ent/webhookdelivery"
 	"context"
 	"errors"
 	"fmt"
@@ -76,10 +80,14 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			poll.Table:       poll.ValidColumn,
-			polloption.Table: polloption.ValidColumn,
-			user.Table:       user.ValidColumn,
-			vote.Table:       vote.ValidColumn,
+			auditlog.Table:        auditlog.ValidColumn,
+			poll.Table:            poll.ValidColumn,
+			pollinvite.Table:      pollinvite.ValidColumn,
+			polloption.Table:      polloption.ValidColumn,
+			user.Table:            user.ValidColumn,
+			vote.Table:            vote.ValidColumn,
+			webhook.Table:         webhook.ValidColumn,
+			webhookdelivery.Table: webhookdelivery.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
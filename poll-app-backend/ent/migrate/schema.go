@@ -3,11 +3,49 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// AuditLogsColumns holds the columns for the "audit_logs" table.
+	AuditLogsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "action", Type: field.TypeString},
+		{Name: "actor_email", Type: field.TypeString},
+		{Name: "target_type", Type: field.TypeString},
+		{Name: "target_id", Type: field.TypeInt},
+		{Name: "details", Type: field.TypeJSON, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_audit_logs", Type: field.TypeInt, Nullable: true},
+	}
+	// AuditLogsTable holds the schema information for the "audit_logs" table.
+	AuditLogsTable = &schema.Table{
+		Name:       "audit_logs",
+		Columns:    AuditLogsColumns,
+		PrimaryKey: []*schema.Column{AuditLogsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "audit_logs_users_audit_logs",
+				Columns:    []*schema.Column{AuditLogsColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "auditlog_target_type_target_id",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[3], AuditLogsColumns[4]},
+			},
+			{
+				Name:    "auditlog_action",
+				Unique:  false,
+				Columns: []*schema.Column{AuditLogsColumns[1]},
+			},
+		},
+	}
 	// PollsColumns holds the columns for the "polls" table.
 	PollsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -16,15 +54,100 @@ var (
 		{Name: "poll_type", Type: field.TypeString, Default: "single_choice"},
 		{Name: "created_by", Type: field.TypeString, Nullable: true},
 		{Name: "max_votes_per_user", Type: field.TypeInt, Default: 1},
+		{Name: "rating_min", Type: field.TypeInt, Nullable: true},
+		{Name: "rating_max", Type: field.TypeInt, Nullable: true},
+		{Name: "allow_vote_changes", Type: field.TypeBool, Default: true},
+		{Name: "opens_at", Type: field.TypeTime, Nullable: true},
 		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "state", Type: field.TypeString, Default: "open"},
+		{Name: "closed_at", Type: field.TypeTime, Nullable: true},
+		{Name: "visibility", Type: field.TypeString, Default: "public"},
+		{Name: "results_visibility", Type: field.TypeString, Default: "always"},
+		{Name: "invited_emails", Type: field.TypeJSON, Nullable: true},
+		{Name: "recurrence", Type: field.TypeString, Nullable: true},
+		{Name: "recurrence_timezone", Type: field.TypeString, Nullable: true},
+		{Name: "next_occurrence_at", Type: field.TypeTime, Nullable: true},
+		{Name: "search_text", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "total_votes", Type: field.TypeInt, Default: 0},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "poll_occurrences", Type: field.TypeInt, Nullable: true},
+		{Name: "user_polls", Type: field.TypeInt, Nullable: true},
 	}
 	// PollsTable holds the schema information for the "polls" table.
 	PollsTable = &schema.Table{
 		Name:       "polls",
 		Columns:    PollsColumns,
 		PrimaryKey: []*schema.Column{PollsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "polls_polls_occurrences",
+				Columns:    []*schema.Column{PollsColumns[23]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+			{
+				Symbol:     "polls_users_polls",
+				Columns:    []*schema.Column{PollsColumns[24]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "poll_created_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[21], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_total_votes_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[20], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_expires_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[10], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_state_opens_at",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[11], PollsColumns[9]},
+			},
+			{
+				Name:    "poll_state_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[11], PollsColumns[10]},
+			},
+			{
+				Name:    "poll_state_next_occurrence_at",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[11], PollsColumns[18]},
+			},
+		},
+	}
+	// PollInvitesColumns holds the columns for the "poll_invites" table.
+	PollInvitesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "label", Type: field.TypeString, Nullable: true, Size: 100},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
+		{Name: "revoked_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "poll_invites", Type: field.TypeInt},
+	}
+	// PollInvitesTable holds the schema information for the "poll_invites" table.
+	PollInvitesTable = &schema.Table{
+		Name:       "poll_invites",
+		Columns:    PollInvitesColumns,
+		PrimaryKey: []*schema.Column{PollInvitesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_invites_polls_invites",
+				Columns:    []*schema.Column{PollInvitesColumns[5]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
 	}
 	// PollOptionsColumns holds the columns for the "poll_options" table.
 	PollOptionsColumns = []*schema.Column{
@@ -53,7 +176,13 @@ var (
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "email", Type: field.TypeString, Unique: true},
 		{Name: "password", Type: field.TypeString},
+		{Name: "display_name", Type: field.TypeString, Nullable: true, Size: 100},
+		{Name: "avatar_url", Type: field.TypeString, Nullable: true, Size: 2048},
+		{Name: "role", Type: field.TypeEnum, Enums: []string{"admin", "creator", "voter"}, Default: "voter"},
+		{Name: "token_version", Type: field.TypeInt, Default: 0},
+		{Name: "disabled_at", Type: field.TypeTime, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
 	}
 	// UsersTable holds the schema information for the "users" table.
 	UsersTable = &schema.Table{
@@ -65,9 +194,12 @@ var (
 	VotesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
 		{Name: "voter_identifier", Type: field.TypeString, Nullable: true},
+		{Name: "rank", Type: field.TypeInt, Nullable: true},
+		{Name: "score", Type: field.TypeInt, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "poll_votes", Type: field.TypeInt},
 		{Name: "poll_option_votes", Type: field.TypeInt},
+		{Name: "user_votes", Type: field.TypeInt, Nullable: true},
 	}
 	// VotesTable holds the schema information for the "votes" table.
 	VotesTable = &schema.Table{
@@ -77,29 +209,117 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "votes_polls_votes",
-				Columns:    []*schema.Column{VotesColumns[3]},
+				Columns:    []*schema.Column{VotesColumns[5]},
 				RefColumns: []*schema.Column{PollsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "votes_poll_options_votes",
-				Columns:    []*schema.Column{VotesColumns[4]},
+				Columns:    []*schema.Column{VotesColumns[6]},
 				RefColumns: []*schema.Column{PollOptionsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
+			{
+				Symbol:     "votes_users_votes",
+				Columns:    []*schema.Column{VotesColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "vote_voter_identifier_poll_votes_poll_option_votes",
+				Unique:  true,
+				Columns: []*schema.Column{VotesColumns[1], VotesColumns[5], VotesColumns[6]},
+			},
+		},
+	}
+	// WebhooksColumns holds the columns for the "webhooks" table.
+	WebhooksColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "url", Type: field.TypeString, Size: 2048},
+		{Name: "secret", Type: field.TypeString},
+		{Name: "events", Type: field.TypeJSON},
+		{Name: "active", Type: field.TypeBool, Default: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "user_webhooks", Type: field.TypeInt},
+	}
+	// WebhooksTable holds the schema information for the "webhooks" table.
+	WebhooksTable = &schema.Table{
+		Name:       "webhooks",
+		Columns:    WebhooksColumns,
+		PrimaryKey: []*schema.Column{WebhooksColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webhooks_users_webhooks",
+				Columns:    []*schema.Column{WebhooksColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+	}
+	// WebhookDeliveriesColumns holds the columns for the "webhook_deliveries" table.
+	WebhookDeliveriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "event", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeString, Size: 2147483647},
+		{Name: "status", Type: field.TypeString, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "response_status", Type: field.TypeInt, Nullable: true},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "next_attempt_at", Type: field.TypeTime, Nullable: true},
+		{Name: "delivered_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "webhook_deliveries", Type: field.TypeInt},
+	}
+	// WebhookDeliveriesTable holds the schema information for the "webhook_deliveries" table.
+	WebhookDeliveriesTable = &schema.Table{
+		Name:       "webhook_deliveries",
+		Columns:    WebhookDeliveriesColumns,
+		PrimaryKey: []*schema.Column{WebhookDeliveriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webhook_deliveries_webhooks_deliveries",
+				Columns:    []*schema.Column{WebhookDeliveriesColumns[10]},
+				RefColumns: []*schema.Column{WebhooksColumns[0]},
+				OnDelete:   schema.Cascade,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webhookdelivery_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[3], WebhookDeliveriesColumns[7]},
+			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		AuditLogsTable,
 		PollsTable,
+		PollInvitesTable,
 		PollOptionsTable,
 		UsersTable,
 		VotesTable,
+		WebhooksTable,
+		WebhookDeliveriesTable,
 	}
 )
 
 func init() {
+	AuditLogsTable.ForeignKeys[0].RefTable = UsersTable
+	PollsTable.ForeignKeys[0].RefTable = PollsTable
+	PollsTable.ForeignKeys[1].RefTable = UsersTable
+	PollInvitesTable.ForeignKeys[0].RefTable = PollsTable
 	PollOptionsTable.ForeignKeys[0].RefTable = PollsTable
+	UsersTable.Annotation = &entsql.Annotation{}
+	UsersTable.Annotation.Checks = map[string]string{
+		"users_role_check": "role IN ('admin', 'creator', 'voter')",
+	}
 	VotesTable.ForeignKeys[0].RefTable = PollsTable
 	VotesTable.ForeignKeys[1].RefTable = PollOptionsTable
+	VotesTable.ForeignKeys[2].RefTable = UsersTable
+	WebhooksTable.ForeignKeys[0].RefTable = UsersTable
+	WebhookDeliveriesTable.ForeignKeys[0].RefTable = WebhooksTable
 }
@@ -3,11 +3,15 @@
 package ent
 
 import (
+	"backend/ent/auditlog"
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
 	"backend/ent/predicate"
 	"backend/ent/user"
 	"backend/ent/vote"
+	"backend/ent/webhook"
+	"backend/ent/webhookdelivery"
 	"context"
 	"errors"
 	"fmt"
@@ -27,50 +31,48 @@ const (
 	OpUpdateOne = ent.OpUpdateOne
 
 	// Node types.
-	TypePoll       = "Poll"
-	TypePollOption = "PollOption"
-	TypeUser       = "User"
-	TypeVote       = "Vote"
+	TypeAuditLog        = "AuditLog"
+	TypePoll            = "Poll"
+	TypePollInvite      = "PollInvite"
+	TypePollOption      = "PollOption"
+	TypeUser            = "User"
+	TypeVote            = "Vote"
+	TypeWebhook         = "Webhook"
+	TypeWebhookDelivery = "WebhookDelivery"
 )
 
-// PollMutation represents an operation that mutates the Poll nodes in the graph.
-type PollMutation struct {
+// AuditLogMutation represents an operation that mutates the AuditLog nodes in the graph.
+type AuditLogMutation struct {
 	config
-	op                    Op
-	typ                   string
-	id                    *int
-	title                 *string
-	description           *string
-	poll_type             *string
-	created_by            *string
-	max_votes_per_user    *int
-	addmax_votes_per_user *int
-	expires_at            *time.Time
-	created_at            *time.Time
-	updated_at            *time.Time
-	clearedFields         map[string]struct{}
-	options               map[int]struct{}
-	removedoptions        map[int]struct{}
-	clearedoptions        bool
-	votes                 map[int]struct{}
-	removedvotes          map[int]struct{}
-	clearedvotes          bool
-	done                  bool
-	oldValue              func(context.Context) (*Poll, error)
-	predicates            []predicate.Poll
+	op            Op
+	typ           string
+	id            *int
+	action        *string
+	actor_email   *string
+	target_type   *string
+	target_id     *int
+	addtarget_id  *int
+	details       *map[string]interface{}
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	actor         *int
+	clearedactor  bool
+	done          bool
+	oldValue      func(context.Context) (*AuditLog, error)
+	predicates    []predicate.AuditLog
 }
 
-var _ ent.Mutation = (*PollMutation)(nil)
+var _ ent.Mutation = (*AuditLogMutation)(nil)
 
-// pollOption allows management of the mutation configuration using functional options.
-type pollOption func(*PollMutation)
+// auditlogOption allows management of the mutation configuration using functional options.
+type auditlogOption func(*AuditLogMutation)
 
-// newPollMutation creates new mutation for the Poll entity.
-func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
-	m := &PollMutation{
+// newAuditLogMutation creates new mutation for the AuditLog entity.
+func newAuditLogMutation(c config, op Op, opts ...auditlogOption) *AuditLogMutation {
+	m := &AuditLogMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePoll,
+		typ:           TypeAuditLog,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -79,20 +81,20 @@ func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
 	return m
 }
 
-// withPollID sets the ID field of the mutation.
-func withPollID(id int) pollOption {
-	return func(m *PollMutation) {
+// withAuditLogID sets the ID field of the mutation.
+func withAuditLogID(id int) auditlogOption {
+	return func(m *AuditLogMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Poll
+			value *AuditLog
 		)
-		m.oldValue = func(ctx context.Context) (*Poll, error) {
+		m.oldValue = func(ctx context.Context) (*AuditLog, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Poll.Get(ctx, id)
+					value, err = m.Client().AuditLog.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -101,10 +103,10 @@ func withPollID(id int) pollOption {
 	}
 }
 
-// withPoll sets the old Poll of the mutation.
-func withPoll(node *Poll) pollOption {
-	return func(m *PollMutation) {
-		m.oldValue = func(context.Context) (*Poll, error) {
+// withAuditLog sets the old AuditLog of the mutation.
+func withAuditLog(node *AuditLog) auditlogOption {
+	return func(m *AuditLogMutation) {
+		m.oldValue = func(context.Context) (*AuditLog, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -113,7 +115,7 @@ func withPoll(node *Poll) pollOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PollMutation) Client() *Client {
+func (m AuditLogMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -121,7 +123,7 @@ func (m PollMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PollMutation) Tx() (*Tx, error) {
+func (m AuditLogMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -132,7 +134,7 @@ func (m PollMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PollMutation) ID() (id int, exists bool) {
+func (m *AuditLogMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -143,7 +145,7 @@ func (m *PollMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *AuditLogMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -152,294 +154,232 @@ func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Poll.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().AuditLog.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetTitle sets the "title" field.
-func (m *PollMutation) SetTitle(s string) {
-	m.title = &s
-}
-
-// Title returns the value of the "title" field in the mutation.
-func (m *PollMutation) Title() (r string, exists bool) {
-	v := m.title
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldTitle returns the old "title" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldTitle(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldTitle requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
-	}
-	return oldValue.Title, nil
-}
-
-// ResetTitle resets all changes to the "title" field.
-func (m *PollMutation) ResetTitle() {
-	m.title = nil
-}
-
-// SetDescription sets the "description" field.
-func (m *PollMutation) SetDescription(s string) {
-	m.description = &s
+// SetAction sets the "action" field.
+func (m *AuditLogMutation) SetAction(s string) {
+	m.action = &s
 }
 
-// Description returns the value of the "description" field in the mutation.
-func (m *PollMutation) Description() (r string, exists bool) {
-	v := m.description
+// Action returns the value of the "action" field in the mutation.
+func (m *AuditLogMutation) Action() (r string, exists bool) {
+	v := m.action
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDescription returns the old "description" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldAction returns the old "action" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldDescription(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldAction(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
+		return v, errors.New("OldAction is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDescription requires an ID field in the mutation")
+		return v, errors.New("OldAction requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
+		return v, fmt.Errorf("querying old value for OldAction: %w", err)
 	}
-	return oldValue.Description, nil
-}
-
-// ClearDescription clears the value of the "description" field.
-func (m *PollMutation) ClearDescription() {
-	m.description = nil
-	m.clearedFields[poll.FieldDescription] = struct{}{}
-}
-
-// DescriptionCleared returns if the "description" field was cleared in this mutation.
-func (m *PollMutation) DescriptionCleared() bool {
-	_, ok := m.clearedFields[poll.FieldDescription]
-	return ok
+	return oldValue.Action, nil
 }
 
-// ResetDescription resets all changes to the "description" field.
-func (m *PollMutation) ResetDescription() {
-	m.description = nil
-	delete(m.clearedFields, poll.FieldDescription)
+// ResetAction resets all changes to the "action" field.
+func (m *AuditLogMutation) ResetAction() {
+	m.action = nil
 }
 
-// SetPollType sets the "poll_type" field.
-func (m *PollMutation) SetPollType(s string) {
-	m.poll_type = &s
+// SetActorEmail sets the "actor_email" field.
+func (m *AuditLogMutation) SetActorEmail(s string) {
+	m.actor_email = &s
 }
 
-// PollType returns the value of the "poll_type" field in the mutation.
-func (m *PollMutation) PollType() (r string, exists bool) {
-	v := m.poll_type
+// ActorEmail returns the value of the "actor_email" field in the mutation.
+func (m *AuditLogMutation) ActorEmail() (r string, exists bool) {
+	v := m.actor_email
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPollType returns the old "poll_type" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldActorEmail returns the old "actor_email" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldPollType(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldActorEmail(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPollType is only allowed on UpdateOne operations")
+		return v, errors.New("OldActorEmail is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPollType requires an ID field in the mutation")
+		return v, errors.New("OldActorEmail requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPollType: %w", err)
+		return v, fmt.Errorf("querying old value for OldActorEmail: %w", err)
 	}
-	return oldValue.PollType, nil
+	return oldValue.ActorEmail, nil
 }
 
-// ResetPollType resets all changes to the "poll_type" field.
-func (m *PollMutation) ResetPollType() {
-	m.poll_type = nil
+// ResetActorEmail resets all changes to the "actor_email" field.
+func (m *AuditLogMutation) ResetActorEmail() {
+	m.actor_email = nil
 }
 
-// SetCreatedBy sets the "created_by" field.
-func (m *PollMutation) SetCreatedBy(s string) {
-	m.created_by = &s
+// SetTargetType sets the "target_type" field.
+func (m *AuditLogMutation) SetTargetType(s string) {
+	m.target_type = &s
 }
 
-// CreatedBy returns the value of the "created_by" field in the mutation.
-func (m *PollMutation) CreatedBy() (r string, exists bool) {
-	v := m.created_by
+// TargetType returns the value of the "target_type" field in the mutation.
+func (m *AuditLogMutation) TargetType() (r string, exists bool) {
+	v := m.target_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedBy returns the old "created_by" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldTargetType returns the old "target_type" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldCreatedBy(ctx context.Context) (v string, err error) {
+func (m *AuditLogMutation) OldTargetType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+		return v, errors.New("OldTargetType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+		return v, errors.New("OldTargetType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+		return v, fmt.Errorf("querying old value for OldTargetType: %w", err)
 	}
-	return oldValue.CreatedBy, nil
-}
-
-// ClearCreatedBy clears the value of the "created_by" field.
-func (m *PollMutation) ClearCreatedBy() {
-	m.created_by = nil
-	m.clearedFields[poll.FieldCreatedBy] = struct{}{}
+	return oldValue.TargetType, nil
 }
 
-// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
-func (m *PollMutation) CreatedByCleared() bool {
-	_, ok := m.clearedFields[poll.FieldCreatedBy]
-	return ok
-}
-
-// ResetCreatedBy resets all changes to the "created_by" field.
-func (m *PollMutation) ResetCreatedBy() {
-	m.created_by = nil
-	delete(m.clearedFields, poll.FieldCreatedBy)
+// ResetTargetType resets all changes to the "target_type" field.
+func (m *AuditLogMutation) ResetTargetType() {
+	m.target_type = nil
 }
 
-// SetMaxVotesPerUser sets the "max_votes_per_user" field.
-func (m *PollMutation) SetMaxVotesPerUser(i int) {
-	m.max_votes_per_user = &i
-	m.addmax_votes_per_user = nil
+// SetTargetID sets the "target_id" field.
+func (m *AuditLogMutation) SetTargetID(i int) {
+	m.target_id = &i
+	m.addtarget_id = nil
 }
 
-// MaxVotesPerUser returns the value of the "max_votes_per_user" field in the mutation.
-func (m *PollMutation) MaxVotesPerUser() (r int, exists bool) {
-	v := m.max_votes_per_user
+// TargetID returns the value of the "target_id" field in the mutation.
+func (m *AuditLogMutation) TargetID() (r int, exists bool) {
+	v := m.target_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldMaxVotesPerUser returns the old "max_votes_per_user" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldTargetID returns the old "target_id" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldMaxVotesPerUser(ctx context.Context) (v int, err error) {
+func (m *AuditLogMutation) OldTargetID(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMaxVotesPerUser is only allowed on UpdateOne operations")
+		return v, errors.New("OldTargetID is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMaxVotesPerUser requires an ID field in the mutation")
+		return v, errors.New("OldTargetID requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMaxVotesPerUser: %w", err)
+		return v, fmt.Errorf("querying old value for OldTargetID: %w", err)
 	}
-	return oldValue.MaxVotesPerUser, nil
+	return oldValue.TargetID, nil
 }
 
-// AddMaxVotesPerUser adds i to the "max_votes_per_user" field.
-func (m *PollMutation) AddMaxVotesPerUser(i int) {
-	if m.addmax_votes_per_user != nil {
-		*m.addmax_votes_per_user += i
+// AddTargetID adds i to the "target_id" field.
+func (m *AuditLogMutation) AddTargetID(i int) {
+	if m.addtarget_id != nil {
+		*m.addtarget_id += i
 	} else {
-		m.addmax_votes_per_user = &i
+		m.addtarget_id = &i
 	}
 }
 
-// AddedMaxVotesPerUser returns the value that was added to the "max_votes_per_user" field in this mutation.
-func (m *PollMutation) AddedMaxVotesPerUser() (r int, exists bool) {
-	v := m.addmax_votes_per_user
+// AddedTargetID returns the value that was added to the "target_id" field in this mutation.
+func (m *AuditLogMutation) AddedTargetID() (r int, exists bool) {
+	v := m.addtarget_id
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// ResetMaxVotesPerUser resets all changes to the "max_votes_per_user" field.
-func (m *PollMutation) ResetMaxVotesPerUser() {
-	m.max_votes_per_user = nil
-	m.addmax_votes_per_user = nil
+// ResetTargetID resets all changes to the "target_id" field.
+func (m *AuditLogMutation) ResetTargetID() {
+	m.target_id = nil
+	m.addtarget_id = nil
 }
 
-// SetExpiresAt sets the "expires_at" field.
-func (m *PollMutation) SetExpiresAt(t time.Time) {
-	m.expires_at = &t
+// SetDetails sets the "details" field.
+func (m *AuditLogMutation) SetDetails(value map[string]interface{}) {
+	m.details = &value
 }
 
-// ExpiresAt returns the value of the "expires_at" field in the mutation.
-func (m *PollMutation) ExpiresAt() (r time.Time, exists bool) {
-	v := m.expires_at
+// Details returns the value of the "details" field in the mutation.
+func (m *AuditLogMutation) Details() (r map[string]interface{}, exists bool) {
+	v := m.details
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldExpiresAt returns the old "expires_at" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldDetails returns the old "details" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+func (m *AuditLogMutation) OldDetails(ctx context.Context) (v map[string]interface{}, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldDetails is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+		return v, errors.New("OldDetails requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldDetails: %w", err)
 	}
-	return oldValue.ExpiresAt, nil
+	return oldValue.Details, nil
 }
 
-// ClearExpiresAt clears the value of the "expires_at" field.
-func (m *PollMutation) ClearExpiresAt() {
-	m.expires_at = nil
-	m.clearedFields[poll.FieldExpiresAt] = struct{}{}
+// ClearDetails clears the value of the "details" field.
+func (m *AuditLogMutation) ClearDetails() {
+	m.details = nil
+	m.clearedFields[auditlog.FieldDetails] = struct{}{}
 }
 
-// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
-func (m *PollMutation) ExpiresAtCleared() bool {
-	_, ok := m.clearedFields[poll.FieldExpiresAt]
+// DetailsCleared returns if the "details" field was cleared in this mutation.
+func (m *AuditLogMutation) DetailsCleared() bool {
+	_, ok := m.clearedFields[auditlog.FieldDetails]
 	return ok
 }
 
-// ResetExpiresAt resets all changes to the "expires_at" field.
-func (m *PollMutation) ResetExpiresAt() {
-	m.expires_at = nil
-	delete(m.clearedFields, poll.FieldExpiresAt)
+// ResetDetails resets all changes to the "details" field.
+func (m *AuditLogMutation) ResetDetails() {
+	m.details = nil
+	delete(m.clearedFields, auditlog.FieldDetails)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *PollMutation) SetCreatedAt(t time.Time) {
+func (m *AuditLogMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *PollMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *AuditLogMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -447,10 +387,10 @@ func (m *PollMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the AuditLog entity.
+// If the AuditLog object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *AuditLogMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -465,163 +405,58 @@ func (m *PollMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *PollMutation) ResetCreatedAt() {
+func (m *AuditLogMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *PollMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetActorID sets the "actor" edge to the User entity by id.
+func (m *AuditLogMutation) SetActorID(id int) {
+	m.actor = &id
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *PollMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
-	if v == nil {
-		return
-	}
-	return *v, true
+// ClearActor clears the "actor" edge to the User entity.
+func (m *AuditLogMutation) ClearActor() {
+	m.clearedactor = true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Poll entity.
-// If the Poll object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+// ActorCleared reports if the "actor" edge to the User entity was cleared.
+func (m *AuditLogMutation) ActorCleared() bool {
+	return m.clearedactor
+}
+
+// ActorID returns the "actor" edge ID in the mutation.
+func (m *AuditLogMutation) ActorID() (id int, exists bool) {
+	if m.actor != nil {
+		return *m.actor, true
 	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	return
+}
+
+// ActorIDs returns the "actor" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// ActorID instead. It exists only for internal usage by the builders.
+func (m *AuditLogMutation) ActorIDs() (ids []int) {
+	if id := m.actor; id != nil {
+		ids = append(ids, *id)
 	}
-	return oldValue.UpdatedAt, nil
+	return
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *PollMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetActor resets all changes to the "actor" edge.
+func (m *AuditLogMutation) ResetActor() {
+	m.actor = nil
+	m.clearedactor = false
 }
 
-// AddOptionIDs adds the "options" edge to the PollOption entity by ids.
-func (m *PollMutation) AddOptionIDs(ids ...int) {
-	if m.options == nil {
-		m.options = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.options[ids[i]] = struct{}{}
-	}
-}
-
-// ClearOptions clears the "options" edge to the PollOption entity.
-func (m *PollMutation) ClearOptions() {
-	m.clearedoptions = true
-}
-
-// OptionsCleared reports if the "options" edge to the PollOption entity was cleared.
-func (m *PollMutation) OptionsCleared() bool {
-	return m.clearedoptions
-}
-
-// RemoveOptionIDs removes the "options" edge to the PollOption entity by IDs.
-func (m *PollMutation) RemoveOptionIDs(ids ...int) {
-	if m.removedoptions == nil {
-		m.removedoptions = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.options, ids[i])
-		m.removedoptions[ids[i]] = struct{}{}
-	}
-}
-
-// RemovedOptions returns the removed IDs of the "options" edge to the PollOption entity.
-func (m *PollMutation) RemovedOptionsIDs() (ids []int) {
-	for id := range m.removedoptions {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// OptionsIDs returns the "options" edge IDs in the mutation.
-func (m *PollMutation) OptionsIDs() (ids []int) {
-	for id := range m.options {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// ResetOptions resets all changes to the "options" edge.
-func (m *PollMutation) ResetOptions() {
-	m.options = nil
-	m.clearedoptions = false
-	m.removedoptions = nil
-}
-
-// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
-func (m *PollMutation) AddVoteIDs(ids ...int) {
-	if m.votes == nil {
-		m.votes = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.votes[ids[i]] = struct{}{}
-	}
-}
-
-// ClearVotes clears the "votes" edge to the Vote entity.
-func (m *PollMutation) ClearVotes() {
-	m.clearedvotes = true
-}
-
-// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
-func (m *PollMutation) VotesCleared() bool {
-	return m.clearedvotes
-}
-
-// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
-func (m *PollMutation) RemoveVoteIDs(ids ...int) {
-	if m.removedvotes == nil {
-		m.removedvotes = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.votes, ids[i])
-		m.removedvotes[ids[i]] = struct{}{}
-	}
-}
-
-// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
-func (m *PollMutation) RemovedVotesIDs() (ids []int) {
-	for id := range m.removedvotes {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// VotesIDs returns the "votes" edge IDs in the mutation.
-func (m *PollMutation) VotesIDs() (ids []int) {
-	for id := range m.votes {
-		ids = append(ids, id)
-	}
-	return
-}
-
-// ResetVotes resets all changes to the "votes" edge.
-func (m *PollMutation) ResetVotes() {
-	m.votes = nil
-	m.clearedvotes = false
-	m.removedvotes = nil
-}
-
-// Where appends a list predicates to the PollMutation builder.
-func (m *PollMutation) Where(ps ...predicate.Poll) {
+// Where appends a list predicates to the AuditLogMutation builder.
+func (m *AuditLogMutation) Where(ps ...predicate.AuditLog) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PollMutation builder. Using this method,
+// WhereP appends storage-level predicates to the AuditLogMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Poll, len(ps))
+func (m *AuditLogMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.AuditLog, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -629,48 +464,42 @@ func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PollMutation) Op() Op {
+func (m *AuditLogMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PollMutation) SetOp(op Op) {
+func (m *AuditLogMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Poll).
-func (m *PollMutation) Type() string {
+// Type returns the node type of this mutation (AuditLog).
+func (m *AuditLogMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PollMutation) Fields() []string {
-	fields := make([]string, 0, 8)
-	if m.title != nil {
-		fields = append(fields, poll.FieldTitle)
+func (m *AuditLogMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.action != nil {
+		fields = append(fields, auditlog.FieldAction)
 	}
-	if m.description != nil {
-		fields = append(fields, poll.FieldDescription)
-	}
-	if m.poll_type != nil {
-		fields = append(fields, poll.FieldPollType)
+	if m.actor_email != nil {
+		fields = append(fields, auditlog.FieldActorEmail)
 	}
-	if m.created_by != nil {
-		fields = append(fields, poll.FieldCreatedBy)
+	if m.target_type != nil {
+		fields = append(fields, auditlog.FieldTargetType)
 	}
-	if m.max_votes_per_user != nil {
-		fields = append(fields, poll.FieldMaxVotesPerUser)
+	if m.target_id != nil {
+		fields = append(fields, auditlog.FieldTargetID)
 	}
-	if m.expires_at != nil {
-		fields = append(fields, poll.FieldExpiresAt)
+	if m.details != nil {
+		fields = append(fields, auditlog.FieldDetails)
 	}
 	if m.created_at != nil {
-		fields = append(fields, poll.FieldCreatedAt)
-	}
-	if m.updated_at != nil {
-		fields = append(fields, poll.FieldUpdatedAt)
+		fields = append(fields, auditlog.FieldCreatedAt)
 	}
 	return fields
 }
@@ -678,24 +507,20 @@ func (m *PollMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PollMutation) Field(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case poll.FieldTitle:
-		return m.Title()
-	case poll.FieldDescription:
-		return m.Description()
-	case poll.FieldPollType:
-		return m.PollType()
-	case poll.FieldCreatedBy:
-		return m.CreatedBy()
-	case poll.FieldMaxVotesPerUser:
-		return m.MaxVotesPerUser()
-	case poll.FieldExpiresAt:
-		return m.ExpiresAt()
-	case poll.FieldCreatedAt:
+	case auditlog.FieldAction:
+		return m.Action()
+	case auditlog.FieldActorEmail:
+		return m.ActorEmail()
+	case auditlog.FieldTargetType:
+		return m.TargetType()
+	case auditlog.FieldTargetID:
+		return m.TargetID()
+	case auditlog.FieldDetails:
+		return m.Details()
+	case auditlog.FieldCreatedAt:
 		return m.CreatedAt()
-	case poll.FieldUpdatedAt:
-		return m.UpdatedAt()
 	}
 	return nil, false
 }
@@ -703,99 +528,81 @@ func (m *PollMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PollMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *AuditLogMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case poll.FieldTitle:
-		return m.OldTitle(ctx)
-	case poll.FieldDescription:
-		return m.OldDescription(ctx)
-	case poll.FieldPollType:
-		return m.OldPollType(ctx)
-	case poll.FieldCreatedBy:
-		return m.OldCreatedBy(ctx)
-	case poll.FieldMaxVotesPerUser:
-		return m.OldMaxVotesPerUser(ctx)
-	case poll.FieldExpiresAt:
-		return m.OldExpiresAt(ctx)
-	case poll.FieldCreatedAt:
+	case auditlog.FieldAction:
+		return m.OldAction(ctx)
+	case auditlog.FieldActorEmail:
+		return m.OldActorEmail(ctx)
+	case auditlog.FieldTargetType:
+		return m.OldTargetType(ctx)
+	case auditlog.FieldTargetID:
+		return m.OldTargetID(ctx)
+	case auditlog.FieldDetails:
+		return m.OldDetails(ctx)
+	case auditlog.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case poll.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Poll field %s", name)
+	return nil, fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollMutation) SetField(name string, value ent.Value) error {
+func (m *AuditLogMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case poll.FieldTitle:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetTitle(v)
-		return nil
-	case poll.FieldDescription:
+	case auditlog.FieldAction:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDescription(v)
+		m.SetAction(v)
 		return nil
-	case poll.FieldPollType:
+	case auditlog.FieldActorEmail:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPollType(v)
+		m.SetActorEmail(v)
 		return nil
-	case poll.FieldCreatedBy:
+	case auditlog.FieldTargetType:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedBy(v)
+		m.SetTargetType(v)
 		return nil
-	case poll.FieldMaxVotesPerUser:
+	case auditlog.FieldTargetID:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetMaxVotesPerUser(v)
+		m.SetTargetID(v)
 		return nil
-	case poll.FieldExpiresAt:
-		v, ok := value.(time.Time)
+	case auditlog.FieldDetails:
+		v, ok := value.(map[string]interface{})
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetExpiresAt(v)
+		m.SetDetails(v)
 		return nil
-	case poll.FieldCreatedAt:
+	case auditlog.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case poll.FieldUpdatedAt:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetUpdatedAt(v)
-		return nil
 	}
-	return fmt.Errorf("unknown Poll field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PollMutation) AddedFields() []string {
+func (m *AuditLogMutation) AddedFields() []string {
 	var fields []string
-	if m.addmax_votes_per_user != nil {
-		fields = append(fields, poll.FieldMaxVotesPerUser)
+	if m.addtarget_id != nil {
+		fields = append(fields, auditlog.FieldTargetID)
 	}
 	return fields
 }
@@ -803,10 +610,10 @@ func (m *PollMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PollMutation) AddedField(name string) (ent.Value, bool) {
+func (m *AuditLogMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case poll.FieldMaxVotesPerUser:
-		return m.AddedMaxVotesPerUser()
+	case auditlog.FieldTargetID:
+		return m.AddedTargetID()
 	}
 	return nil, false
 }
@@ -814,233 +621,213 @@ func (m *PollMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollMutation) AddField(name string, value ent.Value) error {
+func (m *AuditLogMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case poll.FieldMaxVotesPerUser:
+	case auditlog.FieldTargetID:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddMaxVotesPerUser(v)
+		m.AddTargetID(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Poll numeric field %s", name)
+	return fmt.Errorf("unknown AuditLog numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PollMutation) ClearedFields() []string {
+func (m *AuditLogMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(poll.FieldDescription) {
-		fields = append(fields, poll.FieldDescription)
-	}
-	if m.FieldCleared(poll.FieldCreatedBy) {
-		fields = append(fields, poll.FieldCreatedBy)
-	}
-	if m.FieldCleared(poll.FieldExpiresAt) {
-		fields = append(fields, poll.FieldExpiresAt)
+	if m.FieldCleared(auditlog.FieldDetails) {
+		fields = append(fields, auditlog.FieldDetails)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PollMutation) FieldCleared(name string) bool {
+func (m *AuditLogMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PollMutation) ClearField(name string) error {
+func (m *AuditLogMutation) ClearField(name string) error {
 	switch name {
-	case poll.FieldDescription:
-		m.ClearDescription()
-		return nil
-	case poll.FieldCreatedBy:
-		m.ClearCreatedBy()
-		return nil
-	case poll.FieldExpiresAt:
-		m.ClearExpiresAt()
+	case auditlog.FieldDetails:
+		m.ClearDetails()
 		return nil
 	}
-	return fmt.Errorf("unknown Poll nullable field %s", name)
+	return fmt.Errorf("unknown AuditLog nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PollMutation) ResetField(name string) error {
+func (m *AuditLogMutation) ResetField(name string) error {
 	switch name {
-	case poll.FieldTitle:
-		m.ResetTitle()
-		return nil
-	case poll.FieldDescription:
-		m.ResetDescription()
+	case auditlog.FieldAction:
+		m.ResetAction()
 		return nil
-	case poll.FieldPollType:
-		m.ResetPollType()
+	case auditlog.FieldActorEmail:
+		m.ResetActorEmail()
 		return nil
-	case poll.FieldCreatedBy:
-		m.ResetCreatedBy()
+	case auditlog.FieldTargetType:
+		m.ResetTargetType()
 		return nil
-	case poll.FieldMaxVotesPerUser:
-		m.ResetMaxVotesPerUser()
+	case auditlog.FieldTargetID:
+		m.ResetTargetID()
 		return nil
-	case poll.FieldExpiresAt:
-		m.ResetExpiresAt()
+	case auditlog.FieldDetails:
+		m.ResetDetails()
 		return nil
-	case poll.FieldCreatedAt:
+	case auditlog.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case poll.FieldUpdatedAt:
-		m.ResetUpdatedAt()
-		return nil
 	}
-	return fmt.Errorf("unknown Poll field %s", name)
+	return fmt.Errorf("unknown AuditLog field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PollMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.options != nil {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.votes != nil {
-		edges = append(edges, poll.EdgeVotes)
+func (m *AuditLogMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.actor != nil {
+		edges = append(edges, auditlog.EdgeActor)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PollMutation) AddedIDs(name string) []ent.Value {
+func (m *AuditLogMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case poll.EdgeOptions:
-		ids := make([]ent.Value, 0, len(m.options))
-		for id := range m.options {
-			ids = append(ids, id)
+	case auditlog.EdgeActor:
+		if id := m.actor; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
-	case poll.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.votes))
-		for id := range m.votes {
-			ids = append(ids, id)
-		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PollMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedoptions != nil {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.removedvotes != nil {
-		edges = append(edges, poll.EdgeVotes)
-	}
+func (m *AuditLogMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PollMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case poll.EdgeOptions:
-		ids := make([]ent.Value, 0, len(m.removedoptions))
-		for id := range m.removedoptions {
-			ids = append(ids, id)
-		}
-		return ids
-	case poll.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.removedvotes))
-		for id := range m.removedvotes {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *AuditLogMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PollMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedoptions {
-		edges = append(edges, poll.EdgeOptions)
-	}
-	if m.clearedvotes {
-		edges = append(edges, poll.EdgeVotes)
+func (m *AuditLogMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedactor {
+		edges = append(edges, auditlog.EdgeActor)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PollMutation) EdgeCleared(name string) bool {
+func (m *AuditLogMutation) EdgeCleared(name string) bool {
 	switch name {
-	case poll.EdgeOptions:
-		return m.clearedoptions
-	case poll.EdgeVotes:
-		return m.clearedvotes
+	case auditlog.EdgeActor:
+		return m.clearedactor
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PollMutation) ClearEdge(name string) error {
+func (m *AuditLogMutation) ClearEdge(name string) error {
 	switch name {
+	case auditlog.EdgeActor:
+		m.ClearActor()
+		return nil
 	}
-	return fmt.Errorf("unknown Poll unique edge %s", name)
+	return fmt.Errorf("unknown AuditLog unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PollMutation) ResetEdge(name string) error {
+func (m *AuditLogMutation) ResetEdge(name string) error {
 	switch name {
-	case poll.EdgeOptions:
-		m.ResetOptions()
-		return nil
-	case poll.EdgeVotes:
-		m.ResetVotes()
+	case auditlog.EdgeActor:
+		m.ResetActor()
 		return nil
 	}
-	return fmt.Errorf("unknown Poll edge %s", name)
+	return fmt.Errorf("unknown AuditLog edge %s", name)
 }
 
-// PollOptionMutation represents an operation that mutates the PollOption nodes in the graph.
-type PollOptionMutation struct {
+// PollMutation represents an operation that mutates the Poll nodes in the graph.
+type PollMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	option_text   *string
-	vote_count    *int
-	addvote_count *int
-	created_at    *time.Time
-	clearedFields map[string]struct{}
-	poll          *int
-	clearedpoll   bool
-	votes         map[int]struct{}
-	removedvotes  map[int]struct{}
-	clearedvotes  bool
-	done          bool
-	oldValue      func(context.Context) (*PollOption, error)
-	predicates    []predicate.PollOption
+	op                    Op
+	typ                   string
+	id                    *int
+	title                 *string
+	description           *string
+	poll_type             *string
+	created_by            *string
+	max_votes_per_user    *int
+	addmax_votes_per_user *int
+	rating_min            *int
+	addrating_min         *int
+	rating_max            *int
+	addrating_max         *int
+	allow_vote_changes    *bool
+	opens_at              *time.Time
+	expires_at            *time.Time
+	state                 *string
+	closed_at             *time.Time
+	visibility            *string
+	results_visibility    *string
+	invited_emails        *[]string
+	appendinvited_emails  []string
+	recurrence            *string
+	recurrence_timezone   *string
+	next_occurrence_at    *time.Time
+	search_text           *string
+	total_votes           *int
+	addtotal_votes        *int
+	created_at            *time.Time
+	updated_at            *time.Time
+	clearedFields         map[string]struct{}
+	options               map[int]struct{}
+	removedoptions        map[int]struct{}
+	clearedoptions        bool
+	votes                 map[int]struct{}
+	removedvotes          map[int]struct{}
+	clearedvotes          bool
+	invites               map[int]struct{}
+	removedinvites        map[int]struct{}
+	clearedinvites        bool
+	template              *int
+	clearedtemplate       bool
+	occurrences           map[int]struct{}
+	removedoccurrences    map[int]struct{}
+	clearedoccurrences    bool
+	owner                 *int
+	clearedowner          bool
+	done                  bool
+	oldValue              func(context.Context) (*Poll, error)
+	predicates            []predicate.Poll
 }
 
-var _ ent.Mutation = (*PollOptionMutation)(nil)
+var _ ent.Mutation = (*PollMutation)(nil)
 
-// polloptionOption allows management of the mutation configuration using functional options.
-type polloptionOption func(*PollOptionMutation)
+// pollOption allows management of the mutation configuration using functional options.
+type pollOption func(*PollMutation)
 
-// newPollOptionMutation creates new mutation for the PollOption entity.
-func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptionMutation {
-	m := &PollOptionMutation{
+// newPollMutation creates new mutation for the Poll entity.
+func newPollMutation(c config, op Op, opts ...pollOption) *PollMutation {
+	m := &PollMutation{
 		config:        c,
 		op:            op,
-		typ:           TypePollOption,
+		typ:           TypePoll,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1049,20 +836,20 @@ func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptio
 	return m
 }
 
-// withPollOptionID sets the ID field of the mutation.
-func withPollOptionID(id int) polloptionOption {
-	return func(m *PollOptionMutation) {
+// withPollID sets the ID field of the mutation.
+func withPollID(id int) pollOption {
+	return func(m *PollMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *PollOption
+			value *Poll
 		)
-		m.oldValue = func(ctx context.Context) (*PollOption, error) {
+		m.oldValue = func(ctx context.Context) (*Poll, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().PollOption.Get(ctx, id)
+					value, err = m.Client().Poll.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1071,10 +858,10 @@ func withPollOptionID(id int) polloptionOption {
 	}
 }
 
-// withPollOption sets the old PollOption of the mutation.
-func withPollOption(node *PollOption) polloptionOption {
-	return func(m *PollOptionMutation) {
-		m.oldValue = func(context.Context) (*PollOption, error) {
+// withPoll sets the old Poll of the mutation.
+func withPoll(node *Poll) pollOption {
+	return func(m *PollMutation) {
+		m.oldValue = func(context.Context) (*Poll, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1083,7 +870,7 @@ func withPollOption(node *PollOption) polloptionOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m PollOptionMutation) Client() *Client {
+func (m PollMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1091,7 +878,7 @@ func (m PollOptionMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m PollOptionMutation) Tx() (*Tx, error) {
+func (m PollMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1102,7 +889,7 @@ func (m PollOptionMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *PollOptionMutation) ID() (id int, exists bool) {
+func (m *PollMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1113,7 +900,7 @@ func (m *PollOptionMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *PollMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1122,157 +909,4961 @@ func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().PollOption.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Poll.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetOptionText sets the "option_text" field.
-func (m *PollOptionMutation) SetOptionText(s string) {
-	m.option_text = &s
+// SetTitle sets the "title" field.
+func (m *PollMutation) SetTitle(s string) {
+	m.title = &s
 }
 
-// OptionText returns the value of the "option_text" field in the mutation.
-func (m *PollOptionMutation) OptionText() (r string, exists bool) {
-	v := m.option_text
+// Title returns the value of the "title" field in the mutation.
+func (m *PollMutation) Title() (r string, exists bool) {
+	v := m.title
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOptionText returns the old "option_text" field's value of the PollOption entity.
-// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// OldTitle returns the old "title" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollOptionMutation) OldOptionText(ctx context.Context) (v string, err error) {
+func (m *PollMutation) OldTitle(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOptionText is only allowed on UpdateOne operations")
+		return v, errors.New("OldTitle is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOptionText requires an ID field in the mutation")
+		return v, errors.New("OldTitle requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOptionText: %w", err)
+		return v, fmt.Errorf("querying old value for OldTitle: %w", err)
 	}
-	return oldValue.OptionText, nil
+	return oldValue.Title, nil
 }
 
-// ResetOptionText resets all changes to the "option_text" field.
-func (m *PollOptionMutation) ResetOptionText() {
-	m.option_text = nil
+// ResetTitle resets all changes to the "title" field.
+func (m *PollMutation) ResetTitle() {
+	m.title = nil
 }
 
-// SetVoteCount sets the "vote_count" field.
-func (m *PollOptionMutation) SetVoteCount(i int) {
-	m.vote_count = &i
-	m.addvote_count = nil
+// SetDescription sets the "description" field.
+func (m *PollMutation) SetDescription(s string) {
+	m.description = &s
 }
 
-// VoteCount returns the value of the "vote_count" field in the mutation.
-func (m *PollOptionMutation) VoteCount() (r int, exists bool) {
-	v := m.vote_count
+// Description returns the value of the "description" field in the mutation.
+func (m *PollMutation) Description() (r string, exists bool) {
+	v := m.description
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVoteCount returns the old "vote_count" field's value of the PollOption entity.
-// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// OldDescription returns the old "description" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollOptionMutation) OldVoteCount(ctx context.Context) (v int, err error) {
+func (m *PollMutation) OldDescription(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVoteCount is only allowed on UpdateOne operations")
+		return v, errors.New("OldDescription is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVoteCount requires an ID field in the mutation")
+		return v, errors.New("OldDescription requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVoteCount: %w", err)
+		return v, fmt.Errorf("querying old value for OldDescription: %w", err)
 	}
-	return oldValue.VoteCount, nil
+	return oldValue.Description, nil
 }
 
-// AddVoteCount adds i to the "vote_count" field.
-func (m *PollOptionMutation) AddVoteCount(i int) {
-	if m.addvote_count != nil {
-		*m.addvote_count += i
-	} else {
-		m.addvote_count = &i
-	}
+// ClearDescription clears the value of the "description" field.
+func (m *PollMutation) ClearDescription() {
+	m.description = nil
+	m.clearedFields[poll.FieldDescription] = struct{}{}
 }
 
-// AddedVoteCount returns the value that was added to the "vote_count" field in this mutation.
-func (m *PollOptionMutation) AddedVoteCount() (r int, exists bool) {
-	v := m.addvote_count
-	if v == nil {
-		return
-	}
-	return *v, true
+// DescriptionCleared returns if the "description" field was cleared in this mutation.
+func (m *PollMutation) DescriptionCleared() bool {
+	_, ok := m.clearedFields[poll.FieldDescription]
+	return ok
 }
 
-// ResetVoteCount resets all changes to the "vote_count" field.
-func (m *PollOptionMutation) ResetVoteCount() {
-	m.vote_count = nil
-	m.addvote_count = nil
+// ResetDescription resets all changes to the "description" field.
+func (m *PollMutation) ResetDescription() {
+	m.description = nil
+	delete(m.clearedFields, poll.FieldDescription)
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *PollOptionMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetPollType sets the "poll_type" field.
+func (m *PollMutation) SetPollType(s string) {
+	m.poll_type = &s
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *PollOptionMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// PollType returns the value of the "poll_type" field in the mutation.
+func (m *PollMutation) PollType() (r string, exists bool) {
+	v := m.poll_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the PollOption entity.
-// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// OldPollType returns the old "poll_type" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *PollOptionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *PollMutation) OldPollType(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldPollType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldPollType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldPollType: %w", err)
 	}
-	return oldValue.CreatedAt, nil
-}
-
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *PollOptionMutation) ResetCreatedAt() {
-	m.created_at = nil
+	return oldValue.PollType, nil
+}
+
+// ResetPollType resets all changes to the "poll_type" field.
+func (m *PollMutation) ResetPollType() {
+	m.poll_type = nil
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (m *PollMutation) SetCreatedBy(s string) {
+	m.created_by = &s
+}
+
+// CreatedBy returns the value of the "created_by" field in the mutation.
+func (m *PollMutation) CreatedBy() (r string, exists bool) {
+	v := m.created_by
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedBy returns the old "created_by" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldCreatedBy(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedBy is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedBy requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedBy: %w", err)
+	}
+	return oldValue.CreatedBy, nil
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (m *PollMutation) ClearCreatedBy() {
+	m.created_by = nil
+	m.clearedFields[poll.FieldCreatedBy] = struct{}{}
+}
+
+// CreatedByCleared returns if the "created_by" field was cleared in this mutation.
+func (m *PollMutation) CreatedByCleared() bool {
+	_, ok := m.clearedFields[poll.FieldCreatedBy]
+	return ok
+}
+
+// ResetCreatedBy resets all changes to the "created_by" field.
+func (m *PollMutation) ResetCreatedBy() {
+	m.created_by = nil
+	delete(m.clearedFields, poll.FieldCreatedBy)
+}
+
+// SetMaxVotesPerUser sets the "max_votes_per_user" field.
+func (m *PollMutation) SetMaxVotesPerUser(i int) {
+	m.max_votes_per_user = &i
+	m.addmax_votes_per_user = nil
+}
+
+// MaxVotesPerUser returns the value of the "max_votes_per_user" field in the mutation.
+func (m *PollMutation) MaxVotesPerUser() (r int, exists bool) {
+	v := m.max_votes_per_user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMaxVotesPerUser returns the old "max_votes_per_user" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldMaxVotesPerUser(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMaxVotesPerUser is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMaxVotesPerUser requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMaxVotesPerUser: %w", err)
+	}
+	return oldValue.MaxVotesPerUser, nil
+}
+
+// AddMaxVotesPerUser adds i to the "max_votes_per_user" field.
+func (m *PollMutation) AddMaxVotesPerUser(i int) {
+	if m.addmax_votes_per_user != nil {
+		*m.addmax_votes_per_user += i
+	} else {
+		m.addmax_votes_per_user = &i
+	}
+}
+
+// AddedMaxVotesPerUser returns the value that was added to the "max_votes_per_user" field in this mutation.
+func (m *PollMutation) AddedMaxVotesPerUser() (r int, exists bool) {
+	v := m.addmax_votes_per_user
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetMaxVotesPerUser resets all changes to the "max_votes_per_user" field.
+func (m *PollMutation) ResetMaxVotesPerUser() {
+	m.max_votes_per_user = nil
+	m.addmax_votes_per_user = nil
+}
+
+// SetRatingMin sets the "rating_min" field.
+func (m *PollMutation) SetRatingMin(i int) {
+	m.rating_min = &i
+	m.addrating_min = nil
+}
+
+// RatingMin returns the value of the "rating_min" field in the mutation.
+func (m *PollMutation) RatingMin() (r int, exists bool) {
+	v := m.rating_min
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRatingMin returns the old "rating_min" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldRatingMin(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRatingMin is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRatingMin requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRatingMin: %w", err)
+	}
+	return oldValue.RatingMin, nil
+}
+
+// AddRatingMin adds i to the "rating_min" field.
+func (m *PollMutation) AddRatingMin(i int) {
+	if m.addrating_min != nil {
+		*m.addrating_min += i
+	} else {
+		m.addrating_min = &i
+	}
+}
+
+// AddedRatingMin returns the value that was added to the "rating_min" field in this mutation.
+func (m *PollMutation) AddedRatingMin() (r int, exists bool) {
+	v := m.addrating_min
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRatingMin clears the value of the "rating_min" field.
+func (m *PollMutation) ClearRatingMin() {
+	m.rating_min = nil
+	m.addrating_min = nil
+	m.clearedFields[poll.FieldRatingMin] = struct{}{}
+}
+
+// RatingMinCleared returns if the "rating_min" field was cleared in this mutation.
+func (m *PollMutation) RatingMinCleared() bool {
+	_, ok := m.clearedFields[poll.FieldRatingMin]
+	return ok
+}
+
+// ResetRatingMin resets all changes to the "rating_min" field.
+func (m *PollMutation) ResetRatingMin() {
+	m.rating_min = nil
+	m.addrating_min = nil
+	delete(m.clearedFields, poll.FieldRatingMin)
+}
+
+// SetRatingMax sets the "rating_max" field.
+func (m *PollMutation) SetRatingMax(i int) {
+	m.rating_max = &i
+	m.addrating_max = nil
+}
+
+// RatingMax returns the value of the "rating_max" field in the mutation.
+func (m *PollMutation) RatingMax() (r int, exists bool) {
+	v := m.rating_max
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRatingMax returns the old "rating_max" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldRatingMax(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRatingMax is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRatingMax requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRatingMax: %w", err)
+	}
+	return oldValue.RatingMax, nil
+}
+
+// AddRatingMax adds i to the "rating_max" field.
+func (m *PollMutation) AddRatingMax(i int) {
+	if m.addrating_max != nil {
+		*m.addrating_max += i
+	} else {
+		m.addrating_max = &i
+	}
+}
+
+// AddedRatingMax returns the value that was added to the "rating_max" field in this mutation.
+func (m *PollMutation) AddedRatingMax() (r int, exists bool) {
+	v := m.addrating_max
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRatingMax clears the value of the "rating_max" field.
+func (m *PollMutation) ClearRatingMax() {
+	m.rating_max = nil
+	m.addrating_max = nil
+	m.clearedFields[poll.FieldRatingMax] = struct{}{}
+}
+
+// RatingMaxCleared returns if the "rating_max" field was cleared in this mutation.
+func (m *PollMutation) RatingMaxCleared() bool {
+	_, ok := m.clearedFields[poll.FieldRatingMax]
+	return ok
+}
+
+// ResetRatingMax resets all changes to the "rating_max" field.
+func (m *PollMutation) ResetRatingMax() {
+	m.rating_max = nil
+	m.addrating_max = nil
+	delete(m.clearedFields, poll.FieldRatingMax)
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (m *PollMutation) SetAllowVoteChanges(b bool) {
+	m.allow_vote_changes = &b
+}
+
+// AllowVoteChanges returns the value of the "allow_vote_changes" field in the mutation.
+func (m *PollMutation) AllowVoteChanges() (r bool, exists bool) {
+	v := m.allow_vote_changes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAllowVoteChanges returns the old "allow_vote_changes" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldAllowVoteChanges(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAllowVoteChanges is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAllowVoteChanges requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAllowVoteChanges: %w", err)
+	}
+	return oldValue.AllowVoteChanges, nil
+}
+
+// ResetAllowVoteChanges resets all changes to the "allow_vote_changes" field.
+func (m *PollMutation) ResetAllowVoteChanges() {
+	m.allow_vote_changes = nil
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (m *PollMutation) SetOpensAt(t time.Time) {
+	m.opens_at = &t
+}
+
+// OpensAt returns the value of the "opens_at" field in the mutation.
+func (m *PollMutation) OpensAt() (r time.Time, exists bool) {
+	v := m.opens_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOpensAt returns the old "opens_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldOpensAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOpensAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOpensAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOpensAt: %w", err)
+	}
+	return oldValue.OpensAt, nil
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (m *PollMutation) ClearOpensAt() {
+	m.opens_at = nil
+	m.clearedFields[poll.FieldOpensAt] = struct{}{}
+}
+
+// OpensAtCleared returns if the "opens_at" field was cleared in this mutation.
+func (m *PollMutation) OpensAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldOpensAt]
+	return ok
+}
+
+// ResetOpensAt resets all changes to the "opens_at" field.
+func (m *PollMutation) ResetOpensAt() {
+	m.opens_at = nil
+	delete(m.clearedFields, poll.FieldOpensAt)
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *PollMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *PollMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expires_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldExpiresAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *PollMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[poll.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *PollMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *PollMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, poll.FieldExpiresAt)
+}
+
+// SetState sets the "state" field.
+func (m *PollMutation) SetState(s string) {
+	m.state = &s
+}
+
+// State returns the value of the "state" field in the mutation.
+func (m *PollMutation) State() (r string, exists bool) {
+	v := m.state
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldState returns the old "state" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldState(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldState is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldState requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldState: %w", err)
+	}
+	return oldValue.State, nil
+}
+
+// ResetState resets all changes to the "state" field.
+func (m *PollMutation) ResetState() {
+	m.state = nil
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (m *PollMutation) SetClosedAt(t time.Time) {
+	m.closed_at = &t
+}
+
+// ClosedAt returns the value of the "closed_at" field in the mutation.
+func (m *PollMutation) ClosedAt() (r time.Time, exists bool) {
+	v := m.closed_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldClosedAt returns the old "closed_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldClosedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldClosedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldClosedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldClosedAt: %w", err)
+	}
+	return oldValue.ClosedAt, nil
+}
+
+// ClearClosedAt clears the value of the "closed_at" field.
+func (m *PollMutation) ClearClosedAt() {
+	m.closed_at = nil
+	m.clearedFields[poll.FieldClosedAt] = struct{}{}
+}
+
+// ClosedAtCleared returns if the "closed_at" field was cleared in this mutation.
+func (m *PollMutation) ClosedAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldClosedAt]
+	return ok
+}
+
+// ResetClosedAt resets all changes to the "closed_at" field.
+func (m *PollMutation) ResetClosedAt() {
+	m.closed_at = nil
+	delete(m.clearedFields, poll.FieldClosedAt)
+}
+
+// SetVisibility sets the "visibility" field.
+func (m *PollMutation) SetVisibility(s string) {
+	m.visibility = &s
+}
+
+// Visibility returns the value of the "visibility" field in the mutation.
+func (m *PollMutation) Visibility() (r string, exists bool) {
+	v := m.visibility
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVisibility returns the old "visibility" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldVisibility(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVisibility is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVisibility requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVisibility: %w", err)
+	}
+	return oldValue.Visibility, nil
+}
+
+// ResetVisibility resets all changes to the "visibility" field.
+func (m *PollMutation) ResetVisibility() {
+	m.visibility = nil
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (m *PollMutation) SetResultsVisibility(s string) {
+	m.results_visibility = &s
+}
+
+// ResultsVisibility returns the value of the "results_visibility" field in the mutation.
+func (m *PollMutation) ResultsVisibility() (r string, exists bool) {
+	v := m.results_visibility
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResultsVisibility returns the old "results_visibility" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldResultsVisibility(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResultsVisibility is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResultsVisibility requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResultsVisibility: %w", err)
+	}
+	return oldValue.ResultsVisibility, nil
+}
+
+// ResetResultsVisibility resets all changes to the "results_visibility" field.
+func (m *PollMutation) ResetResultsVisibility() {
+	m.results_visibility = nil
+}
+
+// SetInvitedEmails sets the "invited_emails" field.
+func (m *PollMutation) SetInvitedEmails(s []string) {
+	m.invited_emails = &s
+	m.appendinvited_emails = nil
+}
+
+// InvitedEmails returns the value of the "invited_emails" field in the mutation.
+func (m *PollMutation) InvitedEmails() (r []string, exists bool) {
+	v := m.invited_emails
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldInvitedEmails returns the old "invited_emails" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldInvitedEmails(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldInvitedEmails is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldInvitedEmails requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldInvitedEmails: %w", err)
+	}
+	return oldValue.InvitedEmails, nil
+}
+
+// AppendInvitedEmails adds s to the "invited_emails" field.
+func (m *PollMutation) AppendInvitedEmails(s []string) {
+	m.appendinvited_emails = append(m.appendinvited_emails, s...)
+}
+
+// AppendedInvitedEmails returns the list of values that were appended to the "invited_emails" field in this mutation.
+func (m *PollMutation) AppendedInvitedEmails() ([]string, bool) {
+	if len(m.appendinvited_emails) == 0 {
+		return nil, false
+	}
+	return m.appendinvited_emails, true
+}
+
+// ClearInvitedEmails clears the value of the "invited_emails" field.
+func (m *PollMutation) ClearInvitedEmails() {
+	m.invited_emails = nil
+	m.appendinvited_emails = nil
+	m.clearedFields[poll.FieldInvitedEmails] = struct{}{}
+}
+
+// InvitedEmailsCleared returns if the "invited_emails" field was cleared in this mutation.
+func (m *PollMutation) InvitedEmailsCleared() bool {
+	_, ok := m.clearedFields[poll.FieldInvitedEmails]
+	return ok
+}
+
+// ResetInvitedEmails resets all changes to the "invited_emails" field.
+func (m *PollMutation) ResetInvitedEmails() {
+	m.invited_emails = nil
+	m.appendinvited_emails = nil
+	delete(m.clearedFields, poll.FieldInvitedEmails)
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (m *PollMutation) SetRecurrence(s string) {
+	m.recurrence = &s
+}
+
+// Recurrence returns the value of the "recurrence" field in the mutation.
+func (m *PollMutation) Recurrence() (r string, exists bool) {
+	v := m.recurrence
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRecurrence returns the old "recurrence" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldRecurrence(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRecurrence is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRecurrence requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRecurrence: %w", err)
+	}
+	return oldValue.Recurrence, nil
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (m *PollMutation) ClearRecurrence() {
+	m.recurrence = nil
+	m.clearedFields[poll.FieldRecurrence] = struct{}{}
+}
+
+// RecurrenceCleared returns if the "recurrence" field was cleared in this mutation.
+func (m *PollMutation) RecurrenceCleared() bool {
+	_, ok := m.clearedFields[poll.FieldRecurrence]
+	return ok
+}
+
+// ResetRecurrence resets all changes to the "recurrence" field.
+func (m *PollMutation) ResetRecurrence() {
+	m.recurrence = nil
+	delete(m.clearedFields, poll.FieldRecurrence)
+}
+
+// SetRecurrenceTimezone sets the "recurrence_timezone" field.
+func (m *PollMutation) SetRecurrenceTimezone(s string) {
+	m.recurrence_timezone = &s
+}
+
+// RecurrenceTimezone returns the value of the "recurrence_timezone" field in the mutation.
+func (m *PollMutation) RecurrenceTimezone() (r string, exists bool) {
+	v := m.recurrence_timezone
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRecurrenceTimezone returns the old "recurrence_timezone" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldRecurrenceTimezone(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRecurrenceTimezone is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRecurrenceTimezone requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRecurrenceTimezone: %w", err)
+	}
+	return oldValue.RecurrenceTimezone, nil
+}
+
+// ClearRecurrenceTimezone clears the value of the "recurrence_timezone" field.
+func (m *PollMutation) ClearRecurrenceTimezone() {
+	m.recurrence_timezone = nil
+	m.clearedFields[poll.FieldRecurrenceTimezone] = struct{}{}
+}
+
+// RecurrenceTimezoneCleared returns if the "recurrence_timezone" field was cleared in this mutation.
+func (m *PollMutation) RecurrenceTimezoneCleared() bool {
+	_, ok := m.clearedFields[poll.FieldRecurrenceTimezone]
+	return ok
+}
+
+// ResetRecurrenceTimezone resets all changes to the "recurrence_timezone" field.
+func (m *PollMutation) ResetRecurrenceTimezone() {
+	m.recurrence_timezone = nil
+	delete(m.clearedFields, poll.FieldRecurrenceTimezone)
+}
+
+// SetNextOccurrenceAt sets the "next_occurrence_at" field.
+func (m *PollMutation) SetNextOccurrenceAt(t time.Time) {
+	m.next_occurrence_at = &t
+}
+
+// NextOccurrenceAt returns the value of the "next_occurrence_at" field in the mutation.
+func (m *PollMutation) NextOccurrenceAt() (r time.Time, exists bool) {
+	v := m.next_occurrence_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNextOccurrenceAt returns the old "next_occurrence_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldNextOccurrenceAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNextOccurrenceAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNextOccurrenceAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNextOccurrenceAt: %w", err)
+	}
+	return oldValue.NextOccurrenceAt, nil
+}
+
+// ClearNextOccurrenceAt clears the value of the "next_occurrence_at" field.
+func (m *PollMutation) ClearNextOccurrenceAt() {
+	m.next_occurrence_at = nil
+	m.clearedFields[poll.FieldNextOccurrenceAt] = struct{}{}
+}
+
+// NextOccurrenceAtCleared returns if the "next_occurrence_at" field was cleared in this mutation.
+func (m *PollMutation) NextOccurrenceAtCleared() bool {
+	_, ok := m.clearedFields[poll.FieldNextOccurrenceAt]
+	return ok
+}
+
+// ResetNextOccurrenceAt resets all changes to the "next_occurrence_at" field.
+func (m *PollMutation) ResetNextOccurrenceAt() {
+	m.next_occurrence_at = nil
+	delete(m.clearedFields, poll.FieldNextOccurrenceAt)
+}
+
+// SetSearchText sets the "search_text" field.
+func (m *PollMutation) SetSearchText(s string) {
+	m.search_text = &s
+}
+
+// SearchText returns the value of the "search_text" field in the mutation.
+func (m *PollMutation) SearchText() (r string, exists bool) {
+	v := m.search_text
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldSearchText returns the old "search_text" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldSearchText(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldSearchText is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldSearchText requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldSearchText: %w", err)
+	}
+	return oldValue.SearchText, nil
+}
+
+// ClearSearchText clears the value of the "search_text" field.
+func (m *PollMutation) ClearSearchText() {
+	m.search_text = nil
+	m.clearedFields[poll.FieldSearchText] = struct{}{}
+}
+
+// SearchTextCleared returns if the "search_text" field was cleared in this mutation.
+func (m *PollMutation) SearchTextCleared() bool {
+	_, ok := m.clearedFields[poll.FieldSearchText]
+	return ok
+}
+
+// ResetSearchText resets all changes to the "search_text" field.
+func (m *PollMutation) ResetSearchText() {
+	m.search_text = nil
+	delete(m.clearedFields, poll.FieldSearchText)
+}
+
+// SetTotalVotes sets the "total_votes" field.
+func (m *PollMutation) SetTotalVotes(i int) {
+	m.total_votes = &i
+	m.addtotal_votes = nil
+}
+
+// TotalVotes returns the value of the "total_votes" field in the mutation.
+func (m *PollMutation) TotalVotes() (r int, exists bool) {
+	v := m.total_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTotalVotes returns the old "total_votes" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldTotalVotes(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTotalVotes is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTotalVotes requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTotalVotes: %w", err)
+	}
+	return oldValue.TotalVotes, nil
+}
+
+// AddTotalVotes adds i to the "total_votes" field.
+func (m *PollMutation) AddTotalVotes(i int) {
+	if m.addtotal_votes != nil {
+		*m.addtotal_votes += i
+	} else {
+		m.addtotal_votes = &i
+	}
+}
+
+// AddedTotalVotes returns the value that was added to the "total_votes" field in this mutation.
+func (m *PollMutation) AddedTotalVotes() (r int, exists bool) {
+	v := m.addtotal_votes
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTotalVotes resets all changes to the "total_votes" field.
+func (m *PollMutation) ResetTotalVotes() {
+	m.total_votes = nil
+	m.addtotal_votes = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PollMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PollMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PollMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *PollMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *PollMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Poll entity.
+// If the Poll object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *PollMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// AddOptionIDs adds the "options" edge to the PollOption entity by ids.
+func (m *PollMutation) AddOptionIDs(ids ...int) {
+	if m.options == nil {
+		m.options = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.options[ids[i]] = struct{}{}
+	}
+}
+
+// ClearOptions clears the "options" edge to the PollOption entity.
+func (m *PollMutation) ClearOptions() {
+	m.clearedoptions = true
+}
+
+// OptionsCleared reports if the "options" edge to the PollOption entity was cleared.
+func (m *PollMutation) OptionsCleared() bool {
+	return m.clearedoptions
+}
+
+// RemoveOptionIDs removes the "options" edge to the PollOption entity by IDs.
+func (m *PollMutation) RemoveOptionIDs(ids ...int) {
+	if m.removedoptions == nil {
+		m.removedoptions = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.options, ids[i])
+		m.removedoptions[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedOptions returns the removed IDs of the "options" edge to the PollOption entity.
+func (m *PollMutation) RemovedOptionsIDs() (ids []int) {
+	for id := range m.removedoptions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// OptionsIDs returns the "options" edge IDs in the mutation.
+func (m *PollMutation) OptionsIDs() (ids []int) {
+	for id := range m.options {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetOptions resets all changes to the "options" edge.
+func (m *PollMutation) ResetOptions() {
+	m.options = nil
+	m.clearedoptions = false
+	m.removedoptions = nil
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *PollMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *PollMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *PollMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *PollMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *PollMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *PollMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *PollMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// AddInviteIDs adds the "invites" edge to the PollInvite entity by ids.
+func (m *PollMutation) AddInviteIDs(ids ...int) {
+	if m.invites == nil {
+		m.invites = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.invites[ids[i]] = struct{}{}
+	}
+}
+
+// ClearInvites clears the "invites" edge to the PollInvite entity.
+func (m *PollMutation) ClearInvites() {
+	m.clearedinvites = true
+}
+
+// InvitesCleared reports if the "invites" edge to the PollInvite entity was cleared.
+func (m *PollMutation) InvitesCleared() bool {
+	return m.clearedinvites
+}
+
+// RemoveInviteIDs removes the "invites" edge to the PollInvite entity by IDs.
+func (m *PollMutation) RemoveInviteIDs(ids ...int) {
+	if m.removedinvites == nil {
+		m.removedinvites = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.invites, ids[i])
+		m.removedinvites[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedInvites returns the removed IDs of the "invites" edge to the PollInvite entity.
+func (m *PollMutation) RemovedInvitesIDs() (ids []int) {
+	for id := range m.removedinvites {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// InvitesIDs returns the "invites" edge IDs in the mutation.
+func (m *PollMutation) InvitesIDs() (ids []int) {
+	for id := range m.invites {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetInvites resets all changes to the "invites" edge.
+func (m *PollMutation) ResetInvites() {
+	m.invites = nil
+	m.clearedinvites = false
+	m.removedinvites = nil
+}
+
+// SetTemplateID sets the "template" edge to the Poll entity by id.
+func (m *PollMutation) SetTemplateID(id int) {
+	m.template = &id
+}
+
+// ClearTemplate clears the "template" edge to the Poll entity.
+func (m *PollMutation) ClearTemplate() {
+	m.clearedtemplate = true
+}
+
+// TemplateCleared reports if the "template" edge to the Poll entity was cleared.
+func (m *PollMutation) TemplateCleared() bool {
+	return m.clearedtemplate
+}
+
+// TemplateID returns the "template" edge ID in the mutation.
+func (m *PollMutation) TemplateID() (id int, exists bool) {
+	if m.template != nil {
+		return *m.template, true
+	}
+	return
+}
+
+// TemplateIDs returns the "template" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// TemplateID instead. It exists only for internal usage by the builders.
+func (m *PollMutation) TemplateIDs() (ids []int) {
+	if id := m.template; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetTemplate resets all changes to the "template" edge.
+func (m *PollMutation) ResetTemplate() {
+	m.template = nil
+	m.clearedtemplate = false
+}
+
+// AddOccurrenceIDs adds the "occurrences" edge to the Poll entity by ids.
+func (m *PollMutation) AddOccurrenceIDs(ids ...int) {
+	if m.occurrences == nil {
+		m.occurrences = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.occurrences[ids[i]] = struct{}{}
+	}
+}
+
+// ClearOccurrences clears the "occurrences" edge to the Poll entity.
+func (m *PollMutation) ClearOccurrences() {
+	m.clearedoccurrences = true
+}
+
+// OccurrencesCleared reports if the "occurrences" edge to the Poll entity was cleared.
+func (m *PollMutation) OccurrencesCleared() bool {
+	return m.clearedoccurrences
+}
+
+// RemoveOccurrenceIDs removes the "occurrences" edge to the Poll entity by IDs.
+func (m *PollMutation) RemoveOccurrenceIDs(ids ...int) {
+	if m.removedoccurrences == nil {
+		m.removedoccurrences = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.occurrences, ids[i])
+		m.removedoccurrences[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedOccurrences returns the removed IDs of the "occurrences" edge to the Poll entity.
+func (m *PollMutation) RemovedOccurrencesIDs() (ids []int) {
+	for id := range m.removedoccurrences {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// OccurrencesIDs returns the "occurrences" edge IDs in the mutation.
+func (m *PollMutation) OccurrencesIDs() (ids []int) {
+	for id := range m.occurrences {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetOccurrences resets all changes to the "occurrences" edge.
+func (m *PollMutation) ResetOccurrences() {
+	m.occurrences = nil
+	m.clearedoccurrences = false
+	m.removedoccurrences = nil
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by id.
+func (m *PollMutation) SetOwnerID(id int) {
+	m.owner = &id
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (m *PollMutation) ClearOwner() {
+	m.clearedowner = true
+}
+
+// OwnerCleared reports if the "owner" edge to the User entity was cleared.
+func (m *PollMutation) OwnerCleared() bool {
+	return m.clearedowner
+}
+
+// OwnerID returns the "owner" edge ID in the mutation.
+func (m *PollMutation) OwnerID() (id int, exists bool) {
+	if m.owner != nil {
+		return *m.owner, true
+	}
+	return
+}
+
+// OwnerIDs returns the "owner" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// OwnerID instead. It exists only for internal usage by the builders.
+func (m *PollMutation) OwnerIDs() (ids []int) {
+	if id := m.owner; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetOwner resets all changes to the "owner" edge.
+func (m *PollMutation) ResetOwner() {
+	m.owner = nil
+	m.clearedowner = false
+}
+
+// Where appends a list predicates to the PollMutation builder.
+func (m *PollMutation) Where(ps ...predicate.Poll) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Poll, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Poll).
+func (m *PollMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollMutation) Fields() []string {
+	fields := make([]string, 0, 22)
+	if m.title != nil {
+		fields = append(fields, poll.FieldTitle)
+	}
+	if m.description != nil {
+		fields = append(fields, poll.FieldDescription)
+	}
+	if m.poll_type != nil {
+		fields = append(fields, poll.FieldPollType)
+	}
+	if m.created_by != nil {
+		fields = append(fields, poll.FieldCreatedBy)
+	}
+	if m.max_votes_per_user != nil {
+		fields = append(fields, poll.FieldMaxVotesPerUser)
+	}
+	if m.rating_min != nil {
+		fields = append(fields, poll.FieldRatingMin)
+	}
+	if m.rating_max != nil {
+		fields = append(fields, poll.FieldRatingMax)
+	}
+	if m.allow_vote_changes != nil {
+		fields = append(fields, poll.FieldAllowVoteChanges)
+	}
+	if m.opens_at != nil {
+		fields = append(fields, poll.FieldOpensAt)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, poll.FieldExpiresAt)
+	}
+	if m.state != nil {
+		fields = append(fields, poll.FieldState)
+	}
+	if m.closed_at != nil {
+		fields = append(fields, poll.FieldClosedAt)
+	}
+	if m.visibility != nil {
+		fields = append(fields, poll.FieldVisibility)
+	}
+	if m.results_visibility != nil {
+		fields = append(fields, poll.FieldResultsVisibility)
+	}
+	if m.invited_emails != nil {
+		fields = append(fields, poll.FieldInvitedEmails)
+	}
+	if m.recurrence != nil {
+		fields = append(fields, poll.FieldRecurrence)
+	}
+	if m.recurrence_timezone != nil {
+		fields = append(fields, poll.FieldRecurrenceTimezone)
+	}
+	if m.next_occurrence_at != nil {
+		fields = append(fields, poll.FieldNextOccurrenceAt)
+	}
+	if m.search_text != nil {
+		fields = append(fields, poll.FieldSearchText)
+	}
+	if m.total_votes != nil {
+		fields = append(fields, poll.FieldTotalVotes)
+	}
+	if m.created_at != nil {
+		fields = append(fields, poll.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, poll.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case poll.FieldTitle:
+		return m.Title()
+	case poll.FieldDescription:
+		return m.Description()
+	case poll.FieldPollType:
+		return m.PollType()
+	case poll.FieldCreatedBy:
+		return m.CreatedBy()
+	case poll.FieldMaxVotesPerUser:
+		return m.MaxVotesPerUser()
+	case poll.FieldRatingMin:
+		return m.RatingMin()
+	case poll.FieldRatingMax:
+		return m.RatingMax()
+	case poll.FieldAllowVoteChanges:
+		return m.AllowVoteChanges()
+	case poll.FieldOpensAt:
+		return m.OpensAt()
+	case poll.FieldExpiresAt:
+		return m.ExpiresAt()
+	case poll.FieldState:
+		return m.State()
+	case poll.FieldClosedAt:
+		return m.ClosedAt()
+	case poll.FieldVisibility:
+		return m.Visibility()
+	case poll.FieldResultsVisibility:
+		return m.ResultsVisibility()
+	case poll.FieldInvitedEmails:
+		return m.InvitedEmails()
+	case poll.FieldRecurrence:
+		return m.Recurrence()
+	case poll.FieldRecurrenceTimezone:
+		return m.RecurrenceTimezone()
+	case poll.FieldNextOccurrenceAt:
+		return m.NextOccurrenceAt()
+	case poll.FieldSearchText:
+		return m.SearchText()
+	case poll.FieldTotalVotes:
+		return m.TotalVotes()
+	case poll.FieldCreatedAt:
+		return m.CreatedAt()
+	case poll.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case poll.FieldTitle:
+		return m.OldTitle(ctx)
+	case poll.FieldDescription:
+		return m.OldDescription(ctx)
+	case poll.FieldPollType:
+		return m.OldPollType(ctx)
+	case poll.FieldCreatedBy:
+		return m.OldCreatedBy(ctx)
+	case poll.FieldMaxVotesPerUser:
+		return m.OldMaxVotesPerUser(ctx)
+	case poll.FieldRatingMin:
+		return m.OldRatingMin(ctx)
+	case poll.FieldRatingMax:
+		return m.OldRatingMax(ctx)
+	case poll.FieldAllowVoteChanges:
+		return m.OldAllowVoteChanges(ctx)
+	case poll.FieldOpensAt:
+		return m.OldOpensAt(ctx)
+	case poll.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case poll.FieldState:
+		return m.OldState(ctx)
+	case poll.FieldClosedAt:
+		return m.OldClosedAt(ctx)
+	case poll.FieldVisibility:
+		return m.OldVisibility(ctx)
+	case poll.FieldResultsVisibility:
+		return m.OldResultsVisibility(ctx)
+	case poll.FieldInvitedEmails:
+		return m.OldInvitedEmails(ctx)
+	case poll.FieldRecurrence:
+		return m.OldRecurrence(ctx)
+	case poll.FieldRecurrenceTimezone:
+		return m.OldRecurrenceTimezone(ctx)
+	case poll.FieldNextOccurrenceAt:
+		return m.OldNextOccurrenceAt(ctx)
+	case poll.FieldSearchText:
+		return m.OldSearchText(ctx)
+	case poll.FieldTotalVotes:
+		return m.OldTotalVotes(ctx)
+	case poll.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case poll.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Poll field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case poll.FieldTitle:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTitle(v)
+		return nil
+	case poll.FieldDescription:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDescription(v)
+		return nil
+	case poll.FieldPollType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPollType(v)
+		return nil
+	case poll.FieldCreatedBy:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedBy(v)
+		return nil
+	case poll.FieldMaxVotesPerUser:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMaxVotesPerUser(v)
+		return nil
+	case poll.FieldRatingMin:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRatingMin(v)
+		return nil
+	case poll.FieldRatingMax:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRatingMax(v)
+		return nil
+	case poll.FieldAllowVoteChanges:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAllowVoteChanges(v)
+		return nil
+	case poll.FieldOpensAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOpensAt(v)
+		return nil
+	case poll.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case poll.FieldState:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetState(v)
+		return nil
+	case poll.FieldClosedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetClosedAt(v)
+		return nil
+	case poll.FieldVisibility:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVisibility(v)
+		return nil
+	case poll.FieldResultsVisibility:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResultsVisibility(v)
+		return nil
+	case poll.FieldInvitedEmails:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetInvitedEmails(v)
+		return nil
+	case poll.FieldRecurrence:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRecurrence(v)
+		return nil
+	case poll.FieldRecurrenceTimezone:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRecurrenceTimezone(v)
+		return nil
+	case poll.FieldNextOccurrenceAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNextOccurrenceAt(v)
+		return nil
+	case poll.FieldSearchText:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetSearchText(v)
+		return nil
+	case poll.FieldTotalVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTotalVotes(v)
+		return nil
+	case poll.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case poll.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Poll field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollMutation) AddedFields() []string {
+	var fields []string
+	if m.addmax_votes_per_user != nil {
+		fields = append(fields, poll.FieldMaxVotesPerUser)
+	}
+	if m.addrating_min != nil {
+		fields = append(fields, poll.FieldRatingMin)
+	}
+	if m.addrating_max != nil {
+		fields = append(fields, poll.FieldRatingMax)
+	}
+	if m.addtotal_votes != nil {
+		fields = append(fields, poll.FieldTotalVotes)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case poll.FieldMaxVotesPerUser:
+		return m.AddedMaxVotesPerUser()
+	case poll.FieldRatingMin:
+		return m.AddedRatingMin()
+	case poll.FieldRatingMax:
+		return m.AddedRatingMax()
+	case poll.FieldTotalVotes:
+		return m.AddedTotalVotes()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case poll.FieldMaxVotesPerUser:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddMaxVotesPerUser(v)
+		return nil
+	case poll.FieldRatingMin:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRatingMin(v)
+		return nil
+	case poll.FieldRatingMax:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddRatingMax(v)
+		return nil
+	case poll.FieldTotalVotes:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTotalVotes(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Poll numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(poll.FieldDescription) {
+		fields = append(fields, poll.FieldDescription)
+	}
+	if m.FieldCleared(poll.FieldCreatedBy) {
+		fields = append(fields, poll.FieldCreatedBy)
+	}
+	if m.FieldCleared(poll.FieldRatingMin) {
+		fields = append(fields, poll.FieldRatingMin)
+	}
+	if m.FieldCleared(poll.FieldRatingMax) {
+		fields = append(fields, poll.FieldRatingMax)
+	}
+	if m.FieldCleared(poll.FieldOpensAt) {
+		fields = append(fields, poll.FieldOpensAt)
+	}
+	if m.FieldCleared(poll.FieldExpiresAt) {
+		fields = append(fields, poll.FieldExpiresAt)
+	}
+	if m.FieldCleared(poll.FieldClosedAt) {
+		fields = append(fields, poll.FieldClosedAt)
+	}
+	if m.FieldCleared(poll.FieldInvitedEmails) {
+		fields = append(fields, poll.FieldInvitedEmails)
+	}
+	if m.FieldCleared(poll.FieldRecurrence) {
+		fields = append(fields, poll.FieldRecurrence)
+	}
+	if m.FieldCleared(poll.FieldRecurrenceTimezone) {
+		fields = append(fields, poll.FieldRecurrenceTimezone)
+	}
+	if m.FieldCleared(poll.FieldNextOccurrenceAt) {
+		fields = append(fields, poll.FieldNextOccurrenceAt)
+	}
+	if m.FieldCleared(poll.FieldSearchText) {
+		fields = append(fields, poll.FieldSearchText)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollMutation) ClearField(name string) error {
+	switch name {
+	case poll.FieldDescription:
+		m.ClearDescription()
+		return nil
+	case poll.FieldCreatedBy:
+		m.ClearCreatedBy()
+		return nil
+	case poll.FieldRatingMin:
+		m.ClearRatingMin()
+		return nil
+	case poll.FieldRatingMax:
+		m.ClearRatingMax()
+		return nil
+	case poll.FieldOpensAt:
+		m.ClearOpensAt()
+		return nil
+	case poll.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case poll.FieldClosedAt:
+		m.ClearClosedAt()
+		return nil
+	case poll.FieldInvitedEmails:
+		m.ClearInvitedEmails()
+		return nil
+	case poll.FieldRecurrence:
+		m.ClearRecurrence()
+		return nil
+	case poll.FieldRecurrenceTimezone:
+		m.ClearRecurrenceTimezone()
+		return nil
+	case poll.FieldNextOccurrenceAt:
+		m.ClearNextOccurrenceAt()
+		return nil
+	case poll.FieldSearchText:
+		m.ClearSearchText()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollMutation) ResetField(name string) error {
+	switch name {
+	case poll.FieldTitle:
+		m.ResetTitle()
+		return nil
+	case poll.FieldDescription:
+		m.ResetDescription()
+		return nil
+	case poll.FieldPollType:
+		m.ResetPollType()
+		return nil
+	case poll.FieldCreatedBy:
+		m.ResetCreatedBy()
+		return nil
+	case poll.FieldMaxVotesPerUser:
+		m.ResetMaxVotesPerUser()
+		return nil
+	case poll.FieldRatingMin:
+		m.ResetRatingMin()
+		return nil
+	case poll.FieldRatingMax:
+		m.ResetRatingMax()
+		return nil
+	case poll.FieldAllowVoteChanges:
+		m.ResetAllowVoteChanges()
+		return nil
+	case poll.FieldOpensAt:
+		m.ResetOpensAt()
+		return nil
+	case poll.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case poll.FieldState:
+		m.ResetState()
+		return nil
+	case poll.FieldClosedAt:
+		m.ResetClosedAt()
+		return nil
+	case poll.FieldVisibility:
+		m.ResetVisibility()
+		return nil
+	case poll.FieldResultsVisibility:
+		m.ResetResultsVisibility()
+		return nil
+	case poll.FieldInvitedEmails:
+		m.ResetInvitedEmails()
+		return nil
+	case poll.FieldRecurrence:
+		m.ResetRecurrence()
+		return nil
+	case poll.FieldRecurrenceTimezone:
+		m.ResetRecurrenceTimezone()
+		return nil
+	case poll.FieldNextOccurrenceAt:
+		m.ResetNextOccurrenceAt()
+		return nil
+	case poll.FieldSearchText:
+		m.ResetSearchText()
+		return nil
+	case poll.FieldTotalVotes:
+		m.ResetTotalVotes()
+		return nil
+	case poll.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case poll.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollMutation) AddedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.options != nil {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.votes != nil {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.invites != nil {
+		edges = append(edges, poll.EdgeInvites)
+	}
+	if m.template != nil {
+		edges = append(edges, poll.EdgeTemplate)
+	}
+	if m.occurrences != nil {
+		edges = append(edges, poll.EdgeOccurrences)
+	}
+	if m.owner != nil {
+		edges = append(edges, poll.EdgeOwner)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case poll.EdgeOptions:
+		ids := make([]ent.Value, 0, len(m.options))
+		for id := range m.options {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.votes))
+		for id := range m.votes {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeInvites:
+		ids := make([]ent.Value, 0, len(m.invites))
+		for id := range m.invites {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeTemplate:
+		if id := m.template; id != nil {
+			return []ent.Value{*id}
+		}
+	case poll.EdgeOccurrences:
+		ids := make([]ent.Value, 0, len(m.occurrences))
+		for id := range m.occurrences {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeOwner:
+		if id := m.owner; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.removedoptions != nil {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.removedvotes != nil {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.removedinvites != nil {
+		edges = append(edges, poll.EdgeInvites)
+	}
+	if m.removedoccurrences != nil {
+		edges = append(edges, poll.EdgeOccurrences)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case poll.EdgeOptions:
+		ids := make([]ent.Value, 0, len(m.removedoptions))
+		for id := range m.removedoptions {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.removedvotes))
+		for id := range m.removedvotes {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeInvites:
+		ids := make([]ent.Value, 0, len(m.removedinvites))
+		for id := range m.removedinvites {
+			ids = append(ids, id)
+		}
+		return ids
+	case poll.EdgeOccurrences:
+		ids := make([]ent.Value, 0, len(m.removedoccurrences))
+		for id := range m.removedoccurrences {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 6)
+	if m.clearedoptions {
+		edges = append(edges, poll.EdgeOptions)
+	}
+	if m.clearedvotes {
+		edges = append(edges, poll.EdgeVotes)
+	}
+	if m.clearedinvites {
+		edges = append(edges, poll.EdgeInvites)
+	}
+	if m.clearedtemplate {
+		edges = append(edges, poll.EdgeTemplate)
+	}
+	if m.clearedoccurrences {
+		edges = append(edges, poll.EdgeOccurrences)
+	}
+	if m.clearedowner {
+		edges = append(edges, poll.EdgeOwner)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollMutation) EdgeCleared(name string) bool {
+	switch name {
+	case poll.EdgeOptions:
+		return m.clearedoptions
+	case poll.EdgeVotes:
+		return m.clearedvotes
+	case poll.EdgeInvites:
+		return m.clearedinvites
+	case poll.EdgeTemplate:
+		return m.clearedtemplate
+	case poll.EdgeOccurrences:
+		return m.clearedoccurrences
+	case poll.EdgeOwner:
+		return m.clearedowner
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollMutation) ClearEdge(name string) error {
+	switch name {
+	case poll.EdgeTemplate:
+		m.ClearTemplate()
+		return nil
+	case poll.EdgeOwner:
+		m.ClearOwner()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollMutation) ResetEdge(name string) error {
+	switch name {
+	case poll.EdgeOptions:
+		m.ResetOptions()
+		return nil
+	case poll.EdgeVotes:
+		m.ResetVotes()
+		return nil
+	case poll.EdgeInvites:
+		m.ResetInvites()
+		return nil
+	case poll.EdgeTemplate:
+		m.ResetTemplate()
+		return nil
+	case poll.EdgeOccurrences:
+		m.ResetOccurrences()
+		return nil
+	case poll.EdgeOwner:
+		m.ResetOwner()
+		return nil
+	}
+	return fmt.Errorf("unknown Poll edge %s", name)
+}
+
+// PollInviteMutation represents an operation that mutates the PollInvite nodes in the graph.
+type PollInviteMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	label         *string
+	expires_at    *time.Time
+	revoked_at    *time.Time
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	poll          *int
+	clearedpoll   bool
+	done          bool
+	oldValue      func(context.Context) (*PollInvite, error)
+	predicates    []predicate.PollInvite
+}
+
+var _ ent.Mutation = (*PollInviteMutation)(nil)
+
+// pollinviteOption allows management of the mutation configuration using functional options.
+type pollinviteOption func(*PollInviteMutation)
+
+// newPollInviteMutation creates new mutation for the PollInvite entity.
+func newPollInviteMutation(c config, op Op, opts ...pollinviteOption) *PollInviteMutation {
+	m := &PollInviteMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePollInvite,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPollInviteID sets the ID field of the mutation.
+func withPollInviteID(id int) pollinviteOption {
+	return func(m *PollInviteMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PollInvite
+		)
+		m.oldValue = func(ctx context.Context) (*PollInvite, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PollInvite.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPollInvite sets the old PollInvite of the mutation.
+func withPollInvite(node *PollInvite) pollinviteOption {
+	return func(m *PollInviteMutation) {
+		m.oldValue = func(context.Context) (*PollInvite, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PollInviteMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PollInviteMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PollInviteMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PollInviteMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PollInvite.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetLabel sets the "label" field.
+func (m *PollInviteMutation) SetLabel(s string) {
+	m.label = &s
+}
+
+// Label returns the value of the "label" field in the mutation.
+func (m *PollInviteMutation) Label() (r string, exists bool) {
+	v := m.label
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLabel returns the old "label" field's value of the PollInvite entity.
+// If the PollInvite object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollInviteMutation) OldLabel(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLabel is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLabel requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLabel: %w", err)
+	}
+	return oldValue.Label, nil
+}
+
+// ClearLabel clears the value of the "label" field.
+func (m *PollInviteMutation) ClearLabel() {
+	m.label = nil
+	m.clearedFields[pollinvite.FieldLabel] = struct{}{}
+}
+
+// LabelCleared returns if the "label" field was cleared in this mutation.
+func (m *PollInviteMutation) LabelCleared() bool {
+	_, ok := m.clearedFields[pollinvite.FieldLabel]
+	return ok
+}
+
+// ResetLabel resets all changes to the "label" field.
+func (m *PollInviteMutation) ResetLabel() {
+	m.label = nil
+	delete(m.clearedFields, pollinvite.FieldLabel)
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (m *PollInviteMutation) SetExpiresAt(t time.Time) {
+	m.expires_at = &t
+}
+
+// ExpiresAt returns the value of the "expires_at" field in the mutation.
+func (m *PollInviteMutation) ExpiresAt() (r time.Time, exists bool) {
+	v := m.expires_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldExpiresAt returns the old "expires_at" field's value of the PollInvite entity.
+// If the PollInvite object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollInviteMutation) OldExpiresAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldExpiresAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldExpiresAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldExpiresAt: %w", err)
+	}
+	return oldValue.ExpiresAt, nil
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (m *PollInviteMutation) ClearExpiresAt() {
+	m.expires_at = nil
+	m.clearedFields[pollinvite.FieldExpiresAt] = struct{}{}
+}
+
+// ExpiresAtCleared returns if the "expires_at" field was cleared in this mutation.
+func (m *PollInviteMutation) ExpiresAtCleared() bool {
+	_, ok := m.clearedFields[pollinvite.FieldExpiresAt]
+	return ok
+}
+
+// ResetExpiresAt resets all changes to the "expires_at" field.
+func (m *PollInviteMutation) ResetExpiresAt() {
+	m.expires_at = nil
+	delete(m.clearedFields, pollinvite.FieldExpiresAt)
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (m *PollInviteMutation) SetRevokedAt(t time.Time) {
+	m.revoked_at = &t
+}
+
+// RevokedAt returns the value of the "revoked_at" field in the mutation.
+func (m *PollInviteMutation) RevokedAt() (r time.Time, exists bool) {
+	v := m.revoked_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRevokedAt returns the old "revoked_at" field's value of the PollInvite entity.
+// If the PollInvite object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollInviteMutation) OldRevokedAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRevokedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRevokedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRevokedAt: %w", err)
+	}
+	return oldValue.RevokedAt, nil
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (m *PollInviteMutation) ClearRevokedAt() {
+	m.revoked_at = nil
+	m.clearedFields[pollinvite.FieldRevokedAt] = struct{}{}
+}
+
+// RevokedAtCleared returns if the "revoked_at" field was cleared in this mutation.
+func (m *PollInviteMutation) RevokedAtCleared() bool {
+	_, ok := m.clearedFields[pollinvite.FieldRevokedAt]
+	return ok
+}
+
+// ResetRevokedAt resets all changes to the "revoked_at" field.
+func (m *PollInviteMutation) ResetRevokedAt() {
+	m.revoked_at = nil
+	delete(m.clearedFields, pollinvite.FieldRevokedAt)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PollInviteMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PollInviteMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the PollInvite entity.
+// If the PollInvite object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollInviteMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PollInviteMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by id.
+func (m *PollInviteMutation) SetPollID(id int) {
+	m.poll = &id
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (m *PollInviteMutation) ClearPoll() {
+	m.clearedpoll = true
+}
+
+// PollCleared reports if the "poll" edge to the Poll entity was cleared.
+func (m *PollInviteMutation) PollCleared() bool {
+	return m.clearedpoll
+}
+
+// PollID returns the "poll" edge ID in the mutation.
+func (m *PollInviteMutation) PollID() (id int, exists bool) {
+	if m.poll != nil {
+		return *m.poll, true
+	}
+	return
+}
+
+// PollIDs returns the "poll" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PollID instead. It exists only for internal usage by the builders.
+func (m *PollInviteMutation) PollIDs() (ids []int) {
+	if id := m.poll; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPoll resets all changes to the "poll" edge.
+func (m *PollInviteMutation) ResetPoll() {
+	m.poll = nil
+	m.clearedpoll = false
+}
+
+// Where appends a list predicates to the PollInviteMutation builder.
+func (m *PollInviteMutation) Where(ps ...predicate.PollInvite) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollInviteMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollInviteMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PollInvite, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollInviteMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollInviteMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PollInvite).
+func (m *PollInviteMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollInviteMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.label != nil {
+		fields = append(fields, pollinvite.FieldLabel)
+	}
+	if m.expires_at != nil {
+		fields = append(fields, pollinvite.FieldExpiresAt)
+	}
+	if m.revoked_at != nil {
+		fields = append(fields, pollinvite.FieldRevokedAt)
+	}
+	if m.created_at != nil {
+		fields = append(fields, pollinvite.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollInviteMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case pollinvite.FieldLabel:
+		return m.Label()
+	case pollinvite.FieldExpiresAt:
+		return m.ExpiresAt()
+	case pollinvite.FieldRevokedAt:
+		return m.RevokedAt()
+	case pollinvite.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollInviteMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case pollinvite.FieldLabel:
+		return m.OldLabel(ctx)
+	case pollinvite.FieldExpiresAt:
+		return m.OldExpiresAt(ctx)
+	case pollinvite.FieldRevokedAt:
+		return m.OldRevokedAt(ctx)
+	case pollinvite.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PollInvite field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollInviteMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case pollinvite.FieldLabel:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLabel(v)
+		return nil
+	case pollinvite.FieldExpiresAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetExpiresAt(v)
+		return nil
+	case pollinvite.FieldRevokedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRevokedAt(v)
+		return nil
+	case pollinvite.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollInvite field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollInviteMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollInviteMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollInviteMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown PollInvite numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollInviteMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(pollinvite.FieldLabel) {
+		fields = append(fields, pollinvite.FieldLabel)
+	}
+	if m.FieldCleared(pollinvite.FieldExpiresAt) {
+		fields = append(fields, pollinvite.FieldExpiresAt)
+	}
+	if m.FieldCleared(pollinvite.FieldRevokedAt) {
+		fields = append(fields, pollinvite.FieldRevokedAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollInviteMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollInviteMutation) ClearField(name string) error {
+	switch name {
+	case pollinvite.FieldLabel:
+		m.ClearLabel()
+		return nil
+	case pollinvite.FieldExpiresAt:
+		m.ClearExpiresAt()
+		return nil
+	case pollinvite.FieldRevokedAt:
+		m.ClearRevokedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PollInvite nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollInviteMutation) ResetField(name string) error {
+	switch name {
+	case pollinvite.FieldLabel:
+		m.ResetLabel()
+		return nil
+	case pollinvite.FieldExpiresAt:
+		m.ResetExpiresAt()
+		return nil
+	case pollinvite.FieldRevokedAt:
+		m.ResetRevokedAt()
+		return nil
+	case pollinvite.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PollInvite field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollInviteMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.poll != nil {
+		edges = append(edges, pollinvite.EdgePoll)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollInviteMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case pollinvite.EdgePoll:
+		if id := m.poll; id != nil {
+			return []ent.Value{*id}
+		}
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollInviteMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollInviteMutation) RemovedIDs(name string) []ent.Value {
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollInviteMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedpoll {
+		edges = append(edges, pollinvite.EdgePoll)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollInviteMutation) EdgeCleared(name string) bool {
+	switch name {
+	case pollinvite.EdgePoll:
+		return m.clearedpoll
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollInviteMutation) ClearEdge(name string) error {
+	switch name {
+	case pollinvite.EdgePoll:
+		m.ClearPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown PollInvite unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollInviteMutation) ResetEdge(name string) error {
+	switch name {
+	case pollinvite.EdgePoll:
+		m.ResetPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown PollInvite edge %s", name)
+}
+
+// PollOptionMutation represents an operation that mutates the PollOption nodes in the graph.
+type PollOptionMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	option_text   *string
+	vote_count    *int
+	addvote_count *int
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	poll          *int
+	clearedpoll   bool
+	votes         map[int]struct{}
+	removedvotes  map[int]struct{}
+	clearedvotes  bool
+	done          bool
+	oldValue      func(context.Context) (*PollOption, error)
+	predicates    []predicate.PollOption
+}
+
+var _ ent.Mutation = (*PollOptionMutation)(nil)
+
+// polloptionOption allows management of the mutation configuration using functional options.
+type polloptionOption func(*PollOptionMutation)
+
+// newPollOptionMutation creates new mutation for the PollOption entity.
+func newPollOptionMutation(c config, op Op, opts ...polloptionOption) *PollOptionMutation {
+	m := &PollOptionMutation{
+		config:        c,
+		op:            op,
+		typ:           TypePollOption,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withPollOptionID sets the ID field of the mutation.
+func withPollOptionID(id int) polloptionOption {
+	return func(m *PollOptionMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *PollOption
+		)
+		m.oldValue = func(ctx context.Context) (*PollOption, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().PollOption.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withPollOption sets the old PollOption of the mutation.
+func withPollOption(node *PollOption) polloptionOption {
+	return func(m *PollOptionMutation) {
+		m.oldValue = func(context.Context) (*PollOption, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m PollOptionMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m PollOptionMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *PollOptionMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *PollOptionMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().PollOption.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetOptionText sets the "option_text" field.
+func (m *PollOptionMutation) SetOptionText(s string) {
+	m.option_text = &s
+}
+
+// OptionText returns the value of the "option_text" field in the mutation.
+func (m *PollOptionMutation) OptionText() (r string, exists bool) {
+	v := m.option_text
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOptionText returns the old "option_text" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldOptionText(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOptionText is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOptionText requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOptionText: %w", err)
+	}
+	return oldValue.OptionText, nil
+}
+
+// ResetOptionText resets all changes to the "option_text" field.
+func (m *PollOptionMutation) ResetOptionText() {
+	m.option_text = nil
+}
+
+// SetVoteCount sets the "vote_count" field.
+func (m *PollOptionMutation) SetVoteCount(i int) {
+	m.vote_count = &i
+	m.addvote_count = nil
+}
+
+// VoteCount returns the value of the "vote_count" field in the mutation.
+func (m *PollOptionMutation) VoteCount() (r int, exists bool) {
+	v := m.vote_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVoteCount returns the old "vote_count" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldVoteCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVoteCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVoteCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVoteCount: %w", err)
+	}
+	return oldValue.VoteCount, nil
+}
+
+// AddVoteCount adds i to the "vote_count" field.
+func (m *PollOptionMutation) AddVoteCount(i int) {
+	if m.addvote_count != nil {
+		*m.addvote_count += i
+	} else {
+		m.addvote_count = &i
+	}
+}
+
+// AddedVoteCount returns the value that was added to the "vote_count" field in this mutation.
+func (m *PollOptionMutation) AddedVoteCount() (r int, exists bool) {
+	v := m.addvote_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetVoteCount resets all changes to the "vote_count" field.
+func (m *PollOptionMutation) ResetVoteCount() {
+	m.vote_count = nil
+	m.addvote_count = nil
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *PollOptionMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *PollOptionMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the PollOption entity.
+// If the PollOption object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *PollOptionMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *PollOptionMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by id.
+func (m *PollOptionMutation) SetPollID(id int) {
+	m.poll = &id
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (m *PollOptionMutation) ClearPoll() {
+	m.clearedpoll = true
+}
+
+// PollCleared reports if the "poll" edge to the Poll entity was cleared.
+func (m *PollOptionMutation) PollCleared() bool {
+	return m.clearedpoll
+}
+
+// PollID returns the "poll" edge ID in the mutation.
+func (m *PollOptionMutation) PollID() (id int, exists bool) {
+	if m.poll != nil {
+		return *m.poll, true
+	}
+	return
+}
+
+// PollIDs returns the "poll" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// PollID instead. It exists only for internal usage by the builders.
+func (m *PollOptionMutation) PollIDs() (ids []int) {
+	if id := m.poll; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetPoll resets all changes to the "poll" edge.
+func (m *PollOptionMutation) ResetPoll() {
+	m.poll = nil
+	m.clearedpoll = false
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *PollOptionMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *PollOptionMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *PollOptionMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *PollOptionMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *PollOptionMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *PollOptionMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *PollOptionMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// Where appends a list predicates to the PollOptionMutation builder.
+func (m *PollOptionMutation) Where(ps ...predicate.PollOption) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the PollOptionMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.PollOption, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *PollOptionMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *PollOptionMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (PollOption).
+func (m *PollOptionMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *PollOptionMutation) Fields() []string {
+	fields := make([]string, 0, 3)
+	if m.option_text != nil {
+		fields = append(fields, polloption.FieldOptionText)
+	}
+	if m.vote_count != nil {
+		fields = append(fields, polloption.FieldVoteCount)
+	}
+	if m.created_at != nil {
+		fields = append(fields, polloption.FieldCreatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case polloption.FieldOptionText:
+		return m.OptionText()
+	case polloption.FieldVoteCount:
+		return m.VoteCount()
+	case polloption.FieldCreatedAt:
+		return m.CreatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *PollOptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case polloption.FieldOptionText:
+		return m.OldOptionText(ctx)
+	case polloption.FieldVoteCount:
+		return m.OldVoteCount(ctx)
+	case polloption.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollOptionMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case polloption.FieldOptionText:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOptionText(v)
+		return nil
+	case polloption.FieldVoteCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVoteCount(v)
+		return nil
+	case polloption.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *PollOptionMutation) AddedFields() []string {
+	var fields []string
+	if m.addvote_count != nil {
+		fields = append(fields, polloption.FieldVoteCount)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *PollOptionMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case polloption.FieldVoteCount:
+		return m.AddedVoteCount()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *PollOptionMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case polloption.FieldVoteCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddVoteCount(v)
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *PollOptionMutation) ClearedFields() []string {
+	return nil
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *PollOptionMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *PollOptionMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown PollOption nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *PollOptionMutation) ResetField(name string) error {
+	switch name {
+	case polloption.FieldOptionText:
+		m.ResetOptionText()
+		return nil
+	case polloption.FieldVoteCount:
+		m.ResetVoteCount()
+		return nil
+	case polloption.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *PollOptionMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.poll != nil {
+		edges = append(edges, polloption.EdgePoll)
+	}
+	if m.votes != nil {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *PollOptionMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case polloption.EdgePoll:
+		if id := m.poll; id != nil {
+			return []ent.Value{*id}
+		}
+	case polloption.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.votes))
+		for id := range m.votes {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *PollOptionMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removedvotes != nil {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *PollOptionMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case polloption.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.removedvotes))
+		for id := range m.removedvotes {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *PollOptionMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedpoll {
+		edges = append(edges, polloption.EdgePoll)
+	}
+	if m.clearedvotes {
+		edges = append(edges, polloption.EdgeVotes)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *PollOptionMutation) EdgeCleared(name string) bool {
+	switch name {
+	case polloption.EdgePoll:
+		return m.clearedpoll
+	case polloption.EdgeVotes:
+		return m.clearedvotes
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *PollOptionMutation) ClearEdge(name string) error {
+	switch name {
+	case polloption.EdgePoll:
+		m.ClearPoll()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *PollOptionMutation) ResetEdge(name string) error {
+	switch name {
+	case polloption.EdgePoll:
+		m.ResetPoll()
+		return nil
+	case polloption.EdgeVotes:
+		m.ResetVotes()
+		return nil
+	}
+	return fmt.Errorf("unknown PollOption edge %s", name)
+}
+
+// UserMutation represents an operation that mutates the User nodes in the graph.
+type UserMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *int
+	email             *string
+	password          *string
+	display_name      *string
+	avatar_url        *string
+	role              *user.Role
+	token_version     *int
+	addtoken_version  *int
+	disabled_at       *time.Time
+	created_at        *time.Time
+	updated_at        *time.Time
+	clearedFields     map[string]struct{}
+	polls             map[int]struct{}
+	removedpolls      map[int]struct{}
+	clearedpolls      bool
+	votes             map[int]struct{}
+	removedvotes      map[int]struct{}
+	clearedvotes      bool
+	webhooks          map[int]struct{}
+	removedwebhooks   map[int]struct{}
+	clearedwebhooks   bool
+	audit_logs        map[int]struct{}
+	removedaudit_logs map[int]struct{}
+	clearedaudit_logs bool
+	done              bool
+	oldValue          func(context.Context) (*User, error)
+	predicates        []predicate.User
+}
+
+var _ ent.Mutation = (*UserMutation)(nil)
+
+// userOption allows management of the mutation configuration using functional options.
+type userOption func(*UserMutation)
+
+// newUserMutation creates new mutation for the User entity.
+func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
+	m := &UserMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeUser,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withUserID sets the ID field of the mutation.
+func withUserID(id int) userOption {
+	return func(m *UserMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *User
+		)
+		m.oldValue = func(ctx context.Context) (*User, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().User.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withUser sets the old User of the mutation.
+func withUser(node *User) userOption {
+	return func(m *UserMutation) {
+		m.oldValue = func(context.Context) (*User, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m UserMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m UserMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *UserMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *UserMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetEmail sets the "email" field.
+func (m *UserMutation) SetEmail(s string) {
+	m.email = &s
+}
+
+// Email returns the value of the "email" field in the mutation.
+func (m *UserMutation) Email() (r string, exists bool) {
+	v := m.email
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEmail returns the old "email" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEmail requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+	}
+	return oldValue.Email, nil
+}
+
+// ResetEmail resets all changes to the "email" field.
+func (m *UserMutation) ResetEmail() {
+	m.email = nil
+}
+
+// SetPassword sets the "password" field.
+func (m *UserMutation) SetPassword(s string) {
+	m.password = &s
+}
+
+// Password returns the value of the "password" field in the mutation.
+func (m *UserMutation) Password() (r string, exists bool) {
+	v := m.password
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPassword returns the old "password" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldPassword(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPassword requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+	}
+	return oldValue.Password, nil
+}
+
+// ResetPassword resets all changes to the "password" field.
+func (m *UserMutation) ResetPassword() {
+	m.password = nil
+}
+
+// SetDisplayName sets the "display_name" field.
+func (m *UserMutation) SetDisplayName(s string) {
+	m.display_name = &s
+}
+
+// DisplayName returns the value of the "display_name" field in the mutation.
+func (m *UserMutation) DisplayName() (r string, exists bool) {
+	v := m.display_name
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDisplayName returns the old "display_name" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDisplayName(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisplayName is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisplayName requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisplayName: %w", err)
+	}
+	return oldValue.DisplayName, nil
+}
+
+// ClearDisplayName clears the value of the "display_name" field.
+func (m *UserMutation) ClearDisplayName() {
+	m.display_name = nil
+	m.clearedFields[user.FieldDisplayName] = struct{}{}
+}
+
+// DisplayNameCleared returns if the "display_name" field was cleared in this mutation.
+func (m *UserMutation) DisplayNameCleared() bool {
+	_, ok := m.clearedFields[user.FieldDisplayName]
+	return ok
+}
+
+// ResetDisplayName resets all changes to the "display_name" field.
+func (m *UserMutation) ResetDisplayName() {
+	m.display_name = nil
+	delete(m.clearedFields, user.FieldDisplayName)
+}
+
+// SetAvatarURL sets the "avatar_url" field.
+func (m *UserMutation) SetAvatarURL(s string) {
+	m.avatar_url = &s
+}
+
+// AvatarURL returns the value of the "avatar_url" field in the mutation.
+func (m *UserMutation) AvatarURL() (r string, exists bool) {
+	v := m.avatar_url
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAvatarURL returns the old "avatar_url" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldAvatarURL(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAvatarURL is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAvatarURL requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAvatarURL: %w", err)
+	}
+	return oldValue.AvatarURL, nil
+}
+
+// ClearAvatarURL clears the value of the "avatar_url" field.
+func (m *UserMutation) ClearAvatarURL() {
+	m.avatar_url = nil
+	m.clearedFields[user.FieldAvatarURL] = struct{}{}
+}
+
+// AvatarURLCleared returns if the "avatar_url" field was cleared in this mutation.
+func (m *UserMutation) AvatarURLCleared() bool {
+	_, ok := m.clearedFields[user.FieldAvatarURL]
+	return ok
+}
+
+// ResetAvatarURL resets all changes to the "avatar_url" field.
+func (m *UserMutation) ResetAvatarURL() {
+	m.avatar_url = nil
+	delete(m.clearedFields, user.FieldAvatarURL)
+}
+
+// SetRole sets the "role" field.
+func (m *UserMutation) SetRole(u user.Role) {
+	m.role = &u
+}
+
+// Role returns the value of the "role" field in the mutation.
+func (m *UserMutation) Role() (r user.Role, exists bool) {
+	v := m.role
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRole returns the old "role" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldRole(ctx context.Context) (v user.Role, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRole is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRole requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRole: %w", err)
+	}
+	return oldValue.Role, nil
+}
+
+// ResetRole resets all changes to the "role" field.
+func (m *UserMutation) ResetRole() {
+	m.role = nil
+}
+
+// SetTokenVersion sets the "token_version" field.
+func (m *UserMutation) SetTokenVersion(i int) {
+	m.token_version = &i
+	m.addtoken_version = nil
+}
+
+// TokenVersion returns the value of the "token_version" field in the mutation.
+func (m *UserMutation) TokenVersion() (r int, exists bool) {
+	v := m.token_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldTokenVersion returns the old "token_version" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldTokenVersion(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldTokenVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldTokenVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldTokenVersion: %w", err)
+	}
+	return oldValue.TokenVersion, nil
+}
+
+// AddTokenVersion adds i to the "token_version" field.
+func (m *UserMutation) AddTokenVersion(i int) {
+	if m.addtoken_version != nil {
+		*m.addtoken_version += i
+	} else {
+		m.addtoken_version = &i
+	}
+}
+
+// AddedTokenVersion returns the value that was added to the "token_version" field in this mutation.
+func (m *UserMutation) AddedTokenVersion() (r int, exists bool) {
+	v := m.addtoken_version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetTokenVersion resets all changes to the "token_version" field.
+func (m *UserMutation) ResetTokenVersion() {
+	m.token_version = nil
+	m.addtoken_version = nil
+}
+
+// SetDisabledAt sets the "disabled_at" field.
+func (m *UserMutation) SetDisabledAt(t time.Time) {
+	m.disabled_at = &t
+}
+
+// DisabledAt returns the value of the "disabled_at" field in the mutation.
+func (m *UserMutation) DisabledAt() (r time.Time, exists bool) {
+	v := m.disabled_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDisabledAt returns the old "disabled_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldDisabledAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDisabledAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDisabledAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDisabledAt: %w", err)
+	}
+	return oldValue.DisabledAt, nil
+}
+
+// ClearDisabledAt clears the value of the "disabled_at" field.
+func (m *UserMutation) ClearDisabledAt() {
+	m.disabled_at = nil
+	m.clearedFields[user.FieldDisabledAt] = struct{}{}
+}
+
+// DisabledAtCleared returns if the "disabled_at" field was cleared in this mutation.
+func (m *UserMutation) DisabledAtCleared() bool {
+	_, ok := m.clearedFields[user.FieldDisabledAt]
+	return ok
+}
+
+// ResetDisabledAt resets all changes to the "disabled_at" field.
+func (m *UserMutation) ResetDisabledAt() {
+	m.disabled_at = nil
+	delete(m.clearedFields, user.FieldDisabledAt)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *UserMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *UserMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *UserMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *UserMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the User entity.
+// If the User object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *UserMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *UserMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// AddPollIDs adds the "polls" edge to the Poll entity by ids.
+func (m *UserMutation) AddPollIDs(ids ...int) {
+	if m.polls == nil {
+		m.polls = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.polls[ids[i]] = struct{}{}
+	}
+}
+
+// ClearPolls clears the "polls" edge to the Poll entity.
+func (m *UserMutation) ClearPolls() {
+	m.clearedpolls = true
+}
+
+// PollsCleared reports if the "polls" edge to the Poll entity was cleared.
+func (m *UserMutation) PollsCleared() bool {
+	return m.clearedpolls
+}
+
+// RemovePollIDs removes the "polls" edge to the Poll entity by IDs.
+func (m *UserMutation) RemovePollIDs(ids ...int) {
+	if m.removedpolls == nil {
+		m.removedpolls = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.polls, ids[i])
+		m.removedpolls[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedPolls returns the removed IDs of the "polls" edge to the Poll entity.
+func (m *UserMutation) RemovedPollsIDs() (ids []int) {
+	for id := range m.removedpolls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// PollsIDs returns the "polls" edge IDs in the mutation.
+func (m *UserMutation) PollsIDs() (ids []int) {
+	for id := range m.polls {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetPolls resets all changes to the "polls" edge.
+func (m *UserMutation) ResetPolls() {
+	m.polls = nil
+	m.clearedpolls = false
+	m.removedpolls = nil
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
+func (m *UserMutation) AddVoteIDs(ids ...int) {
+	if m.votes == nil {
+		m.votes = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.votes[ids[i]] = struct{}{}
+	}
+}
+
+// ClearVotes clears the "votes" edge to the Vote entity.
+func (m *UserMutation) ClearVotes() {
+	m.clearedvotes = true
+}
+
+// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
+func (m *UserMutation) VotesCleared() bool {
+	return m.clearedvotes
+}
+
+// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
+func (m *UserMutation) RemoveVoteIDs(ids ...int) {
+	if m.removedvotes == nil {
+		m.removedvotes = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.votes, ids[i])
+		m.removedvotes[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
+func (m *UserMutation) RemovedVotesIDs() (ids []int) {
+	for id := range m.removedvotes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// VotesIDs returns the "votes" edge IDs in the mutation.
+func (m *UserMutation) VotesIDs() (ids []int) {
+	for id := range m.votes {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetVotes resets all changes to the "votes" edge.
+func (m *UserMutation) ResetVotes() {
+	m.votes = nil
+	m.clearedvotes = false
+	m.removedvotes = nil
+}
+
+// AddWebhookIDs adds the "webhooks" edge to the Webhook entity by ids.
+func (m *UserMutation) AddWebhookIDs(ids ...int) {
+	if m.webhooks == nil {
+		m.webhooks = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.webhooks[ids[i]] = struct{}{}
+	}
+}
+
+// ClearWebhooks clears the "webhooks" edge to the Webhook entity.
+func (m *UserMutation) ClearWebhooks() {
+	m.clearedwebhooks = true
+}
+
+// WebhooksCleared reports if the "webhooks" edge to the Webhook entity was cleared.
+func (m *UserMutation) WebhooksCleared() bool {
+	return m.clearedwebhooks
+}
+
+// RemoveWebhookIDs removes the "webhooks" edge to the Webhook entity by IDs.
+func (m *UserMutation) RemoveWebhookIDs(ids ...int) {
+	if m.removedwebhooks == nil {
+		m.removedwebhooks = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.webhooks, ids[i])
+		m.removedwebhooks[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedWebhooks returns the removed IDs of the "webhooks" edge to the Webhook entity.
+func (m *UserMutation) RemovedWebhooksIDs() (ids []int) {
+	for id := range m.removedwebhooks {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// WebhooksIDs returns the "webhooks" edge IDs in the mutation.
+func (m *UserMutation) WebhooksIDs() (ids []int) {
+	for id := range m.webhooks {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetWebhooks resets all changes to the "webhooks" edge.
+func (m *UserMutation) ResetWebhooks() {
+	m.webhooks = nil
+	m.clearedwebhooks = false
+	m.removedwebhooks = nil
+}
+
+// AddAuditLogIDs adds the "audit_logs" edge to the AuditLog entity by ids.
+func (m *UserMutation) AddAuditLogIDs(ids ...int) {
+	if m.audit_logs == nil {
+		m.audit_logs = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.audit_logs[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAuditLogs clears the "audit_logs" edge to the AuditLog entity.
+func (m *UserMutation) ClearAuditLogs() {
+	m.clearedaudit_logs = true
+}
+
+// AuditLogsCleared reports if the "audit_logs" edge to the AuditLog entity was cleared.
+func (m *UserMutation) AuditLogsCleared() bool {
+	return m.clearedaudit_logs
+}
+
+// RemoveAuditLogIDs removes the "audit_logs" edge to the AuditLog entity by IDs.
+func (m *UserMutation) RemoveAuditLogIDs(ids ...int) {
+	if m.removedaudit_logs == nil {
+		m.removedaudit_logs = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.audit_logs, ids[i])
+		m.removedaudit_logs[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAuditLogs returns the removed IDs of the "audit_logs" edge to the AuditLog entity.
+func (m *UserMutation) RemovedAuditLogsIDs() (ids []int) {
+	for id := range m.removedaudit_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// AuditLogsIDs returns the "audit_logs" edge IDs in the mutation.
+func (m *UserMutation) AuditLogsIDs() (ids []int) {
+	for id := range m.audit_logs {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAuditLogs resets all changes to the "audit_logs" edge.
+func (m *UserMutation) ResetAuditLogs() {
+	m.audit_logs = nil
+	m.clearedaudit_logs = false
+	m.removedaudit_logs = nil
+}
+
+// Where appends a list predicates to the UserMutation builder.
+func (m *UserMutation) Where(ps ...predicate.User) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.User, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *UserMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *UserMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (User).
+func (m *UserMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *UserMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.email != nil {
+		fields = append(fields, user.FieldEmail)
+	}
+	if m.password != nil {
+		fields = append(fields, user.FieldPassword)
+	}
+	if m.display_name != nil {
+		fields = append(fields, user.FieldDisplayName)
+	}
+	if m.avatar_url != nil {
+		fields = append(fields, user.FieldAvatarURL)
+	}
+	if m.role != nil {
+		fields = append(fields, user.FieldRole)
+	}
+	if m.token_version != nil {
+		fields = append(fields, user.FieldTokenVersion)
+	}
+	if m.disabled_at != nil {
+		fields = append(fields, user.FieldDisabledAt)
+	}
+	if m.created_at != nil {
+		fields = append(fields, user.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, user.FieldUpdatedAt)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *UserMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldEmail:
+		return m.Email()
+	case user.FieldPassword:
+		return m.Password()
+	case user.FieldDisplayName:
+		return m.DisplayName()
+	case user.FieldAvatarURL:
+		return m.AvatarURL()
+	case user.FieldRole:
+		return m.Role()
+	case user.FieldTokenVersion:
+		return m.TokenVersion()
+	case user.FieldDisabledAt:
+		return m.DisabledAt()
+	case user.FieldCreatedAt:
+		return m.CreatedAt()
+	case user.FieldUpdatedAt:
+		return m.UpdatedAt()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case user.FieldEmail:
+		return m.OldEmail(ctx)
+	case user.FieldPassword:
+		return m.OldPassword(ctx)
+	case user.FieldDisplayName:
+		return m.OldDisplayName(ctx)
+	case user.FieldAvatarURL:
+		return m.OldAvatarURL(ctx)
+	case user.FieldRole:
+		return m.OldRole(ctx)
+	case user.FieldTokenVersion:
+		return m.OldTokenVersion(ctx)
+	case user.FieldDisabledAt:
+		return m.OldDisabledAt(ctx)
+	case user.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case user.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown User field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldEmail:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEmail(v)
+		return nil
+	case user.FieldPassword:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPassword(v)
+		return nil
+	case user.FieldDisplayName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisplayName(v)
+		return nil
+	case user.FieldAvatarURL:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAvatarURL(v)
+		return nil
+	case user.FieldRole:
+		v, ok := value.(user.Role)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetRole(v)
+		return nil
+	case user.FieldTokenVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetTokenVersion(v)
+		return nil
+	case user.FieldDisabledAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDisabledAt(v)
+		return nil
+	case user.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case user.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *UserMutation) AddedFields() []string {
+	var fields []string
+	if m.addtoken_version != nil {
+		fields = append(fields, user.FieldTokenVersion)
+	}
+	return fields
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case user.FieldTokenVersion:
+		return m.AddedTokenVersion()
+	}
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *UserMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	case user.FieldTokenVersion:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddTokenVersion(v)
+		return nil
+	}
+	return fmt.Errorf("unknown User numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *UserMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(user.FieldDisplayName) {
+		fields = append(fields, user.FieldDisplayName)
+	}
+	if m.FieldCleared(user.FieldAvatarURL) {
+		fields = append(fields, user.FieldAvatarURL)
+	}
+	if m.FieldCleared(user.FieldDisabledAt) {
+		fields = append(fields, user.FieldDisabledAt)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *UserMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *UserMutation) ClearField(name string) error {
+	switch name {
+	case user.FieldDisplayName:
+		m.ClearDisplayName()
+		return nil
+	case user.FieldAvatarURL:
+		m.ClearAvatarURL()
+		return nil
+	case user.FieldDisabledAt:
+		m.ClearDisabledAt()
+		return nil
+	}
+	return fmt.Errorf("unknown User nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *UserMutation) ResetField(name string) error {
+	switch name {
+	case user.FieldEmail:
+		m.ResetEmail()
+		return nil
+	case user.FieldPassword:
+		m.ResetPassword()
+		return nil
+	case user.FieldDisplayName:
+		m.ResetDisplayName()
+		return nil
+	case user.FieldAvatarURL:
+		m.ResetAvatarURL()
+		return nil
+	case user.FieldRole:
+		m.ResetRole()
+		return nil
+	case user.FieldTokenVersion:
+		m.ResetTokenVersion()
+		return nil
+	case user.FieldDisabledAt:
+		m.ResetDisabledAt()
+		return nil
+	case user.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case user.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	}
+	return fmt.Errorf("unknown User field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *UserMutation) AddedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.polls != nil {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.votes != nil {
+		edges = append(edges, user.EdgeVotes)
+	}
+	if m.webhooks != nil {
+		edges = append(edges, user.EdgeWebhooks)
+	}
+	if m.audit_logs != nil {
+		edges = append(edges, user.EdgeAuditLogs)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *UserMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.polls))
+		for id := range m.polls {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.votes))
+		for id := range m.votes {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeWebhooks:
+		ids := make([]ent.Value, 0, len(m.webhooks))
+		for id := range m.webhooks {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAuditLogs:
+		ids := make([]ent.Value, 0, len(m.audit_logs))
+		for id := range m.audit_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *UserMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.removedpolls != nil {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.removedvotes != nil {
+		edges = append(edges, user.EdgeVotes)
+	}
+	if m.removedwebhooks != nil {
+		edges = append(edges, user.EdgeWebhooks)
+	}
+	if m.removedaudit_logs != nil {
+		edges = append(edges, user.EdgeAuditLogs)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case user.EdgePolls:
+		ids := make([]ent.Value, 0, len(m.removedpolls))
+		for id := range m.removedpolls {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeVotes:
+		ids := make([]ent.Value, 0, len(m.removedvotes))
+		for id := range m.removedvotes {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeWebhooks:
+		ids := make([]ent.Value, 0, len(m.removedwebhooks))
+		for id := range m.removedwebhooks {
+			ids = append(ids, id)
+		}
+		return ids
+	case user.EdgeAuditLogs:
+		ids := make([]ent.Value, 0, len(m.removedaudit_logs))
+		for id := range m.removedaudit_logs {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *UserMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 4)
+	if m.clearedpolls {
+		edges = append(edges, user.EdgePolls)
+	}
+	if m.clearedvotes {
+		edges = append(edges, user.EdgeVotes)
+	}
+	if m.clearedwebhooks {
+		edges = append(edges, user.EdgeWebhooks)
+	}
+	if m.clearedaudit_logs {
+		edges = append(edges, user.EdgeAuditLogs)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *UserMutation) EdgeCleared(name string) bool {
+	switch name {
+	case user.EdgePolls:
+		return m.clearedpolls
+	case user.EdgeVotes:
+		return m.clearedvotes
+	case user.EdgeWebhooks:
+		return m.clearedwebhooks
+	case user.EdgeAuditLogs:
+		return m.clearedaudit_logs
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *UserMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown User unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *UserMutation) ResetEdge(name string) error {
+	switch name {
+	case user.EdgePolls:
+		m.ResetPolls()
+		return nil
+	case user.EdgeVotes:
+		m.ResetVotes()
+		return nil
+	case user.EdgeWebhooks:
+		m.ResetWebhooks()
+		return nil
+	case user.EdgeAuditLogs:
+		m.ResetAuditLogs()
+		return nil
+	}
+	return fmt.Errorf("unknown User edge %s", name)
+}
+
+// VoteMutation represents an operation that mutates the Vote nodes in the graph.
+type VoteMutation struct {
+	config
+	op               Op
+	typ              string
+	id               *int
+	voter_identifier *string
+	rank             *int
+	addrank          *int
+	score            *int
+	addscore         *int
+	created_at       *time.Time
+	clearedFields    map[string]struct{}
+	poll             *int
+	clearedpoll      bool
+	option           *int
+	clearedoption    bool
+	voter            *int
+	clearedvoter     bool
+	done             bool
+	oldValue         func(context.Context) (*Vote, error)
+	predicates       []predicate.Vote
+}
+
+var _ ent.Mutation = (*VoteMutation)(nil)
+
+// voteOption allows management of the mutation configuration using functional options.
+type voteOption func(*VoteMutation)
+
+// newVoteMutation creates new mutation for the Vote entity.
+func newVoteMutation(c config, op Op, opts ...voteOption) *VoteMutation {
+	m := &VoteMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeVote,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withVoteID sets the ID field of the mutation.
+func withVoteID(id int) voteOption {
+	return func(m *VoteMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Vote
+		)
+		m.oldValue = func(ctx context.Context) (*Vote, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Vote.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withVote sets the old Vote of the mutation.
+func withVote(node *Vote) voteOption {
+	return func(m *VoteMutation) {
+		m.oldValue = func(context.Context) (*Vote, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m VoteMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m VoteMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *VoteMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *VoteMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Vote.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetVoterIdentifier sets the "voter_identifier" field.
+func (m *VoteMutation) SetVoterIdentifier(s string) {
+	m.voter_identifier = &s
+}
+
+// VoterIdentifier returns the value of the "voter_identifier" field in the mutation.
+func (m *VoteMutation) VoterIdentifier() (r string, exists bool) {
+	v := m.voter_identifier
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVoterIdentifier returns the old "voter_identifier" field's value of the Vote entity.
+// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VoteMutation) OldVoterIdentifier(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVoterIdentifier is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVoterIdentifier requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVoterIdentifier: %w", err)
+	}
+	return oldValue.VoterIdentifier, nil
+}
+
+// ClearVoterIdentifier clears the value of the "voter_identifier" field.
+func (m *VoteMutation) ClearVoterIdentifier() {
+	m.voter_identifier = nil
+	m.clearedFields[vote.FieldVoterIdentifier] = struct{}{}
+}
+
+// VoterIdentifierCleared returns if the "voter_identifier" field was cleared in this mutation.
+func (m *VoteMutation) VoterIdentifierCleared() bool {
+	_, ok := m.clearedFields[vote.FieldVoterIdentifier]
+	return ok
+}
+
+// ResetVoterIdentifier resets all changes to the "voter_identifier" field.
+func (m *VoteMutation) ResetVoterIdentifier() {
+	m.voter_identifier = nil
+	delete(m.clearedFields, vote.FieldVoterIdentifier)
+}
+
+// SetRank sets the "rank" field.
+func (m *VoteMutation) SetRank(i int) {
+	m.rank = &i
+	m.addrank = nil
+}
+
+// Rank returns the value of the "rank" field in the mutation.
+func (m *VoteMutation) Rank() (r int, exists bool) {
+	v := m.rank
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldRank returns the old "rank" field's value of the Vote entity.
+// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VoteMutation) OldRank(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldRank is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldRank requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldRank: %w", err)
+	}
+	return oldValue.Rank, nil
+}
+
+// AddRank adds i to the "rank" field.
+func (m *VoteMutation) AddRank(i int) {
+	if m.addrank != nil {
+		*m.addrank += i
+	} else {
+		m.addrank = &i
+	}
+}
+
+// AddedRank returns the value that was added to the "rank" field in this mutation.
+func (m *VoteMutation) AddedRank() (r int, exists bool) {
+	v := m.addrank
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearRank clears the value of the "rank" field.
+func (m *VoteMutation) ClearRank() {
+	m.rank = nil
+	m.addrank = nil
+	m.clearedFields[vote.FieldRank] = struct{}{}
+}
+
+// RankCleared returns if the "rank" field was cleared in this mutation.
+func (m *VoteMutation) RankCleared() bool {
+	_, ok := m.clearedFields[vote.FieldRank]
+	return ok
+}
+
+// ResetRank resets all changes to the "rank" field.
+func (m *VoteMutation) ResetRank() {
+	m.rank = nil
+	m.addrank = nil
+	delete(m.clearedFields, vote.FieldRank)
+}
+
+// SetScore sets the "score" field.
+func (m *VoteMutation) SetScore(i int) {
+	m.score = &i
+	m.addscore = nil
+}
+
+// Score returns the value of the "score" field in the mutation.
+func (m *VoteMutation) Score() (r int, exists bool) {
+	v := m.score
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldScore returns the old "score" field's value of the Vote entity.
+// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VoteMutation) OldScore(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScore is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScore requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScore: %w", err)
+	}
+	return oldValue.Score, nil
+}
+
+// AddScore adds i to the "score" field.
+func (m *VoteMutation) AddScore(i int) {
+	if m.addscore != nil {
+		*m.addscore += i
+	} else {
+		m.addscore = &i
+	}
+}
+
+// AddedScore returns the value that was added to the "score" field in this mutation.
+func (m *VoteMutation) AddedScore() (r int, exists bool) {
+	v := m.addscore
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearScore clears the value of the "score" field.
+func (m *VoteMutation) ClearScore() {
+	m.score = nil
+	m.addscore = nil
+	m.clearedFields[vote.FieldScore] = struct{}{}
+}
+
+// ScoreCleared returns if the "score" field was cleared in this mutation.
+func (m *VoteMutation) ScoreCleared() bool {
+	_, ok := m.clearedFields[vote.FieldScore]
+	return ok
+}
+
+// ResetScore resets all changes to the "score" field.
+func (m *VoteMutation) ResetScore() {
+	m.score = nil
+	m.addscore = nil
+	delete(m.clearedFields, vote.FieldScore)
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *VoteMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *VoteMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldCreatedAt returns the old "created_at" field's value of the Vote entity.
+// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *VoteMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *VoteMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
 // SetPollID sets the "poll" edge to the Poll entity by id.
-func (m *PollOptionMutation) SetPollID(id int) {
+func (m *VoteMutation) SetPollID(id int) {
 	m.poll = &id
 }
 
 // ClearPoll clears the "poll" edge to the Poll entity.
-func (m *PollOptionMutation) ClearPoll() {
+func (m *VoteMutation) ClearPoll() {
 	m.clearedpoll = true
 }
 
 // PollCleared reports if the "poll" edge to the Poll entity was cleared.
-func (m *PollOptionMutation) PollCleared() bool {
+func (m *VoteMutation) PollCleared() bool {
 	return m.clearedpoll
 }
 
 // PollID returns the "poll" edge ID in the mutation.
-func (m *PollOptionMutation) PollID() (id int, exists bool) {
+func (m *VoteMutation) PollID() (id int, exists bool) {
 	if m.poll != nil {
 		return *m.poll, true
 	}
@@ -1282,7 +5873,7 @@ func (m *PollOptionMutation) PollID() (id int, exists bool) {
 // PollIDs returns the "poll" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
 // PollID instead. It exists only for internal usage by the builders.
-func (m *PollOptionMutation) PollIDs() (ids []int) {
+func (m *VoteMutation) PollIDs() (ids []int) {
 	if id := m.poll; id != nil {
 		ids = append(ids, *id)
 	}
@@ -1290,74 +5881,98 @@ func (m *PollOptionMutation) PollIDs() (ids []int) {
 }
 
 // ResetPoll resets all changes to the "poll" edge.
-func (m *PollOptionMutation) ResetPoll() {
+func (m *VoteMutation) ResetPoll() {
 	m.poll = nil
 	m.clearedpoll = false
 }
 
-// AddVoteIDs adds the "votes" edge to the Vote entity by ids.
-func (m *PollOptionMutation) AddVoteIDs(ids ...int) {
-	if m.votes == nil {
-		m.votes = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.votes[ids[i]] = struct{}{}
-	}
+// SetOptionID sets the "option" edge to the PollOption entity by id.
+func (m *VoteMutation) SetOptionID(id int) {
+	m.option = &id
+}
+
+// ClearOption clears the "option" edge to the PollOption entity.
+func (m *VoteMutation) ClearOption() {
+	m.clearedoption = true
+}
+
+// OptionCleared reports if the "option" edge to the PollOption entity was cleared.
+func (m *VoteMutation) OptionCleared() bool {
+	return m.clearedoption
+}
+
+// OptionID returns the "option" edge ID in the mutation.
+func (m *VoteMutation) OptionID() (id int, exists bool) {
+	if m.option != nil {
+		return *m.option, true
+	}
+	return
+}
+
+// OptionIDs returns the "option" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// OptionID instead. It exists only for internal usage by the builders.
+func (m *VoteMutation) OptionIDs() (ids []int) {
+	if id := m.option; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetOption resets all changes to the "option" edge.
+func (m *VoteMutation) ResetOption() {
+	m.option = nil
+	m.clearedoption = false
 }
 
-// ClearVotes clears the "votes" edge to the Vote entity.
-func (m *PollOptionMutation) ClearVotes() {
-	m.clearedvotes = true
+// SetVoterID sets the "voter" edge to the User entity by id.
+func (m *VoteMutation) SetVoterID(id int) {
+	m.voter = &id
 }
 
-// VotesCleared reports if the "votes" edge to the Vote entity was cleared.
-func (m *PollOptionMutation) VotesCleared() bool {
-	return m.clearedvotes
+// ClearVoter clears the "voter" edge to the User entity.
+func (m *VoteMutation) ClearVoter() {
+	m.clearedvoter = true
 }
 
-// RemoveVoteIDs removes the "votes" edge to the Vote entity by IDs.
-func (m *PollOptionMutation) RemoveVoteIDs(ids ...int) {
-	if m.removedvotes == nil {
-		m.removedvotes = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.votes, ids[i])
-		m.removedvotes[ids[i]] = struct{}{}
-	}
+// VoterCleared reports if the "voter" edge to the User entity was cleared.
+func (m *VoteMutation) VoterCleared() bool {
+	return m.clearedvoter
 }
 
-// RemovedVotes returns the removed IDs of the "votes" edge to the Vote entity.
-func (m *PollOptionMutation) RemovedVotesIDs() (ids []int) {
-	for id := range m.removedvotes {
-		ids = append(ids, id)
+// VoterID returns the "voter" edge ID in the mutation.
+func (m *VoteMutation) VoterID() (id int, exists bool) {
+	if m.voter != nil {
+		return *m.voter, true
 	}
 	return
 }
 
-// VotesIDs returns the "votes" edge IDs in the mutation.
-func (m *PollOptionMutation) VotesIDs() (ids []int) {
-	for id := range m.votes {
-		ids = append(ids, id)
+// VoterIDs returns the "voter" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// VoterID instead. It exists only for internal usage by the builders.
+func (m *VoteMutation) VoterIDs() (ids []int) {
+	if id := m.voter; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetVotes resets all changes to the "votes" edge.
-func (m *PollOptionMutation) ResetVotes() {
-	m.votes = nil
-	m.clearedvotes = false
-	m.removedvotes = nil
+// ResetVoter resets all changes to the "voter" edge.
+func (m *VoteMutation) ResetVoter() {
+	m.voter = nil
+	m.clearedvoter = false
 }
 
-// Where appends a list predicates to the PollOptionMutation builder.
-func (m *PollOptionMutation) Where(ps ...predicate.PollOption) {
+// Where appends a list predicates to the VoteMutation builder.
+func (m *VoteMutation) Where(ps ...predicate.Vote) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the PollOptionMutation builder. Using this method,
+// WhereP appends storage-level predicates to the VoteMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.PollOption, len(ps))
+func (m *VoteMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Vote, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1365,33 +5980,36 @@ func (m *PollOptionMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *PollOptionMutation) Op() Op {
+func (m *VoteMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *PollOptionMutation) SetOp(op Op) {
+func (m *VoteMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (PollOption).
-func (m *PollOptionMutation) Type() string {
+// Type returns the node type of this mutation (Vote).
+func (m *VoteMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *PollOptionMutation) Fields() []string {
-	fields := make([]string, 0, 3)
-	if m.option_text != nil {
-		fields = append(fields, polloption.FieldOptionText)
+func (m *VoteMutation) Fields() []string {
+	fields := make([]string, 0, 4)
+	if m.voter_identifier != nil {
+		fields = append(fields, vote.FieldVoterIdentifier)
 	}
-	if m.vote_count != nil {
-		fields = append(fields, polloption.FieldVoteCount)
+	if m.rank != nil {
+		fields = append(fields, vote.FieldRank)
+	}
+	if m.score != nil {
+		fields = append(fields, vote.FieldScore)
 	}
 	if m.created_at != nil {
-		fields = append(fields, polloption.FieldCreatedAt)
+		fields = append(fields, vote.FieldCreatedAt)
 	}
 	return fields
 }
@@ -1399,13 +6017,15 @@ func (m *PollOptionMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
+func (m *VoteMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case polloption.FieldOptionText:
-		return m.OptionText()
-	case polloption.FieldVoteCount:
-		return m.VoteCount()
-	case polloption.FieldCreatedAt:
+	case vote.FieldVoterIdentifier:
+		return m.VoterIdentifier()
+	case vote.FieldRank:
+		return m.Rank()
+	case vote.FieldScore:
+		return m.Score()
+	case vote.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -1414,38 +6034,47 @@ func (m *PollOptionMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *PollOptionMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *VoteMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case polloption.FieldOptionText:
-		return m.OldOptionText(ctx)
-	case polloption.FieldVoteCount:
-		return m.OldVoteCount(ctx)
-	case polloption.FieldCreatedAt:
+	case vote.FieldVoterIdentifier:
+		return m.OldVoterIdentifier(ctx)
+	case vote.FieldRank:
+		return m.OldRank(ctx)
+	case vote.FieldScore:
+		return m.OldScore(ctx)
+	case vote.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown PollOption field %s", name)
+	return nil, fmt.Errorf("unknown Vote field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollOptionMutation) SetField(name string, value ent.Value) error {
+func (m *VoteMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case polloption.FieldOptionText:
+	case vote.FieldVoterIdentifier:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOptionText(v)
+		m.SetVoterIdentifier(v)
 		return nil
-	case polloption.FieldVoteCount:
+	case vote.FieldRank:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetVoteCount(v)
+		m.SetRank(v)
 		return nil
-	case polloption.FieldCreatedAt:
+	case vote.FieldScore:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScore(v)
+		return nil
+	case vote.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -1453,15 +6082,18 @@ func (m *PollOptionMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption field %s", name)
+	return fmt.Errorf("unknown Vote field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *PollOptionMutation) AddedFields() []string {
+func (m *VoteMutation) AddedFields() []string {
 	var fields []string
-	if m.addvote_count != nil {
-		fields = append(fields, polloption.FieldVoteCount)
+	if m.addrank != nil {
+		fields = append(fields, vote.FieldRank)
+	}
+	if m.addscore != nil {
+		fields = append(fields, vote.FieldScore)
 	}
 	return fields
 }
@@ -1469,10 +6101,12 @@ func (m *PollOptionMutation) AddedFields() []string {
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *PollOptionMutation) AddedField(name string) (ent.Value, bool) {
+func (m *VoteMutation) AddedField(name string) (ent.Value, bool) {
 	switch name {
-	case polloption.FieldVoteCount:
-		return m.AddedVoteCount()
+	case vote.FieldRank:
+		return m.AddedRank()
+	case vote.FieldScore:
+		return m.AddedScore()
 	}
 	return nil, false
 }
@@ -1480,183 +6114,231 @@ func (m *PollOptionMutation) AddedField(name string) (ent.Value, bool) {
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *PollOptionMutation) AddField(name string, value ent.Value) error {
+func (m *VoteMutation) AddField(name string, value ent.Value) error {
 	switch name {
-	case polloption.FieldVoteCount:
+	case vote.FieldRank:
 		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.AddVoteCount(v)
+		m.AddRank(v)
+		return nil
+	case vote.FieldScore:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddScore(v)
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption numeric field %s", name)
+	return fmt.Errorf("unknown Vote numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *PollOptionMutation) ClearedFields() []string {
-	return nil
+func (m *VoteMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(vote.FieldVoterIdentifier) {
+		fields = append(fields, vote.FieldVoterIdentifier)
+	}
+	if m.FieldCleared(vote.FieldRank) {
+		fields = append(fields, vote.FieldRank)
+	}
+	if m.FieldCleared(vote.FieldScore) {
+		fields = append(fields, vote.FieldScore)
+	}
+	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *PollOptionMutation) FieldCleared(name string) bool {
+func (m *VoteMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *PollOptionMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown PollOption nullable field %s", name)
+func (m *VoteMutation) ClearField(name string) error {
+	switch name {
+	case vote.FieldVoterIdentifier:
+		m.ClearVoterIdentifier()
+		return nil
+	case vote.FieldRank:
+		m.ClearRank()
+		return nil
+	case vote.FieldScore:
+		m.ClearScore()
+		return nil
+	}
+	return fmt.Errorf("unknown Vote nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *PollOptionMutation) ResetField(name string) error {
+func (m *VoteMutation) ResetField(name string) error {
 	switch name {
-	case polloption.FieldOptionText:
-		m.ResetOptionText()
+	case vote.FieldVoterIdentifier:
+		m.ResetVoterIdentifier()
 		return nil
-	case polloption.FieldVoteCount:
-		m.ResetVoteCount()
+	case vote.FieldRank:
+		m.ResetRank()
 		return nil
-	case polloption.FieldCreatedAt:
+	case vote.FieldScore:
+		m.ResetScore()
+		return nil
+	case vote.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown PollOption field %s", name)
+	return fmt.Errorf("unknown Vote field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *PollOptionMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *VoteMutation) AddedEdges() []string {
+	edges := make([]string, 0, 3)
 	if m.poll != nil {
-		edges = append(edges, polloption.EdgePoll)
+		edges = append(edges, vote.EdgePoll)
 	}
-	if m.votes != nil {
-		edges = append(edges, polloption.EdgeVotes)
+	if m.option != nil {
+		edges = append(edges, vote.EdgeOption)
+	}
+	if m.voter != nil {
+		edges = append(edges, vote.EdgeVoter)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *PollOptionMutation) AddedIDs(name string) []ent.Value {
+func (m *VoteMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case polloption.EdgePoll:
+	case vote.EdgePoll:
 		if id := m.poll; id != nil {
 			return []ent.Value{*id}
 		}
-	case polloption.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.votes))
-		for id := range m.votes {
-			ids = append(ids, id)
+	case vote.EdgeOption:
+		if id := m.option; id != nil {
+			return []ent.Value{*id}
+		}
+	case vote.EdgeVoter:
+		if id := m.voter; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *PollOptionMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.removedvotes != nil {
-		edges = append(edges, polloption.EdgeVotes)
-	}
+func (m *VoteMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 3)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *PollOptionMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case polloption.EdgeVotes:
-		ids := make([]ent.Value, 0, len(m.removedvotes))
-		for id := range m.removedvotes {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *VoteMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *PollOptionMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *VoteMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 3)
 	if m.clearedpoll {
-		edges = append(edges, polloption.EdgePoll)
+		edges = append(edges, vote.EdgePoll)
 	}
-	if m.clearedvotes {
-		edges = append(edges, polloption.EdgeVotes)
+	if m.clearedoption {
+		edges = append(edges, vote.EdgeOption)
+	}
+	if m.clearedvoter {
+		edges = append(edges, vote.EdgeVoter)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *PollOptionMutation) EdgeCleared(name string) bool {
+func (m *VoteMutation) EdgeCleared(name string) bool {
 	switch name {
-	case polloption.EdgePoll:
+	case vote.EdgePoll:
 		return m.clearedpoll
-	case polloption.EdgeVotes:
-		return m.clearedvotes
+	case vote.EdgeOption:
+		return m.clearedoption
+	case vote.EdgeVoter:
+		return m.clearedvoter
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *PollOptionMutation) ClearEdge(name string) error {
+func (m *VoteMutation) ClearEdge(name string) error {
 	switch name {
-	case polloption.EdgePoll:
+	case vote.EdgePoll:
 		m.ClearPoll()
 		return nil
+	case vote.EdgeOption:
+		m.ClearOption()
+		return nil
+	case vote.EdgeVoter:
+		m.ClearVoter()
+		return nil
 	}
-	return fmt.Errorf("unknown PollOption unique edge %s", name)
+	return fmt.Errorf("unknown Vote unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *PollOptionMutation) ResetEdge(name string) error {
+func (m *VoteMutation) ResetEdge(name string) error {
 	switch name {
-	case polloption.EdgePoll:
+	case vote.EdgePoll:
 		m.ResetPoll()
-		return nil
-	case polloption.EdgeVotes:
-		m.ResetVotes()
-		return nil
-	}
-	return fmt.Errorf("unknown PollOption edge %s", name)
-}
-
-// UserMutation represents an operation that mutates the User nodes in the graph.
-type UserMutation struct {
-	config
-	op            Op
-	typ           string
-	id            *int
-	email         *string
-	password      *string
-	created_at    *time.Time
-	clearedFields map[string]struct{}
-	done          bool
-	oldValue      func(context.Context) (*User, error)
-	predicates    []predicate.User
+		return nil
+	case vote.EdgeOption:
+		m.ResetOption()
+		return nil
+	case vote.EdgeVoter:
+		m.ResetVoter()
+		return nil
+	}
+	return fmt.Errorf("unknown Vote edge %s", name)
 }
 
-var _ ent.Mutation = (*UserMutation)(nil)
-
-// userOption allows management of the mutation configuration using functional options.
-type userOption func(*UserMutation)
-
-// newUserMutation creates new mutation for the User entity.
-func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
-	m := &UserMutation{
+// WebhookMutation represents an operation that mutates the Webhook nodes in the graph.
+type WebhookMutation struct {
+	config
+	op                Op
+	typ               string
+	id                *int
+	url               *string
+	secret            *string
+	events            *[]string
+	appendevents      []string
+	active            *bool
+	created_at        *time.Time
+	updated_at        *time.Time
+	clearedFields     map[string]struct{}
+	owner             *int
+	clearedowner      bool
+	deliveries        map[int]struct{}
+	removeddeliveries map[int]struct{}
+	cleareddeliveries bool
+	done              bool
+	oldValue          func(context.Context) (*Webhook, error)
+	predicates        []predicate.Webhook
+}
+
+var _ ent.Mutation = (*WebhookMutation)(nil)
+
+// webhookOption allows management of the mutation configuration using functional options.
+type webhookOption func(*WebhookMutation)
+
+// newWebhookMutation creates new mutation for the Webhook entity.
+func newWebhookMutation(c config, op Op, opts ...webhookOption) *WebhookMutation {
+	m := &WebhookMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeUser,
+		typ:           TypeWebhook,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -1665,20 +6347,20 @@ func newUserMutation(c config, op Op, opts ...userOption) *UserMutation {
 	return m
 }
 
-// withUserID sets the ID field of the mutation.
-func withUserID(id int) userOption {
-	return func(m *UserMutation) {
+// withWebhookID sets the ID field of the mutation.
+func withWebhookID(id int) webhookOption {
+	return func(m *WebhookMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *User
+			value *Webhook
 		)
-		m.oldValue = func(ctx context.Context) (*User, error) {
+		m.oldValue = func(ctx context.Context) (*Webhook, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().User.Get(ctx, id)
+					value, err = m.Client().Webhook.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -1687,10 +6369,10 @@ func withUserID(id int) userOption {
 	}
 }
 
-// withUser sets the old User of the mutation.
-func withUser(node *User) userOption {
-	return func(m *UserMutation) {
-		m.oldValue = func(context.Context) (*User, error) {
+// withWebhook sets the old Webhook of the mutation.
+func withWebhook(node *Webhook) webhookOption {
+	return func(m *WebhookMutation) {
+		m.oldValue = func(context.Context) (*Webhook, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -1699,7 +6381,7 @@ func withUser(node *User) userOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m UserMutation) Client() *Client {
+func (m WebhookMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -1707,7 +6389,7 @@ func (m UserMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m UserMutation) Tx() (*Tx, error) {
+func (m WebhookMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -1718,7 +6400,7 @@ func (m UserMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *UserMutation) ID() (id int, exists bool) {
+func (m *WebhookMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -1729,7 +6411,7 @@ func (m *UserMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *UserMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *WebhookMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -1738,91 +6420,178 @@ func (m *UserMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().User.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Webhook.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetEmail sets the "email" field.
-func (m *UserMutation) SetEmail(s string) {
-	m.email = &s
+// SetURL sets the "url" field.
+func (m *WebhookMutation) SetURL(s string) {
+	m.url = &s
 }
 
-// Email returns the value of the "email" field in the mutation.
-func (m *UserMutation) Email() (r string, exists bool) {
-	v := m.email
+// URL returns the value of the "url" field in the mutation.
+func (m *WebhookMutation) URL() (r string, exists bool) {
+	v := m.url
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldEmail returns the old "email" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldURL returns the old "url" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldEmail(ctx context.Context) (v string, err error) {
+func (m *WebhookMutation) OldURL(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldEmail is only allowed on UpdateOne operations")
+		return v, errors.New("OldURL is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldEmail requires an ID field in the mutation")
+		return v, errors.New("OldURL requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldEmail: %w", err)
+		return v, fmt.Errorf("querying old value for OldURL: %w", err)
 	}
-	return oldValue.Email, nil
+	return oldValue.URL, nil
 }
 
-// ResetEmail resets all changes to the "email" field.
-func (m *UserMutation) ResetEmail() {
-	m.email = nil
+// ResetURL resets all changes to the "url" field.
+func (m *WebhookMutation) ResetURL() {
+	m.url = nil
 }
 
-// SetPassword sets the "password" field.
-func (m *UserMutation) SetPassword(s string) {
-	m.password = &s
+// SetSecret sets the "secret" field.
+func (m *WebhookMutation) SetSecret(s string) {
+	m.secret = &s
 }
 
-// Password returns the value of the "password" field in the mutation.
-func (m *UserMutation) Password() (r string, exists bool) {
-	v := m.password
+// Secret returns the value of the "secret" field in the mutation.
+func (m *WebhookMutation) Secret() (r string, exists bool) {
+	v := m.secret
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPassword returns the old "password" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldSecret returns the old "secret" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldPassword(ctx context.Context) (v string, err error) {
+func (m *WebhookMutation) OldSecret(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+		return v, errors.New("OldSecret is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPassword requires an ID field in the mutation")
+		return v, errors.New("OldSecret requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+		return v, fmt.Errorf("querying old value for OldSecret: %w", err)
 	}
-	return oldValue.Password, nil
+	return oldValue.Secret, nil
 }
 
-// ResetPassword resets all changes to the "password" field.
-func (m *UserMutation) ResetPassword() {
-	m.password = nil
+// ResetSecret resets all changes to the "secret" field.
+func (m *WebhookMutation) ResetSecret() {
+	m.secret = nil
+}
+
+// SetEvents sets the "events" field.
+func (m *WebhookMutation) SetEvents(s []string) {
+	m.events = &s
+	m.appendevents = nil
+}
+
+// Events returns the value of the "events" field in the mutation.
+func (m *WebhookMutation) Events() (r []string, exists bool) {
+	v := m.events
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEvents returns the old "events" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookMutation) OldEvents(ctx context.Context) (v []string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEvents is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEvents requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEvents: %w", err)
+	}
+	return oldValue.Events, nil
+}
+
+// AppendEvents adds s to the "events" field.
+func (m *WebhookMutation) AppendEvents(s []string) {
+	m.appendevents = append(m.appendevents, s...)
+}
+
+// AppendedEvents returns the list of values that were appended to the "events" field in this mutation.
+func (m *WebhookMutation) AppendedEvents() ([]string, bool) {
+	if len(m.appendevents) == 0 {
+		return nil, false
+	}
+	return m.appendevents, true
+}
+
+// ResetEvents resets all changes to the "events" field.
+func (m *WebhookMutation) ResetEvents() {
+	m.events = nil
+	m.appendevents = nil
+}
+
+// SetActive sets the "active" field.
+func (m *WebhookMutation) SetActive(b bool) {
+	m.active = &b
+}
+
+// Active returns the value of the "active" field in the mutation.
+func (m *WebhookMutation) Active() (r bool, exists bool) {
+	v := m.active
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldActive returns the old "active" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookMutation) OldActive(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldActive is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldActive requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldActive: %w", err)
+	}
+	return oldValue.Active, nil
+}
+
+// ResetActive resets all changes to the "active" field.
+func (m *WebhookMutation) ResetActive() {
+	m.active = nil
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *UserMutation) SetCreatedAt(t time.Time) {
+func (m *WebhookMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *WebhookMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -1830,10 +6599,10 @@ func (m *UserMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the User entity.
-// If the User object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *WebhookMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -1848,19 +6617,148 @@ func (m *UserMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *UserMutation) ResetCreatedAt() {
+func (m *WebhookMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// Where appends a list predicates to the UserMutation builder.
-func (m *UserMutation) Where(ps ...predicate.User) {
+// SetUpdatedAt sets the "updated_at" field.
+func (m *WebhookMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *WebhookMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Webhook entity.
+// If the Webhook object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *WebhookMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by id.
+func (m *WebhookMutation) SetOwnerID(id int) {
+	m.owner = &id
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (m *WebhookMutation) ClearOwner() {
+	m.clearedowner = true
+}
+
+// OwnerCleared reports if the "owner" edge to the User entity was cleared.
+func (m *WebhookMutation) OwnerCleared() bool {
+	return m.clearedowner
+}
+
+// OwnerID returns the "owner" edge ID in the mutation.
+func (m *WebhookMutation) OwnerID() (id int, exists bool) {
+	if m.owner != nil {
+		return *m.owner, true
+	}
+	return
+}
+
+// OwnerIDs returns the "owner" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// OwnerID instead. It exists only for internal usage by the builders.
+func (m *WebhookMutation) OwnerIDs() (ids []int) {
+	if id := m.owner; id != nil {
+		ids = append(ids, *id)
+	}
+	return
+}
+
+// ResetOwner resets all changes to the "owner" edge.
+func (m *WebhookMutation) ResetOwner() {
+	m.owner = nil
+	m.clearedowner = false
+}
+
+// AddDeliveryIDs adds the "deliveries" edge to the WebhookDelivery entity by ids.
+func (m *WebhookMutation) AddDeliveryIDs(ids ...int) {
+	if m.deliveries == nil {
+		m.deliveries = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.deliveries[ids[i]] = struct{}{}
+	}
+}
+
+// ClearDeliveries clears the "deliveries" edge to the WebhookDelivery entity.
+func (m *WebhookMutation) ClearDeliveries() {
+	m.cleareddeliveries = true
+}
+
+// DeliveriesCleared reports if the "deliveries" edge to the WebhookDelivery entity was cleared.
+func (m *WebhookMutation) DeliveriesCleared() bool {
+	return m.cleareddeliveries
+}
+
+// RemoveDeliveryIDs removes the "deliveries" edge to the WebhookDelivery entity by IDs.
+func (m *WebhookMutation) RemoveDeliveryIDs(ids ...int) {
+	if m.removeddeliveries == nil {
+		m.removeddeliveries = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.deliveries, ids[i])
+		m.removeddeliveries[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedDeliveries returns the removed IDs of the "deliveries" edge to the WebhookDelivery entity.
+func (m *WebhookMutation) RemovedDeliveriesIDs() (ids []int) {
+	for id := range m.removeddeliveries {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// DeliveriesIDs returns the "deliveries" edge IDs in the mutation.
+func (m *WebhookMutation) DeliveriesIDs() (ids []int) {
+	for id := range m.deliveries {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetDeliveries resets all changes to the "deliveries" edge.
+func (m *WebhookMutation) ResetDeliveries() {
+	m.deliveries = nil
+	m.cleareddeliveries = false
+	m.removeddeliveries = nil
+}
+
+// Where appends a list predicates to the WebhookMutation builder.
+func (m *WebhookMutation) Where(ps ...predicate.Webhook) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the UserMutation builder. Using this method,
+// WhereP appends storage-level predicates to the WebhookMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.User, len(ps))
+func (m *WebhookMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Webhook, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -1868,33 +6766,42 @@ func (m *UserMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *UserMutation) Op() Op {
+func (m *WebhookMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *UserMutation) SetOp(op Op) {
+func (m *WebhookMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (User).
-func (m *UserMutation) Type() string {
+// Type returns the node type of this mutation (Webhook).
+func (m *WebhookMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *UserMutation) Fields() []string {
-	fields := make([]string, 0, 3)
-	if m.email != nil {
-		fields = append(fields, user.FieldEmail)
+func (m *WebhookMutation) Fields() []string {
+	fields := make([]string, 0, 6)
+	if m.url != nil {
+		fields = append(fields, webhook.FieldURL)
 	}
-	if m.password != nil {
-		fields = append(fields, user.FieldPassword)
+	if m.secret != nil {
+		fields = append(fields, webhook.FieldSecret)
+	}
+	if m.events != nil {
+		fields = append(fields, webhook.FieldEvents)
+	}
+	if m.active != nil {
+		fields = append(fields, webhook.FieldActive)
 	}
 	if m.created_at != nil {
-		fields = append(fields, user.FieldCreatedAt)
+		fields = append(fields, webhook.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, webhook.FieldUpdatedAt)
 	}
 	return fields
 }
@@ -1902,14 +6809,20 @@ func (m *UserMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *UserMutation) Field(name string) (ent.Value, bool) {
+func (m *WebhookMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case user.FieldEmail:
-		return m.Email()
-	case user.FieldPassword:
-		return m.Password()
-	case user.FieldCreatedAt:
+	case webhook.FieldURL:
+		return m.URL()
+	case webhook.FieldSecret:
+		return m.Secret()
+	case webhook.FieldEvents:
+		return m.Events()
+	case webhook.FieldActive:
+		return m.Active()
+	case webhook.FieldCreatedAt:
 		return m.CreatedAt()
+	case webhook.FieldUpdatedAt:
+		return m.UpdatedAt()
 	}
 	return nil, false
 }
@@ -1917,183 +6830,280 @@ func (m *UserMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *UserMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *WebhookMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case user.FieldEmail:
-		return m.OldEmail(ctx)
-	case user.FieldPassword:
-		return m.OldPassword(ctx)
-	case user.FieldCreatedAt:
+	case webhook.FieldURL:
+		return m.OldURL(ctx)
+	case webhook.FieldSecret:
+		return m.OldSecret(ctx)
+	case webhook.FieldEvents:
+		return m.OldEvents(ctx)
+	case webhook.FieldActive:
+		return m.OldActive(ctx)
+	case webhook.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case webhook.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown User field %s", name)
+	return nil, fmt.Errorf("unknown Webhook field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) SetField(name string, value ent.Value) error {
+func (m *WebhookMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case user.FieldEmail:
+	case webhook.FieldURL:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetEmail(v)
+		m.SetURL(v)
 		return nil
-	case user.FieldPassword:
+	case webhook.FieldSecret:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPassword(v)
+		m.SetSecret(v)
 		return nil
-	case user.FieldCreatedAt:
+	case webhook.FieldEvents:
+		v, ok := value.([]string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEvents(v)
+		return nil
+	case webhook.FieldActive:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetActive(v)
+		return nil
+	case webhook.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
+	case webhook.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown Webhook field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *UserMutation) AddedFields() []string {
+func (m *WebhookMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *UserMutation) AddedField(name string) (ent.Value, bool) {
+func (m *WebhookMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *UserMutation) AddField(name string, value ent.Value) error {
+func (m *WebhookMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown User numeric field %s", name)
+	return fmt.Errorf("unknown Webhook numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *UserMutation) ClearedFields() []string {
+func (m *WebhookMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *UserMutation) FieldCleared(name string) bool {
+func (m *WebhookMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *UserMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown User nullable field %s", name)
+func (m *WebhookMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Webhook nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *UserMutation) ResetField(name string) error {
+func (m *WebhookMutation) ResetField(name string) error {
 	switch name {
-	case user.FieldEmail:
-		m.ResetEmail()
+	case webhook.FieldURL:
+		m.ResetURL()
 		return nil
-	case user.FieldPassword:
-		m.ResetPassword()
+	case webhook.FieldSecret:
+		m.ResetSecret()
 		return nil
-	case user.FieldCreatedAt:
+	case webhook.FieldEvents:
+		m.ResetEvents()
+		return nil
+	case webhook.FieldActive:
+		m.ResetActive()
+		return nil
+	case webhook.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case webhook.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
 	}
-	return fmt.Errorf("unknown User field %s", name)
+	return fmt.Errorf("unknown Webhook field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *UserMutation) AddedEdges() []string {
-	edges := make([]string, 0, 0)
+func (m *WebhookMutation) AddedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.owner != nil {
+		edges = append(edges, webhook.EdgeOwner)
+	}
+	if m.deliveries != nil {
+		edges = append(edges, webhook.EdgeDeliveries)
+	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *UserMutation) AddedIDs(name string) []ent.Value {
+func (m *WebhookMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case webhook.EdgeOwner:
+		if id := m.owner; id != nil {
+			return []ent.Value{*id}
+		}
+	case webhook.EdgeDeliveries:
+		ids := make([]ent.Value, 0, len(m.deliveries))
+		for id := range m.deliveries {
+			ids = append(ids, id)
+		}
+		return ids
+	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *UserMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 0)
+func (m *WebhookMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.removeddeliveries != nil {
+		edges = append(edges, webhook.EdgeDeliveries)
+	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *UserMutation) RemovedIDs(name string) []ent.Value {
+func (m *WebhookMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case webhook.EdgeDeliveries:
+		ids := make([]ent.Value, 0, len(m.removeddeliveries))
+		for id := range m.removeddeliveries {
+			ids = append(ids, id)
+		}
+		return ids
+	}
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *UserMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 0)
+func (m *WebhookMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 2)
+	if m.clearedowner {
+		edges = append(edges, webhook.EdgeOwner)
+	}
+	if m.cleareddeliveries {
+		edges = append(edges, webhook.EdgeDeliveries)
+	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *UserMutation) EdgeCleared(name string) bool {
+func (m *WebhookMutation) EdgeCleared(name string) bool {
+	switch name {
+	case webhook.EdgeOwner:
+		return m.clearedowner
+	case webhook.EdgeDeliveries:
+		return m.cleareddeliveries
+	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *UserMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown User unique edge %s", name)
+func (m *WebhookMutation) ClearEdge(name string) error {
+	switch name {
+	case webhook.EdgeOwner:
+		m.ClearOwner()
+		return nil
+	}
+	return fmt.Errorf("unknown Webhook unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *UserMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown User edge %s", name)
+func (m *WebhookMutation) ResetEdge(name string) error {
+	switch name {
+	case webhook.EdgeOwner:
+		m.ResetOwner()
+		return nil
+	case webhook.EdgeDeliveries:
+		m.ResetDeliveries()
+		return nil
+	}
+	return fmt.Errorf("unknown Webhook edge %s", name)
 }
 
-// VoteMutation represents an operation that mutates the Vote nodes in the graph.
-type VoteMutation struct {
+// WebhookDeliveryMutation represents an operation that mutates the WebhookDelivery nodes in the graph.
+type WebhookDeliveryMutation struct {
 	config
-	op               Op
-	typ              string
-	id               *int
-	voter_identifier *string
-	created_at       *time.Time
-	clearedFields    map[string]struct{}
-	poll             *int
-	clearedpoll      bool
-	option           *int
-	clearedoption    bool
-	done             bool
-	oldValue         func(context.Context) (*Vote, error)
-	predicates       []predicate.Vote
-}
-
-var _ ent.Mutation = (*VoteMutation)(nil)
-
-// voteOption allows management of the mutation configuration using functional options.
-type voteOption func(*VoteMutation)
-
-// newVoteMutation creates new mutation for the Vote entity.
-func newVoteMutation(c config, op Op, opts ...voteOption) *VoteMutation {
-	m := &VoteMutation{
+	op                 Op
+	typ                string
+	id                 *int
+	event              *string
+	payload            *string
+	status             *string
+	attempts           *int
+	addattempts        *int
+	response_status    *int
+	addresponse_status *int
+	last_error         *string
+	next_attempt_at    *time.Time
+	delivered_at       *time.Time
+	created_at         *time.Time
+	clearedFields      map[string]struct{}
+	webhook            *int
+	clearedwebhook     bool
+	done               bool
+	oldValue           func(context.Context) (*WebhookDelivery, error)
+	predicates         []predicate.WebhookDelivery
+}
+
+var _ ent.Mutation = (*WebhookDeliveryMutation)(nil)
+
+// webhookdeliveryOption allows management of the mutation configuration using functional options.
+type webhookdeliveryOption func(*WebhookDeliveryMutation)
+
+// newWebhookDeliveryMutation creates new mutation for the WebhookDelivery entity.
+func newWebhookDeliveryMutation(c config, op Op, opts ...webhookdeliveryOption) *WebhookDeliveryMutation {
+	m := &WebhookDeliveryMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeVote,
+		typ:           TypeWebhookDelivery,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -2102,20 +7112,20 @@ func newVoteMutation(c config, op Op, opts ...voteOption) *VoteMutation {
 	return m
 }
 
-// withVoteID sets the ID field of the mutation.
-func withVoteID(id int) voteOption {
-	return func(m *VoteMutation) {
+// withWebhookDeliveryID sets the ID field of the mutation.
+func withWebhookDeliveryID(id int) webhookdeliveryOption {
+	return func(m *WebhookDeliveryMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Vote
+			value *WebhookDelivery
 		)
-		m.oldValue = func(ctx context.Context) (*Vote, error) {
+		m.oldValue = func(ctx context.Context) (*WebhookDelivery, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Vote.Get(ctx, id)
+					value, err = m.Client().WebhookDelivery.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -2124,10 +7134,10 @@ func withVoteID(id int) voteOption {
 	}
 }
 
-// withVote sets the old Vote of the mutation.
-func withVote(node *Vote) voteOption {
-	return func(m *VoteMutation) {
-		m.oldValue = func(context.Context) (*Vote, error) {
+// withWebhookDelivery sets the old WebhookDelivery of the mutation.
+func withWebhookDelivery(node *WebhookDelivery) webhookdeliveryOption {
+	return func(m *WebhookDeliveryMutation) {
+		m.oldValue = func(context.Context) (*WebhookDelivery, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -2136,7 +7146,7 @@ func withVote(node *Vote) voteOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m VoteMutation) Client() *Client {
+func (m WebhookDeliveryMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -2144,7 +7154,7 @@ func (m VoteMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m VoteMutation) Tx() (*Tx, error) {
+func (m WebhookDeliveryMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -2155,7 +7165,7 @@ func (m VoteMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *VoteMutation) ID() (id int, exists bool) {
+func (m *WebhookDeliveryMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -2166,7 +7176,7 @@ func (m *VoteMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *VoteMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *WebhookDeliveryMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -2175,68 +7185,400 @@ func (m *VoteMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Vote.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().WebhookDelivery.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetVoterIdentifier sets the "voter_identifier" field.
-func (m *VoteMutation) SetVoterIdentifier(s string) {
-	m.voter_identifier = &s
+// SetEvent sets the "event" field.
+func (m *WebhookDeliveryMutation) SetEvent(s string) {
+	m.event = &s
+}
+
+// Event returns the value of the "event" field in the mutation.
+func (m *WebhookDeliveryMutation) Event() (r string, exists bool) {
+	v := m.event
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEvent returns the old "event" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldEvent(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEvent is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEvent requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEvent: %w", err)
+	}
+	return oldValue.Event, nil
+}
+
+// ResetEvent resets all changes to the "event" field.
+func (m *WebhookDeliveryMutation) ResetEvent() {
+	m.event = nil
+}
+
+// SetPayload sets the "payload" field.
+func (m *WebhookDeliveryMutation) SetPayload(s string) {
+	m.payload = &s
+}
+
+// Payload returns the value of the "payload" field in the mutation.
+func (m *WebhookDeliveryMutation) Payload() (r string, exists bool) {
+	v := m.payload
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPayload returns the old "payload" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldPayload(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPayload requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+	}
+	return oldValue.Payload, nil
+}
+
+// ResetPayload resets all changes to the "payload" field.
+func (m *WebhookDeliveryMutation) ResetPayload() {
+	m.payload = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *WebhookDeliveryMutation) SetStatus(s string) {
+	m.status = &s
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *WebhookDeliveryMutation) Status() (r string, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldStatus(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
+	}
+	return oldValue.Status, nil
+}
+
+// ResetStatus resets all changes to the "status" field.
+func (m *WebhookDeliveryMutation) ResetStatus() {
+	m.status = nil
+}
+
+// SetAttempts sets the "attempts" field.
+func (m *WebhookDeliveryMutation) SetAttempts(i int) {
+	m.attempts = &i
+	m.addattempts = nil
+}
+
+// Attempts returns the value of the "attempts" field in the mutation.
+func (m *WebhookDeliveryMutation) Attempts() (r int, exists bool) {
+	v := m.attempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAttempts returns the old "attempts" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldAttempts(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAttempts is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAttempts requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAttempts: %w", err)
+	}
+	return oldValue.Attempts, nil
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (m *WebhookDeliveryMutation) AddAttempts(i int) {
+	if m.addattempts != nil {
+		*m.addattempts += i
+	} else {
+		m.addattempts = &i
+	}
+}
+
+// AddedAttempts returns the value that was added to the "attempts" field in this mutation.
+func (m *WebhookDeliveryMutation) AddedAttempts() (r int, exists bool) {
+	v := m.addattempts
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAttempts resets all changes to the "attempts" field.
+func (m *WebhookDeliveryMutation) ResetAttempts() {
+	m.attempts = nil
+	m.addattempts = nil
+}
+
+// SetResponseStatus sets the "response_status" field.
+func (m *WebhookDeliveryMutation) SetResponseStatus(i int) {
+	m.response_status = &i
+	m.addresponse_status = nil
+}
+
+// ResponseStatus returns the value of the "response_status" field in the mutation.
+func (m *WebhookDeliveryMutation) ResponseStatus() (r int, exists bool) {
+	v := m.response_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldResponseStatus returns the old "response_status" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldResponseStatus(ctx context.Context) (v *int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldResponseStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldResponseStatus requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldResponseStatus: %w", err)
+	}
+	return oldValue.ResponseStatus, nil
+}
+
+// AddResponseStatus adds i to the "response_status" field.
+func (m *WebhookDeliveryMutation) AddResponseStatus(i int) {
+	if m.addresponse_status != nil {
+		*m.addresponse_status += i
+	} else {
+		m.addresponse_status = &i
+	}
+}
+
+// AddedResponseStatus returns the value that was added to the "response_status" field in this mutation.
+func (m *WebhookDeliveryMutation) AddedResponseStatus() (r int, exists bool) {
+	v := m.addresponse_status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearResponseStatus clears the value of the "response_status" field.
+func (m *WebhookDeliveryMutation) ClearResponseStatus() {
+	m.response_status = nil
+	m.addresponse_status = nil
+	m.clearedFields[webhookdelivery.FieldResponseStatus] = struct{}{}
+}
+
+// ResponseStatusCleared returns if the "response_status" field was cleared in this mutation.
+func (m *WebhookDeliveryMutation) ResponseStatusCleared() bool {
+	_, ok := m.clearedFields[webhookdelivery.FieldResponseStatus]
+	return ok
+}
+
+// ResetResponseStatus resets all changes to the "response_status" field.
+func (m *WebhookDeliveryMutation) ResetResponseStatus() {
+	m.response_status = nil
+	m.addresponse_status = nil
+	delete(m.clearedFields, webhookdelivery.FieldResponseStatus)
+}
+
+// SetLastError sets the "last_error" field.
+func (m *WebhookDeliveryMutation) SetLastError(s string) {
+	m.last_error = &s
+}
+
+// LastError returns the value of the "last_error" field in the mutation.
+func (m *WebhookDeliveryMutation) LastError() (r string, exists bool) {
+	v := m.last_error
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastError returns the old "last_error" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldLastError(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastError is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastError requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastError: %w", err)
+	}
+	return oldValue.LastError, nil
+}
+
+// ClearLastError clears the value of the "last_error" field.
+func (m *WebhookDeliveryMutation) ClearLastError() {
+	m.last_error = nil
+	m.clearedFields[webhookdelivery.FieldLastError] = struct{}{}
+}
+
+// LastErrorCleared returns if the "last_error" field was cleared in this mutation.
+func (m *WebhookDeliveryMutation) LastErrorCleared() bool {
+	_, ok := m.clearedFields[webhookdelivery.FieldLastError]
+	return ok
+}
+
+// ResetLastError resets all changes to the "last_error" field.
+func (m *WebhookDeliveryMutation) ResetLastError() {
+	m.last_error = nil
+	delete(m.clearedFields, webhookdelivery.FieldLastError)
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (m *WebhookDeliveryMutation) SetNextAttemptAt(t time.Time) {
+	m.next_attempt_at = &t
+}
+
+// NextAttemptAt returns the value of the "next_attempt_at" field in the mutation.
+func (m *WebhookDeliveryMutation) NextAttemptAt() (r time.Time, exists bool) {
+	v := m.next_attempt_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldNextAttemptAt returns the old "next_attempt_at" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *WebhookDeliveryMutation) OldNextAttemptAt(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldNextAttemptAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldNextAttemptAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldNextAttemptAt: %w", err)
+	}
+	return oldValue.NextAttemptAt, nil
+}
+
+// ClearNextAttemptAt clears the value of the "next_attempt_at" field.
+func (m *WebhookDeliveryMutation) ClearNextAttemptAt() {
+	m.next_attempt_at = nil
+	m.clearedFields[webhookdelivery.FieldNextAttemptAt] = struct{}{}
 }
 
-// VoterIdentifier returns the value of the "voter_identifier" field in the mutation.
-func (m *VoteMutation) VoterIdentifier() (r string, exists bool) {
-	v := m.voter_identifier
+// NextAttemptAtCleared returns if the "next_attempt_at" field was cleared in this mutation.
+func (m *WebhookDeliveryMutation) NextAttemptAtCleared() bool {
+	_, ok := m.clearedFields[webhookdelivery.FieldNextAttemptAt]
+	return ok
+}
+
+// ResetNextAttemptAt resets all changes to the "next_attempt_at" field.
+func (m *WebhookDeliveryMutation) ResetNextAttemptAt() {
+	m.next_attempt_at = nil
+	delete(m.clearedFields, webhookdelivery.FieldNextAttemptAt)
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (m *WebhookDeliveryMutation) SetDeliveredAt(t time.Time) {
+	m.delivered_at = &t
+}
+
+// DeliveredAt returns the value of the "delivered_at" field in the mutation.
+func (m *WebhookDeliveryMutation) DeliveredAt() (r time.Time, exists bool) {
+	v := m.delivered_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVoterIdentifier returns the old "voter_identifier" field's value of the Vote entity.
-// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// OldDeliveredAt returns the old "delivered_at" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *VoteMutation) OldVoterIdentifier(ctx context.Context) (v string, err error) {
+func (m *WebhookDeliveryMutation) OldDeliveredAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVoterIdentifier is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeliveredAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVoterIdentifier requires an ID field in the mutation")
+		return v, errors.New("OldDeliveredAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVoterIdentifier: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeliveredAt: %w", err)
 	}
-	return oldValue.VoterIdentifier, nil
+	return oldValue.DeliveredAt, nil
 }
 
-// ClearVoterIdentifier clears the value of the "voter_identifier" field.
-func (m *VoteMutation) ClearVoterIdentifier() {
-	m.voter_identifier = nil
-	m.clearedFields[vote.FieldVoterIdentifier] = struct{}{}
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (m *WebhookDeliveryMutation) ClearDeliveredAt() {
+	m.delivered_at = nil
+	m.clearedFields[webhookdelivery.FieldDeliveredAt] = struct{}{}
 }
 
-// VoterIdentifierCleared returns if the "voter_identifier" field was cleared in this mutation.
-func (m *VoteMutation) VoterIdentifierCleared() bool {
-	_, ok := m.clearedFields[vote.FieldVoterIdentifier]
+// DeliveredAtCleared returns if the "delivered_at" field was cleared in this mutation.
+func (m *WebhookDeliveryMutation) DeliveredAtCleared() bool {
+	_, ok := m.clearedFields[webhookdelivery.FieldDeliveredAt]
 	return ok
 }
 
-// ResetVoterIdentifier resets all changes to the "voter_identifier" field.
-func (m *VoteMutation) ResetVoterIdentifier() {
-	m.voter_identifier = nil
-	delete(m.clearedFields, vote.FieldVoterIdentifier)
+// ResetDeliveredAt resets all changes to the "delivered_at" field.
+func (m *WebhookDeliveryMutation) ResetDeliveredAt() {
+	m.delivered_at = nil
+	delete(m.clearedFields, webhookdelivery.FieldDeliveredAt)
 }
 
 // SetCreatedAt sets the "created_at" field.
-func (m *VoteMutation) SetCreatedAt(t time.Time) {
+func (m *WebhookDeliveryMutation) SetCreatedAt(t time.Time) {
 	m.created_at = &t
 }
 
 // CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *VoteMutation) CreatedAt() (r time.Time, exists bool) {
+func (m *WebhookDeliveryMutation) CreatedAt() (r time.Time, exists bool) {
 	v := m.created_at
 	if v == nil {
 		return
@@ -2244,10 +7586,10 @@ func (m *VoteMutation) CreatedAt() (r time.Time, exists bool) {
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Vote entity.
-// If the Vote object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the WebhookDelivery entity.
+// If the WebhookDelivery object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *VoteMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *WebhookDeliveryMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
 		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
@@ -2262,97 +7604,58 @@ func (m *VoteMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error
 }
 
 // ResetCreatedAt resets all changes to the "created_at" field.
-func (m *VoteMutation) ResetCreatedAt() {
+func (m *WebhookDeliveryMutation) ResetCreatedAt() {
 	m.created_at = nil
 }
 
-// SetPollID sets the "poll" edge to the Poll entity by id.
-func (m *VoteMutation) SetPollID(id int) {
-	m.poll = &id
-}
-
-// ClearPoll clears the "poll" edge to the Poll entity.
-func (m *VoteMutation) ClearPoll() {
-	m.clearedpoll = true
-}
-
-// PollCleared reports if the "poll" edge to the Poll entity was cleared.
-func (m *VoteMutation) PollCleared() bool {
-	return m.clearedpoll
-}
-
-// PollID returns the "poll" edge ID in the mutation.
-func (m *VoteMutation) PollID() (id int, exists bool) {
-	if m.poll != nil {
-		return *m.poll, true
-	}
-	return
-}
-
-// PollIDs returns the "poll" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// PollID instead. It exists only for internal usage by the builders.
-func (m *VoteMutation) PollIDs() (ids []int) {
-	if id := m.poll; id != nil {
-		ids = append(ids, *id)
-	}
-	return
-}
-
-// ResetPoll resets all changes to the "poll" edge.
-func (m *VoteMutation) ResetPoll() {
-	m.poll = nil
-	m.clearedpoll = false
-}
-
-// SetOptionID sets the "option" edge to the PollOption entity by id.
-func (m *VoteMutation) SetOptionID(id int) {
-	m.option = &id
+// SetWebhookID sets the "webhook" edge to the Webhook entity by id.
+func (m *WebhookDeliveryMutation) SetWebhookID(id int) {
+	m.webhook = &id
 }
 
-// ClearOption clears the "option" edge to the PollOption entity.
-func (m *VoteMutation) ClearOption() {
-	m.clearedoption = true
+// ClearWebhook clears the "webhook" edge to the Webhook entity.
+func (m *WebhookDeliveryMutation) ClearWebhook() {
+	m.clearedwebhook = true
 }
 
-// OptionCleared reports if the "option" edge to the PollOption entity was cleared.
-func (m *VoteMutation) OptionCleared() bool {
-	return m.clearedoption
+// WebhookCleared reports if the "webhook" edge to the Webhook entity was cleared.
+func (m *WebhookDeliveryMutation) WebhookCleared() bool {
+	return m.clearedwebhook
 }
 
-// OptionID returns the "option" edge ID in the mutation.
-func (m *VoteMutation) OptionID() (id int, exists bool) {
-	if m.option != nil {
-		return *m.option, true
+// WebhookID returns the "webhook" edge ID in the mutation.
+func (m *WebhookDeliveryMutation) WebhookID() (id int, exists bool) {
+	if m.webhook != nil {
+		return *m.webhook, true
 	}
 	return
 }
 
-// OptionIDs returns the "option" edge IDs in the mutation.
+// WebhookIDs returns the "webhook" edge IDs in the mutation.
 // Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// OptionID instead. It exists only for internal usage by the builders.
-func (m *VoteMutation) OptionIDs() (ids []int) {
-	if id := m.option; id != nil {
+// WebhookID instead. It exists only for internal usage by the builders.
+func (m *WebhookDeliveryMutation) WebhookIDs() (ids []int) {
+	if id := m.webhook; id != nil {
 		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetOption resets all changes to the "option" edge.
-func (m *VoteMutation) ResetOption() {
-	m.option = nil
-	m.clearedoption = false
+// ResetWebhook resets all changes to the "webhook" edge.
+func (m *WebhookDeliveryMutation) ResetWebhook() {
+	m.webhook = nil
+	m.clearedwebhook = false
 }
 
-// Where appends a list predicates to the VoteMutation builder.
-func (m *VoteMutation) Where(ps ...predicate.Vote) {
+// Where appends a list predicates to the WebhookDeliveryMutation builder.
+func (m *WebhookDeliveryMutation) Where(ps ...predicate.WebhookDelivery) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the VoteMutation builder. Using this method,
+// WhereP appends storage-level predicates to the WebhookDeliveryMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *VoteMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Vote, len(ps))
+func (m *WebhookDeliveryMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.WebhookDelivery, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -2360,30 +7663,51 @@ func (m *VoteMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *VoteMutation) Op() Op {
+func (m *WebhookDeliveryMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *VoteMutation) SetOp(op Op) {
+func (m *WebhookDeliveryMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Vote).
-func (m *VoteMutation) Type() string {
+// Type returns the node type of this mutation (WebhookDelivery).
+func (m *WebhookDeliveryMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *VoteMutation) Fields() []string {
-	fields := make([]string, 0, 2)
-	if m.voter_identifier != nil {
-		fields = append(fields, vote.FieldVoterIdentifier)
+func (m *WebhookDeliveryMutation) Fields() []string {
+	fields := make([]string, 0, 9)
+	if m.event != nil {
+		fields = append(fields, webhookdelivery.FieldEvent)
+	}
+	if m.payload != nil {
+		fields = append(fields, webhookdelivery.FieldPayload)
+	}
+	if m.status != nil {
+		fields = append(fields, webhookdelivery.FieldStatus)
+	}
+	if m.attempts != nil {
+		fields = append(fields, webhookdelivery.FieldAttempts)
+	}
+	if m.response_status != nil {
+		fields = append(fields, webhookdelivery.FieldResponseStatus)
+	}
+	if m.last_error != nil {
+		fields = append(fields, webhookdelivery.FieldLastError)
+	}
+	if m.next_attempt_at != nil {
+		fields = append(fields, webhookdelivery.FieldNextAttemptAt)
+	}
+	if m.delivered_at != nil {
+		fields = append(fields, webhookdelivery.FieldDeliveredAt)
 	}
 	if m.created_at != nil {
-		fields = append(fields, vote.FieldCreatedAt)
+		fields = append(fields, webhookdelivery.FieldCreatedAt)
 	}
 	return fields
 }
@@ -2391,11 +7715,25 @@ func (m *VoteMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *VoteMutation) Field(name string) (ent.Value, bool) {
+func (m *WebhookDeliveryMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case vote.FieldVoterIdentifier:
-		return m.VoterIdentifier()
-	case vote.FieldCreatedAt:
+	case webhookdelivery.FieldEvent:
+		return m.Event()
+	case webhookdelivery.FieldPayload:
+		return m.Payload()
+	case webhookdelivery.FieldStatus:
+		return m.Status()
+	case webhookdelivery.FieldAttempts:
+		return m.Attempts()
+	case webhookdelivery.FieldResponseStatus:
+		return m.ResponseStatus()
+	case webhookdelivery.FieldLastError:
+		return m.LastError()
+	case webhookdelivery.FieldNextAttemptAt:
+		return m.NextAttemptAt()
+	case webhookdelivery.FieldDeliveredAt:
+		return m.DeliveredAt()
+	case webhookdelivery.FieldCreatedAt:
 		return m.CreatedAt()
 	}
 	return nil, false
@@ -2404,29 +7742,92 @@ func (m *VoteMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *VoteMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *WebhookDeliveryMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case vote.FieldVoterIdentifier:
-		return m.OldVoterIdentifier(ctx)
-	case vote.FieldCreatedAt:
+	case webhookdelivery.FieldEvent:
+		return m.OldEvent(ctx)
+	case webhookdelivery.FieldPayload:
+		return m.OldPayload(ctx)
+	case webhookdelivery.FieldStatus:
+		return m.OldStatus(ctx)
+	case webhookdelivery.FieldAttempts:
+		return m.OldAttempts(ctx)
+	case webhookdelivery.FieldResponseStatus:
+		return m.OldResponseStatus(ctx)
+	case webhookdelivery.FieldLastError:
+		return m.OldLastError(ctx)
+	case webhookdelivery.FieldNextAttemptAt:
+		return m.OldNextAttemptAt(ctx)
+	case webhookdelivery.FieldDeliveredAt:
+		return m.OldDeliveredAt(ctx)
+	case webhookdelivery.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Vote field %s", name)
+	return nil, fmt.Errorf("unknown WebhookDelivery field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *VoteMutation) SetField(name string, value ent.Value) error {
+func (m *WebhookDeliveryMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case vote.FieldVoterIdentifier:
+	case webhookdelivery.FieldEvent:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetVoterIdentifier(v)
+		m.SetEvent(v)
 		return nil
-	case vote.FieldCreatedAt:
+	case webhookdelivery.FieldPayload:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPayload(v)
+		return nil
+	case webhookdelivery.FieldStatus:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case webhookdelivery.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttempts(v)
+		return nil
+	case webhookdelivery.FieldResponseStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetResponseStatus(v)
+		return nil
+	case webhookdelivery.FieldLastError:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastError(v)
+		return nil
+	case webhookdelivery.FieldNextAttemptAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetNextAttemptAt(v)
+		return nil
+	case webhookdelivery.FieldDeliveredAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeliveredAt(v)
+		return nil
+	case webhookdelivery.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
@@ -2434,95 +7835,154 @@ func (m *VoteMutation) SetField(name string, value ent.Value) error {
 		m.SetCreatedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Vote field %s", name)
+	return fmt.Errorf("unknown WebhookDelivery field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *VoteMutation) AddedFields() []string {
-	return nil
+func (m *WebhookDeliveryMutation) AddedFields() []string {
+	var fields []string
+	if m.addattempts != nil {
+		fields = append(fields, webhookdelivery.FieldAttempts)
+	}
+	if m.addresponse_status != nil {
+		fields = append(fields, webhookdelivery.FieldResponseStatus)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *VoteMutation) AddedField(name string) (ent.Value, bool) {
+func (m *WebhookDeliveryMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case webhookdelivery.FieldAttempts:
+		return m.AddedAttempts()
+	case webhookdelivery.FieldResponseStatus:
+		return m.AddedResponseStatus()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *VoteMutation) AddField(name string, value ent.Value) error {
+func (m *WebhookDeliveryMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case webhookdelivery.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAttempts(v)
+		return nil
+	case webhookdelivery.FieldResponseStatus:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddResponseStatus(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Vote numeric field %s", name)
+	return fmt.Errorf("unknown WebhookDelivery numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *VoteMutation) ClearedFields() []string {
+func (m *WebhookDeliveryMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(vote.FieldVoterIdentifier) {
-		fields = append(fields, vote.FieldVoterIdentifier)
+	if m.FieldCleared(webhookdelivery.FieldResponseStatus) {
+		fields = append(fields, webhookdelivery.FieldResponseStatus)
+	}
+	if m.FieldCleared(webhookdelivery.FieldLastError) {
+		fields = append(fields, webhookdelivery.FieldLastError)
+	}
+	if m.FieldCleared(webhookdelivery.FieldNextAttemptAt) {
+		fields = append(fields, webhookdelivery.FieldNextAttemptAt)
+	}
+	if m.FieldCleared(webhookdelivery.FieldDeliveredAt) {
+		fields = append(fields, webhookdelivery.FieldDeliveredAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *VoteMutation) FieldCleared(name string) bool {
+func (m *WebhookDeliveryMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *VoteMutation) ClearField(name string) error {
+func (m *WebhookDeliveryMutation) ClearField(name string) error {
 	switch name {
-	case vote.FieldVoterIdentifier:
-		m.ClearVoterIdentifier()
+	case webhookdelivery.FieldResponseStatus:
+		m.ClearResponseStatus()
+		return nil
+	case webhookdelivery.FieldLastError:
+		m.ClearLastError()
+		return nil
+	case webhookdelivery.FieldNextAttemptAt:
+		m.ClearNextAttemptAt()
+		return nil
+	case webhookdelivery.FieldDeliveredAt:
+		m.ClearDeliveredAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Vote nullable field %s", name)
+	return fmt.Errorf("unknown WebhookDelivery nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *VoteMutation) ResetField(name string) error {
+func (m *WebhookDeliveryMutation) ResetField(name string) error {
 	switch name {
-	case vote.FieldVoterIdentifier:
-		m.ResetVoterIdentifier()
+	case webhookdelivery.FieldEvent:
+		m.ResetEvent()
 		return nil
-	case vote.FieldCreatedAt:
+	case webhookdelivery.FieldPayload:
+		m.ResetPayload()
+		return nil
+	case webhookdelivery.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case webhookdelivery.FieldAttempts:
+		m.ResetAttempts()
+		return nil
+	case webhookdelivery.FieldResponseStatus:
+		m.ResetResponseStatus()
+		return nil
+	case webhookdelivery.FieldLastError:
+		m.ResetLastError()
+		return nil
+	case webhookdelivery.FieldNextAttemptAt:
+		m.ResetNextAttemptAt()
+		return nil
+	case webhookdelivery.FieldDeliveredAt:
+		m.ResetDeliveredAt()
+		return nil
+	case webhookdelivery.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Vote field %s", name)
+	return fmt.Errorf("unknown WebhookDelivery field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *VoteMutation) AddedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.poll != nil {
-		edges = append(edges, vote.EdgePoll)
-	}
-	if m.option != nil {
-		edges = append(edges, vote.EdgeOption)
+func (m *WebhookDeliveryMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.webhook != nil {
+		edges = append(edges, webhookdelivery.EdgeWebhook)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *VoteMutation) AddedIDs(name string) []ent.Value {
+func (m *WebhookDeliveryMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case vote.EdgePoll:
-		if id := m.poll; id != nil {
-			return []ent.Value{*id}
-		}
-	case vote.EdgeOption:
-		if id := m.option; id != nil {
+	case webhookdelivery.EdgeWebhook:
+		if id := m.webhook; id != nil {
 			return []ent.Value{*id}
 		}
 	}
@@ -2530,65 +7990,54 @@ func (m *VoteMutation) AddedIDs(name string) []ent.Value {
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *VoteMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 2)
+func (m *WebhookDeliveryMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *VoteMutation) RemovedIDs(name string) []ent.Value {
+func (m *WebhookDeliveryMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *VoteMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 2)
-	if m.clearedpoll {
-		edges = append(edges, vote.EdgePoll)
-	}
-	if m.clearedoption {
-		edges = append(edges, vote.EdgeOption)
+func (m *WebhookDeliveryMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedwebhook {
+		edges = append(edges, webhookdelivery.EdgeWebhook)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *VoteMutation) EdgeCleared(name string) bool {
+func (m *WebhookDeliveryMutation) EdgeCleared(name string) bool {
 	switch name {
-	case vote.EdgePoll:
-		return m.clearedpoll
-	case vote.EdgeOption:
-		return m.clearedoption
+	case webhookdelivery.EdgeWebhook:
+		return m.clearedwebhook
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *VoteMutation) ClearEdge(name string) error {
+func (m *WebhookDeliveryMutation) ClearEdge(name string) error {
 	switch name {
-	case vote.EdgePoll:
-		m.ClearPoll()
-		return nil
-	case vote.EdgeOption:
-		m.ClearOption()
+	case webhookdelivery.EdgeWebhook:
+		m.ClearWebhook()
 		return nil
 	}
-	return fmt.Errorf("unknown Vote unique edge %s", name)
+	return fmt.Errorf("unknown WebhookDelivery unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *VoteMutation) ResetEdge(name string) error {
+func (m *WebhookDeliveryMutation) ResetEdge(name string) error {
 	switch name {
-	case vote.EdgePoll:
-		m.ResetPoll()
-		return nil
-	case vote.EdgeOption:
-		m.ResetOption()
+	case webhookdelivery.EdgeWebhook:
+		m.ResetWebhook()
 		return nil
 	}
-	return fmt.Errorf("unknown Vote edge %s", name)
+	return fmt.Errorf("unknown WebhookDelivery edge %s", name)
 }
@@ -4,6 +4,8 @@ package ent
 
 import (
 	"backend/ent/poll"
+	"backend/ent/user"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -21,22 +23,52 @@ type Poll struct {
 	Title string `json:"title,omitempty"`
 	// Optional poll description
 	Description string `json:"description,omitempty"`
-	// Type of poll: single_choice, multiple_choice
+	// Type of poll: single_choice, multiple_choice, ranked_choice, rating
 	PollType string `json:"poll_type,omitempty"`
-	// Email of user who created the poll
+	// Email of user who created the poll (kept alongside the owner edge)
 	CreatedBy string `json:"created_by,omitempty"`
-	// Maximum votes allowed per user
+	// Maximum votes allowed per user (options ranked, for ranked_choice)
 	MaxVotesPerUser int `json:"max_votes_per_user,omitempty"`
+	// Lowest score voters can give on rating polls
+	RatingMin *int `json:"rating_min,omitempty"`
+	// Highest score voters can give on rating polls
+	RatingMax *int `json:"rating_max,omitempty"`
+	// Whether voters may change or withdraw their vote
+	AllowVoteChanges bool `json:"allow_vote_changes,omitempty"`
+	// When a draft poll starts taking votes; nil if it opened on creation
+	OpensAt *time.Time `json:"opens_at,omitempty"`
 	// When the poll expires
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Lifecycle state: draft (waiting for opens_at), open, closed, or template for recurring polls
+	State string `json:"state,omitempty"`
+	// When the poll was closed, early by its creator or at expiry; nil while it is open
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	// Who can find and vote on the poll: public, unlisted or private
+	Visibility string `json:"visibility,omitempty"`
+	// When vote counts are shown: always, after_vote, after_close or never (creator only)
+	ResultsVisibility string `json:"results_visibility,omitempty"`
+	// Emails allowed to see and vote on a private poll
+	InvitedEmails []string `json:"-"`
+	// Cron schedule a recurring template creates occurrences on; empty for other polls
+	Recurrence string `json:"recurrence,omitempty"`
+	// IANA time zone the recurrence is read in; UTC when empty
+	RecurrenceTimezone string `json:"recurrence_timezone,omitempty"`
+	// When a recurring template next creates an occurrence
+	NextOccurrenceAt *time.Time `json:"next_occurrence_at,omitempty"`
+	// Option texts joined together, indexed for full-text search
+	SearchText string `json:"-"`
+	// Sum of the options' vote counts, kept in step for sorting
+	TotalVotes int `json:"total_votes,omitempty"`
 	// Poll creation timestamp
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Poll last update timestamp
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PollQuery when eager-loading is set.
-	Edges        PollEdges `json:"edges"`
-	selectValues sql.SelectValues
+	Edges            PollEdges `json:"edges"`
+	poll_occurrences *int
+	user_polls       *int
+	selectValues     sql.SelectValues
 }
 
 // PollEdges holds the relations/edges for other nodes in the graph.
@@ -45,9 +77,17 @@ type PollEdges struct {
 	Options []*PollOption `json:"options,omitempty"`
 	// Votes cast on this poll
 	Votes []*Vote `json:"votes,omitempty"`
+	// Invite links to a private poll
+	Invites []*PollInvite `json:"invites,omitempty"`
+	// The recurring template this poll is an occurrence of
+	Template *Poll `json:"template,omitempty"`
+	// Polls created from this recurring template
+	Occurrences []*Poll `json:"occurrences,omitempty"`
+	// The user who created this poll
+	Owner *User `json:"owner,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [6]bool
 }
 
 // OptionsOrErr returns the Options value or an error if the edge
@@ -68,17 +108,65 @@ func (e PollEdges) VotesOrErr() ([]*Vote, error) {
 	return nil, &NotLoadedError{edge: "votes"}
 }
 
+// InvitesOrErr returns the Invites value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InvitesOrErr() ([]*PollInvite, error) {
+	if e.loadedTypes[2] {
+		return e.Invites, nil
+	}
+	return nil, &NotLoadedError{edge: "invites"}
+}
+
+// TemplateOrErr returns the Template value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollEdges) TemplateOrErr() (*Poll, error) {
+	if e.Template != nil {
+		return e.Template, nil
+	} else if e.loadedTypes[3] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "template"}
+}
+
+// OccurrencesOrErr returns the Occurrences value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) OccurrencesOrErr() ([]*Poll, error) {
+	if e.loadedTypes[4] {
+		return e.Occurrences, nil
+	}
+	return nil, &NotLoadedError{edge: "occurrences"}
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[5] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Poll) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case poll.FieldID, poll.FieldMaxVotesPerUser:
+		case poll.FieldInvitedEmails:
+			values[i] = new([]byte)
+		case poll.FieldAllowVoteChanges:
+			values[i] = new(sql.NullBool)
+		case poll.FieldID, poll.FieldMaxVotesPerUser, poll.FieldRatingMin, poll.FieldRatingMax, poll.FieldTotalVotes:
 			values[i] = new(sql.NullInt64)
-		case poll.FieldTitle, poll.FieldDescription, poll.FieldPollType, poll.FieldCreatedBy:
+		case poll.FieldTitle, poll.FieldDescription, poll.FieldPollType, poll.FieldCreatedBy, poll.FieldState, poll.FieldVisibility, poll.FieldResultsVisibility, poll.FieldRecurrence, poll.FieldRecurrenceTimezone, poll.FieldSearchText:
 			values[i] = new(sql.NullString)
-		case poll.FieldExpiresAt, poll.FieldCreatedAt, poll.FieldUpdatedAt:
+		case poll.FieldOpensAt, poll.FieldExpiresAt, poll.FieldClosedAt, poll.FieldNextOccurrenceAt, poll.FieldCreatedAt, poll.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
+		case poll.ForeignKeys[0]: // poll_occurrences
+			values[i] = new(sql.NullInt64)
+		case poll.ForeignKeys[1]: // user_polls
+			values[i] = new(sql.NullInt64)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -130,12 +218,103 @@ func (_m *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.MaxVotesPerUser = int(value.Int64)
 			}
+		case poll.FieldRatingMin:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rating_min", values[i])
+			} else if value.Valid {
+				_m.RatingMin = new(int)
+				*_m.RatingMin = int(value.Int64)
+			}
+		case poll.FieldRatingMax:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rating_max", values[i])
+			} else if value.Valid {
+				_m.RatingMax = new(int)
+				*_m.RatingMax = int(value.Int64)
+			}
+		case poll.FieldAllowVoteChanges:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field allow_vote_changes", values[i])
+			} else if value.Valid {
+				_m.AllowVoteChanges = value.Bool
+			}
+		case poll.FieldOpensAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field opens_at", values[i])
+			} else if value.Valid {
+				_m.OpensAt = new(time.Time)
+				*_m.OpensAt = value.Time
+			}
 		case poll.FieldExpiresAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
 			} else if value.Valid {
 				_m.ExpiresAt = value.Time
 			}
+		case poll.FieldState:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field state", values[i])
+			} else if value.Valid {
+				_m.State = value.String
+			}
+		case poll.FieldClosedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closed_at", values[i])
+			} else if value.Valid {
+				_m.ClosedAt = new(time.Time)
+				*_m.ClosedAt = value.Time
+			}
+		case poll.FieldVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field visibility", values[i])
+			} else if value.Valid {
+				_m.Visibility = value.String
+			}
+		case poll.FieldResultsVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field results_visibility", values[i])
+			} else if value.Valid {
+				_m.ResultsVisibility = value.String
+			}
+		case poll.FieldInvitedEmails:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field invited_emails", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.InvitedEmails); err != nil {
+					return fmt.Errorf("unmarshal field invited_emails: %w", err)
+				}
+			}
+		case poll.FieldRecurrence:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field recurrence", values[i])
+			} else if value.Valid {
+				_m.Recurrence = value.String
+			}
+		case poll.FieldRecurrenceTimezone:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field recurrence_timezone", values[i])
+			} else if value.Valid {
+				_m.RecurrenceTimezone = value.String
+			}
+		case poll.FieldNextOccurrenceAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field next_occurrence_at", values[i])
+			} else if value.Valid {
+				_m.NextOccurrenceAt = new(time.Time)
+				*_m.NextOccurrenceAt = value.Time
+			}
+		case poll.FieldSearchText:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field search_text", values[i])
+			} else if value.Valid {
+				_m.SearchText = value.String
+			}
+		case poll.FieldTotalVotes:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field total_votes", values[i])
+			} else if value.Valid {
+				_m.TotalVotes = int(value.Int64)
+			}
 		case poll.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -148,6 +327,20 @@ func (_m *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.UpdatedAt = value.Time
 			}
+		case poll.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field poll_occurrences", value)
+			} else if value.Valid {
+				_m.poll_occurrences = new(int)
+				*_m.poll_occurrences = int(value.Int64)
+			}
+		case poll.ForeignKeys[1]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_polls", value)
+			} else if value.Valid {
+				_m.user_polls = new(int)
+				*_m.user_polls = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -171,6 +364,26 @@ func (_m *Poll) QueryVotes() *VoteQuery {
 	return NewPollClient(_m.config).QueryVotes(_m)
 }
 
+// QueryInvites queries the "invites" edge of the Poll entity.
+func (_m *Poll) QueryInvites() *PollInviteQuery {
+	return NewPollClient(_m.config).QueryInvites(_m)
+}
+
+// QueryTemplate queries the "template" edge of the Poll entity.
+func (_m *Poll) QueryTemplate() *PollQuery {
+	return NewPollClient(_m.config).QueryTemplate(_m)
+}
+
+// QueryOccurrences queries the "occurrences" edge of the Poll entity.
+func (_m *Poll) QueryOccurrences() *PollQuery {
+	return NewPollClient(_m.config).QueryOccurrences(_m)
+}
+
+// QueryOwner queries the "owner" edge of the Poll entity.
+func (_m *Poll) QueryOwner() *UserQuery {
+	return NewPollClient(_m.config).QueryOwner(_m)
+}
+
 // Update returns a builder for updating this Poll.
 // Note that you need to call Poll.Unwrap() before calling this method if this Poll
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -209,9 +422,61 @@ func (_m *Poll) String() string {
 	builder.WriteString("max_votes_per_user=")
 	builder.WriteString(fmt.Sprintf("%v", _m.MaxVotesPerUser))
 	builder.WriteString(", ")
+	if v := _m.RatingMin; v != nil {
+		builder.WriteString("rating_min=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.RatingMax; v != nil {
+		builder.WriteString("rating_max=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("allow_vote_changes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.AllowVoteChanges))
+	builder.WriteString(", ")
+	if v := _m.OpensAt; v != nil {
+		builder.WriteString("opens_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("expires_at=")
 	builder.WriteString(_m.ExpiresAt.Format(time.ANSIC))
 	builder.WriteString(", ")
+	builder.WriteString("state=")
+	builder.WriteString(_m.State)
+	builder.WriteString(", ")
+	if v := _m.ClosedAt; v != nil {
+		builder.WriteString("closed_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("visibility=")
+	builder.WriteString(_m.Visibility)
+	builder.WriteString(", ")
+	builder.WriteString("results_visibility=")
+	builder.WriteString(_m.ResultsVisibility)
+	builder.WriteString(", ")
+	builder.WriteString("invited_emails=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InvitedEmails))
+	builder.WriteString(", ")
+	builder.WriteString("recurrence=")
+	builder.WriteString(_m.Recurrence)
+	builder.WriteString(", ")
+	builder.WriteString("recurrence_timezone=")
+	builder.WriteString(_m.RecurrenceTimezone)
+	builder.WriteString(", ")
+	if v := _m.NextOccurrenceAt; v != nil {
+		builder.WriteString("next_occurrence_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("search_text=")
+	builder.WriteString(_m.SearchText)
+	builder.WriteString(", ")
+	builder.WriteString("total_votes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotalVotes))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -24,8 +24,36 @@ const (
 	FieldCreatedBy = "created_by"
 	// FieldMaxVotesPerUser holds the string denoting the max_votes_per_user field in the database.
 	FieldMaxVotesPerUser = "max_votes_per_user"
+	// FieldRatingMin holds the string denoting the rating_min field in the database.
+	FieldRatingMin = "rating_min"
+	// FieldRatingMax holds the string denoting the rating_max field in the database.
+	FieldRatingMax = "rating_max"
+	// FieldAllowVoteChanges holds the string denoting the allow_vote_changes field in the database.
+	FieldAllowVoteChanges = "allow_vote_changes"
+	// FieldOpensAt holds the string denoting the opens_at field in the database.
+	FieldOpensAt = "opens_at"
 	// FieldExpiresAt holds the string denoting the expires_at field in the database.
 	FieldExpiresAt = "expires_at"
+	// FieldState holds the string denoting the state field in the database.
+	FieldState = "state"
+	// FieldClosedAt holds the string denoting the closed_at field in the database.
+	FieldClosedAt = "closed_at"
+	// FieldVisibility holds the string denoting the visibility field in the database.
+	FieldVisibility = "visibility"
+	// FieldResultsVisibility holds the string denoting the results_visibility field in the database.
+	FieldResultsVisibility = "results_visibility"
+	// FieldInvitedEmails holds the string denoting the invited_emails field in the database.
+	FieldInvitedEmails = "invited_emails"
+	// FieldRecurrence holds the string denoting the recurrence field in the database.
+	FieldRecurrence = "recurrence"
+	// FieldRecurrenceTimezone holds the string denoting the recurrence_timezone field in the database.
+	FieldRecurrenceTimezone = "recurrence_timezone"
+	// FieldNextOccurrenceAt holds the string denoting the next_occurrence_at field in the database.
+	FieldNextOccurrenceAt = "next_occurrence_at"
+	// FieldSearchText holds the string denoting the search_text field in the database.
+	FieldSearchText = "search_text"
+	// FieldTotalVotes holds the string denoting the total_votes field in the database.
+	FieldTotalVotes = "total_votes"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
@@ -34,6 +62,14 @@ const (
 	EdgeOptions = "options"
 	// EdgeVotes holds the string denoting the votes edge name in mutations.
 	EdgeVotes = "votes"
+	// EdgeInvites holds the string denoting the invites edge name in mutations.
+	EdgeInvites = "invites"
+	// EdgeTemplate holds the string denoting the template edge name in mutations.
+	EdgeTemplate = "template"
+	// EdgeOccurrences holds the string denoting the occurrences edge name in mutations.
+	EdgeOccurrences = "occurrences"
+	// EdgeOwner holds the string denoting the owner edge name in mutations.
+	EdgeOwner = "owner"
 	// Table holds the table name of the poll in the database.
 	Table = "polls"
 	// OptionsTable is the table that holds the options relation/edge.
@@ -50,6 +86,28 @@ const (
 	VotesInverseTable = "votes"
 	// VotesColumn is the table column denoting the votes relation/edge.
 	VotesColumn = "poll_votes"
+	// InvitesTable is the table that holds the invites relation/edge.
+	InvitesTable = "poll_invites"
+	// InvitesInverseTable is the table name for the PollInvite entity.
+	// It exists in this package in order to avoid circular dependency with the "pollinvite" package.
+	InvitesInverseTable = "poll_invites"
+	// InvitesColumn is the table column denoting the invites relation/edge.
+	InvitesColumn = "poll_invites"
+	// TemplateTable is the table that holds the template relation/edge.
+	TemplateTable = "polls"
+	// TemplateColumn is the table column denoting the template relation/edge.
+	TemplateColumn = "poll_occurrences"
+	// OccurrencesTable is the table that holds the occurrences relation/edge.
+	OccurrencesTable = "polls"
+	// OccurrencesColumn is the table column denoting the occurrences relation/edge.
+	OccurrencesColumn = "poll_occurrences"
+	// OwnerTable is the table that holds the owner relation/edge.
+	OwnerTable = "polls"
+	// OwnerInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	OwnerInverseTable = "users"
+	// OwnerColumn is the table column denoting the owner relation/edge.
+	OwnerColumn = "user_polls"
 )
 
 // Columns holds all SQL columns for poll fields.
@@ -60,11 +118,32 @@ var Columns = []string{
 	FieldPollType,
 	FieldCreatedBy,
 	FieldMaxVotesPerUser,
+	FieldRatingMin,
+	FieldRatingMax,
+	FieldAllowVoteChanges,
+	FieldOpensAt,
 	FieldExpiresAt,
+	FieldState,
+	FieldClosedAt,
+	FieldVisibility,
+	FieldResultsVisibility,
+	FieldInvitedEmails,
+	FieldRecurrence,
+	FieldRecurrenceTimezone,
+	FieldNextOccurrenceAt,
+	FieldSearchText,
+	FieldTotalVotes,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
 
+// ForeignKeys holds the SQL foreign-keys that are owned by the "polls"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"poll_occurrences",
+	"user_polls",
+}
+
 // ValidColumn reports if the column name is valid (part of the table columns).
 func ValidColumn(column string) bool {
 	for i := range Columns {
@@ -72,6 +151,11 @@ func ValidColumn(column string) bool {
 			return true
 		}
 	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
 	return false
 }
 
@@ -82,6 +166,18 @@ var (
 	DefaultPollType string
 	// DefaultMaxVotesPerUser holds the default value on creation for the "max_votes_per_user" field.
 	DefaultMaxVotesPerUser int
+	// DefaultAllowVoteChanges holds the default value on creation for the "allow_vote_changes" field.
+	DefaultAllowVoteChanges bool
+	// DefaultState holds the default value on creation for the "state" field.
+	DefaultState string
+	// DefaultVisibility holds the default value on creation for the "visibility" field.
+	DefaultVisibility string
+	// DefaultResultsVisibility holds the default value on creation for the "results_visibility" field.
+	DefaultResultsVisibility string
+	// DefaultTotalVotes holds the default value on creation for the "total_votes" field.
+	DefaultTotalVotes int
+	// TotalVotesValidator is a validator for the "total_votes" field. It is called by the builders before save.
+	TotalVotesValidator func(int) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
@@ -123,11 +219,76 @@ func ByMaxVotesPerUser(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldMaxVotesPerUser, opts...).ToFunc()
 }
 
+// ByRatingMin orders the results by the rating_min field.
+func ByRatingMin(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRatingMin, opts...).ToFunc()
+}
+
+// ByRatingMax orders the results by the rating_max field.
+func ByRatingMax(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRatingMax, opts...).ToFunc()
+}
+
+// ByAllowVoteChanges orders the results by the allow_vote_changes field.
+func ByAllowVoteChanges(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAllowVoteChanges, opts...).ToFunc()
+}
+
+// ByOpensAt orders the results by the opens_at field.
+func ByOpensAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOpensAt, opts...).ToFunc()
+}
+
 // ByExpiresAt orders the results by the expires_at field.
 func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
 }
 
+// ByState orders the results by the state field.
+func ByState(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldState, opts...).ToFunc()
+}
+
+// ByClosedAt orders the results by the closed_at field.
+func ByClosedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosedAt, opts...).ToFunc()
+}
+
+// ByVisibility orders the results by the visibility field.
+func ByVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVisibility, opts...).ToFunc()
+}
+
+// ByResultsVisibility orders the results by the results_visibility field.
+func ByResultsVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsVisibility, opts...).ToFunc()
+}
+
+// ByRecurrence orders the results by the recurrence field.
+func ByRecurrence(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRecurrence, opts...).ToFunc()
+}
+
+// ByRecurrenceTimezone orders the results by the recurrence_timezone field.
+func ByRecurrenceTimezone(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRecurrenceTimezone, opts...).ToFunc()
+}
+
+// ByNextOccurrenceAt orders the results by the next_occurrence_at field.
+func ByNextOccurrenceAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNextOccurrenceAt, opts...).ToFunc()
+}
+
+// BySearchText orders the results by the search_text field.
+func BySearchText(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSearchText, opts...).ToFunc()
+}
+
+// ByTotalVotes orders the results by the total_votes field.
+func ByTotalVotes(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTotalVotes, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -165,6 +326,48 @@ func ByVotes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newVotesStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByInvitesCount orders the results by invites count.
+func ByInvitesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInvitesStep(), opts...)
+	}
+}
+
+// ByInvites orders the results by invites terms.
+func ByInvites(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInvitesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTemplateField orders the results by template field.
+func ByTemplateField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTemplateStep(), sql.OrderByField(field, opts...))
+	}
+}
+
+// ByOccurrencesCount orders the results by occurrences count.
+func ByOccurrencesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOccurrencesStep(), opts...)
+	}
+}
+
+// ByOccurrences orders the results by occurrences terms.
+func ByOccurrences(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOccurrencesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByOwnerField orders the results by owner field.
+func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOwnerStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newOptionsStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -179,3 +382,31 @@ func newVotesStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, VotesTable, VotesColumn),
 	)
 }
+func newInvitesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InvitesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, InvitesTable, InvitesColumn),
+	)
+}
+func newTemplateStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(Table, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, TemplateTable, TemplateColumn),
+	)
+}
+func newOccurrencesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(Table, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, OccurrencesTable, OccurrencesColumn),
+	)
+}
+func newOwnerStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OwnerInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+	)
+}
@@ -80,11 +80,76 @@ func MaxVotesPerUser(v int) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldMaxVotesPerUser, v))
 }
 
+// RatingMin applies equality check predicate on the "rating_min" field. It's identical to RatingMinEQ.
+func RatingMin(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRatingMin, v))
+}
+
+// RatingMax applies equality check predicate on the "rating_max" field. It's identical to RatingMaxEQ.
+func RatingMax(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRatingMax, v))
+}
+
+// AllowVoteChanges applies equality check predicate on the "allow_vote_changes" field. It's identical to AllowVoteChangesEQ.
+func AllowVoteChanges(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowVoteChanges, v))
+}
+
+// OpensAt applies equality check predicate on the "opens_at" field. It's identical to OpensAtEQ.
+func OpensAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
 // ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
 func ExpiresAt(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldExpiresAt, v))
 }
 
+// State applies equality check predicate on the "state" field. It's identical to StateEQ.
+func State(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldState, v))
+}
+
+// ClosedAt applies equality check predicate on the "closed_at" field. It's identical to ClosedAtEQ.
+func ClosedAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosedAt, v))
+}
+
+// Visibility applies equality check predicate on the "visibility" field. It's identical to VisibilityEQ.
+func Visibility(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVisibility, v))
+}
+
+// ResultsVisibility applies equality check predicate on the "results_visibility" field. It's identical to ResultsVisibilityEQ.
+func ResultsVisibility(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibility, v))
+}
+
+// Recurrence applies equality check predicate on the "recurrence" field. It's identical to RecurrenceEQ.
+func Recurrence(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRecurrence, v))
+}
+
+// RecurrenceTimezone applies equality check predicate on the "recurrence_timezone" field. It's identical to RecurrenceTimezoneEQ.
+func RecurrenceTimezone(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRecurrenceTimezone, v))
+}
+
+// NextOccurrenceAt applies equality check predicate on the "next_occurrence_at" field. It's identical to NextOccurrenceAtEQ.
+func NextOccurrenceAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldNextOccurrenceAt, v))
+}
+
+// SearchText applies equality check predicate on the "search_text" field. It's identical to SearchTextEQ.
+func SearchText(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldSearchText, v))
+}
+
+// TotalVotes applies equality check predicate on the "total_votes" field. It's identical to TotalVotesEQ.
+func TotalVotes(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTotalVotes, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
@@ -415,6 +480,166 @@ func MaxVotesPerUserLTE(v int) predicate.Poll {
 	return predicate.Poll(sql.FieldLTE(FieldMaxVotesPerUser, v))
 }
 
+// RatingMinEQ applies the EQ predicate on the "rating_min" field.
+func RatingMinEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRatingMin, v))
+}
+
+// RatingMinNEQ applies the NEQ predicate on the "rating_min" field.
+func RatingMinNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldRatingMin, v))
+}
+
+// RatingMinIn applies the In predicate on the "rating_min" field.
+func RatingMinIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldRatingMin, vs...))
+}
+
+// RatingMinNotIn applies the NotIn predicate on the "rating_min" field.
+func RatingMinNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldRatingMin, vs...))
+}
+
+// RatingMinGT applies the GT predicate on the "rating_min" field.
+func RatingMinGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldRatingMin, v))
+}
+
+// RatingMinGTE applies the GTE predicate on the "rating_min" field.
+func RatingMinGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldRatingMin, v))
+}
+
+// RatingMinLT applies the LT predicate on the "rating_min" field.
+func RatingMinLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldRatingMin, v))
+}
+
+// RatingMinLTE applies the LTE predicate on the "rating_min" field.
+func RatingMinLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldRatingMin, v))
+}
+
+// RatingMinIsNil applies the IsNil predicate on the "rating_min" field.
+func RatingMinIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldRatingMin))
+}
+
+// RatingMinNotNil applies the NotNil predicate on the "rating_min" field.
+func RatingMinNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldRatingMin))
+}
+
+// RatingMaxEQ applies the EQ predicate on the "rating_max" field.
+func RatingMaxEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRatingMax, v))
+}
+
+// RatingMaxNEQ applies the NEQ predicate on the "rating_max" field.
+func RatingMaxNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldRatingMax, v))
+}
+
+// RatingMaxIn applies the In predicate on the "rating_max" field.
+func RatingMaxIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldRatingMax, vs...))
+}
+
+// RatingMaxNotIn applies the NotIn predicate on the "rating_max" field.
+func RatingMaxNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldRatingMax, vs...))
+}
+
+// RatingMaxGT applies the GT predicate on the "rating_max" field.
+func RatingMaxGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldRatingMax, v))
+}
+
+// RatingMaxGTE applies the GTE predicate on the "rating_max" field.
+func RatingMaxGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldRatingMax, v))
+}
+
+// RatingMaxLT applies the LT predicate on the "rating_max" field.
+func RatingMaxLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldRatingMax, v))
+}
+
+// RatingMaxLTE applies the LTE predicate on the "rating_max" field.
+func RatingMaxLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldRatingMax, v))
+}
+
+// RatingMaxIsNil applies the IsNil predicate on the "rating_max" field.
+func RatingMaxIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldRatingMax))
+}
+
+// RatingMaxNotNil applies the NotNil predicate on the "rating_max" field.
+func RatingMaxNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldRatingMax))
+}
+
+// AllowVoteChangesEQ applies the EQ predicate on the "allow_vote_changes" field.
+func AllowVoteChangesEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAllowVoteChanges, v))
+}
+
+// AllowVoteChangesNEQ applies the NEQ predicate on the "allow_vote_changes" field.
+func AllowVoteChangesNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAllowVoteChanges, v))
+}
+
+// OpensAtEQ applies the EQ predicate on the "opens_at" field.
+func OpensAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// OpensAtNEQ applies the NEQ predicate on the "opens_at" field.
+func OpensAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOpensAt, v))
+}
+
+// OpensAtIn applies the In predicate on the "opens_at" field.
+func OpensAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOpensAt, vs...))
+}
+
+// OpensAtNotIn applies the NotIn predicate on the "opens_at" field.
+func OpensAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOpensAt, vs...))
+}
+
+// OpensAtGT applies the GT predicate on the "opens_at" field.
+func OpensAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOpensAt, v))
+}
+
+// OpensAtGTE applies the GTE predicate on the "opens_at" field.
+func OpensAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOpensAt, v))
+}
+
+// OpensAtLT applies the LT predicate on the "opens_at" field.
+func OpensAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOpensAt, v))
+}
+
+// OpensAtLTE applies the LTE predicate on the "opens_at" field.
+func OpensAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOpensAt, v))
+}
+
+// OpensAtIsNil applies the IsNil predicate on the "opens_at" field.
+func OpensAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOpensAt))
+}
+
+// OpensAtNotNil applies the NotNil predicate on the "opens_at" field.
+func OpensAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOpensAt))
+}
+
 // ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
 func ExpiresAtEQ(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldExpiresAt, v))
@@ -465,6 +690,576 @@ func ExpiresAtNotNil() predicate.Poll {
 	return predicate.Poll(sql.FieldNotNull(FieldExpiresAt))
 }
 
+// StateEQ applies the EQ predicate on the "state" field.
+func StateEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldState, v))
+}
+
+// StateNEQ applies the NEQ predicate on the "state" field.
+func StateNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldState, v))
+}
+
+// StateIn applies the In predicate on the "state" field.
+func StateIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldState, vs...))
+}
+
+// StateNotIn applies the NotIn predicate on the "state" field.
+func StateNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldState, vs...))
+}
+
+// StateGT applies the GT predicate on the "state" field.
+func StateGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldState, v))
+}
+
+// StateGTE applies the GTE predicate on the "state" field.
+func StateGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldState, v))
+}
+
+// StateLT applies the LT predicate on the "state" field.
+func StateLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldState, v))
+}
+
+// StateLTE applies the LTE predicate on the "state" field.
+func StateLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldState, v))
+}
+
+// StateContains applies the Contains predicate on the "state" field.
+func StateContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldState, v))
+}
+
+// StateHasPrefix applies the HasPrefix predicate on the "state" field.
+func StateHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldState, v))
+}
+
+// StateHasSuffix applies the HasSuffix predicate on the "state" field.
+func StateHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldState, v))
+}
+
+// StateEqualFold applies the EqualFold predicate on the "state" field.
+func StateEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldState, v))
+}
+
+// StateContainsFold applies the ContainsFold predicate on the "state" field.
+func StateContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldState, v))
+}
+
+// ClosedAtEQ applies the EQ predicate on the "closed_at" field.
+func ClosedAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosedAt, v))
+}
+
+// ClosedAtNEQ applies the NEQ predicate on the "closed_at" field.
+func ClosedAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosedAt, v))
+}
+
+// ClosedAtIn applies the In predicate on the "closed_at" field.
+func ClosedAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosedAt, vs...))
+}
+
+// ClosedAtNotIn applies the NotIn predicate on the "closed_at" field.
+func ClosedAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosedAt, vs...))
+}
+
+// ClosedAtGT applies the GT predicate on the "closed_at" field.
+func ClosedAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosedAt, v))
+}
+
+// ClosedAtGTE applies the GTE predicate on the "closed_at" field.
+func ClosedAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosedAt, v))
+}
+
+// ClosedAtLT applies the LT predicate on the "closed_at" field.
+func ClosedAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosedAt, v))
+}
+
+// ClosedAtLTE applies the LTE predicate on the "closed_at" field.
+func ClosedAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosedAt, v))
+}
+
+// ClosedAtIsNil applies the IsNil predicate on the "closed_at" field.
+func ClosedAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosedAt))
+}
+
+// ClosedAtNotNil applies the NotNil predicate on the "closed_at" field.
+func ClosedAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosedAt))
+}
+
+// VisibilityEQ applies the EQ predicate on the "visibility" field.
+func VisibilityEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVisibility, v))
+}
+
+// VisibilityNEQ applies the NEQ predicate on the "visibility" field.
+func VisibilityNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldVisibility, v))
+}
+
+// VisibilityIn applies the In predicate on the "visibility" field.
+func VisibilityIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldVisibility, vs...))
+}
+
+// VisibilityNotIn applies the NotIn predicate on the "visibility" field.
+func VisibilityNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldVisibility, vs...))
+}
+
+// VisibilityGT applies the GT predicate on the "visibility" field.
+func VisibilityGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldVisibility, v))
+}
+
+// VisibilityGTE applies the GTE predicate on the "visibility" field.
+func VisibilityGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldVisibility, v))
+}
+
+// VisibilityLT applies the LT predicate on the "visibility" field.
+func VisibilityLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldVisibility, v))
+}
+
+// VisibilityLTE applies the LTE predicate on the "visibility" field.
+func VisibilityLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldVisibility, v))
+}
+
+// VisibilityContains applies the Contains predicate on the "visibility" field.
+func VisibilityContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldVisibility, v))
+}
+
+// VisibilityHasPrefix applies the HasPrefix predicate on the "visibility" field.
+func VisibilityHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldVisibility, v))
+}
+
+// VisibilityHasSuffix applies the HasSuffix predicate on the "visibility" field.
+func VisibilityHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldVisibility, v))
+}
+
+// VisibilityEqualFold applies the EqualFold predicate on the "visibility" field.
+func VisibilityEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldVisibility, v))
+}
+
+// VisibilityContainsFold applies the ContainsFold predicate on the "visibility" field.
+func VisibilityContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldVisibility, v))
+}
+
+// ResultsVisibilityEQ applies the EQ predicate on the "results_visibility" field.
+func ResultsVisibilityEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityNEQ applies the NEQ predicate on the "results_visibility" field.
+func ResultsVisibilityNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityIn applies the In predicate on the "results_visibility" field.
+func ResultsVisibilityIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibilityNotIn applies the NotIn predicate on the "results_visibility" field.
+func ResultsVisibilityNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibilityGT applies the GT predicate on the "results_visibility" field.
+func ResultsVisibilityGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityGTE applies the GTE predicate on the "results_visibility" field.
+func ResultsVisibilityGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityLT applies the LT predicate on the "results_visibility" field.
+func ResultsVisibilityLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityLTE applies the LTE predicate on the "results_visibility" field.
+func ResultsVisibilityLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityContains applies the Contains predicate on the "results_visibility" field.
+func ResultsVisibilityContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityHasPrefix applies the HasPrefix predicate on the "results_visibility" field.
+func ResultsVisibilityHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityHasSuffix applies the HasSuffix predicate on the "results_visibility" field.
+func ResultsVisibilityHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityEqualFold applies the EqualFold predicate on the "results_visibility" field.
+func ResultsVisibilityEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityContainsFold applies the ContainsFold predicate on the "results_visibility" field.
+func ResultsVisibilityContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldResultsVisibility, v))
+}
+
+// InvitedEmailsIsNil applies the IsNil predicate on the "invited_emails" field.
+func InvitedEmailsIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldInvitedEmails))
+}
+
+// InvitedEmailsNotNil applies the NotNil predicate on the "invited_emails" field.
+func InvitedEmailsNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldInvitedEmails))
+}
+
+// RecurrenceEQ applies the EQ predicate on the "recurrence" field.
+func RecurrenceEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRecurrence, v))
+}
+
+// RecurrenceNEQ applies the NEQ predicate on the "recurrence" field.
+func RecurrenceNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldRecurrence, v))
+}
+
+// RecurrenceIn applies the In predicate on the "recurrence" field.
+func RecurrenceIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldRecurrence, vs...))
+}
+
+// RecurrenceNotIn applies the NotIn predicate on the "recurrence" field.
+func RecurrenceNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldRecurrence, vs...))
+}
+
+// RecurrenceGT applies the GT predicate on the "recurrence" field.
+func RecurrenceGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldRecurrence, v))
+}
+
+// RecurrenceGTE applies the GTE predicate on the "recurrence" field.
+func RecurrenceGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldRecurrence, v))
+}
+
+// RecurrenceLT applies the LT predicate on the "recurrence" field.
+func RecurrenceLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldRecurrence, v))
+}
+
+// RecurrenceLTE applies the LTE predicate on the "recurrence" field.
+func RecurrenceLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldRecurrence, v))
+}
+
+// RecurrenceContains applies the Contains predicate on the "recurrence" field.
+func RecurrenceContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldRecurrence, v))
+}
+
+// RecurrenceHasPrefix applies the HasPrefix predicate on the "recurrence" field.
+func RecurrenceHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldRecurrence, v))
+}
+
+// RecurrenceHasSuffix applies the HasSuffix predicate on the "recurrence" field.
+func RecurrenceHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldRecurrence, v))
+}
+
+// RecurrenceIsNil applies the IsNil predicate on the "recurrence" field.
+func RecurrenceIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldRecurrence))
+}
+
+// RecurrenceNotNil applies the NotNil predicate on the "recurrence" field.
+func RecurrenceNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldRecurrence))
+}
+
+// RecurrenceEqualFold applies the EqualFold predicate on the "recurrence" field.
+func RecurrenceEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldRecurrence, v))
+}
+
+// RecurrenceContainsFold applies the ContainsFold predicate on the "recurrence" field.
+func RecurrenceContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldRecurrence, v))
+}
+
+// RecurrenceTimezoneEQ applies the EQ predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneNEQ applies the NEQ predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneIn applies the In predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldRecurrenceTimezone, vs...))
+}
+
+// RecurrenceTimezoneNotIn applies the NotIn predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldRecurrenceTimezone, vs...))
+}
+
+// RecurrenceTimezoneGT applies the GT predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneGTE applies the GTE predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneLT applies the LT predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneLTE applies the LTE predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneContains applies the Contains predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneHasPrefix applies the HasPrefix predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneHasSuffix applies the HasSuffix predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneIsNil applies the IsNil predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldRecurrenceTimezone))
+}
+
+// RecurrenceTimezoneNotNil applies the NotNil predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldRecurrenceTimezone))
+}
+
+// RecurrenceTimezoneEqualFold applies the EqualFold predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldRecurrenceTimezone, v))
+}
+
+// RecurrenceTimezoneContainsFold applies the ContainsFold predicate on the "recurrence_timezone" field.
+func RecurrenceTimezoneContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldRecurrenceTimezone, v))
+}
+
+// NextOccurrenceAtEQ applies the EQ predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtNEQ applies the NEQ predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtIn applies the In predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldNextOccurrenceAt, vs...))
+}
+
+// NextOccurrenceAtNotIn applies the NotIn predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldNextOccurrenceAt, vs...))
+}
+
+// NextOccurrenceAtGT applies the GT predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtGTE applies the GTE predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtLT applies the LT predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtLTE applies the LTE predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldNextOccurrenceAt, v))
+}
+
+// NextOccurrenceAtIsNil applies the IsNil predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldNextOccurrenceAt))
+}
+
+// NextOccurrenceAtNotNil applies the NotNil predicate on the "next_occurrence_at" field.
+func NextOccurrenceAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldNextOccurrenceAt))
+}
+
+// SearchTextEQ applies the EQ predicate on the "search_text" field.
+func SearchTextEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldSearchText, v))
+}
+
+// SearchTextNEQ applies the NEQ predicate on the "search_text" field.
+func SearchTextNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldSearchText, v))
+}
+
+// SearchTextIn applies the In predicate on the "search_text" field.
+func SearchTextIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldSearchText, vs...))
+}
+
+// SearchTextNotIn applies the NotIn predicate on the "search_text" field.
+func SearchTextNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldSearchText, vs...))
+}
+
+// SearchTextGT applies the GT predicate on the "search_text" field.
+func SearchTextGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldSearchText, v))
+}
+
+// SearchTextGTE applies the GTE predicate on the "search_text" field.
+func SearchTextGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldSearchText, v))
+}
+
+// SearchTextLT applies the LT predicate on the "search_text" field.
+func SearchTextLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldSearchText, v))
+}
+
+// SearchTextLTE applies the LTE predicate on the "search_text" field.
+func SearchTextLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldSearchText, v))
+}
+
+// SearchTextContains applies the Contains predicate on the "search_text" field.
+func SearchTextContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldSearchText, v))
+}
+
+// SearchTextHasPrefix applies the HasPrefix predicate on the "search_text" field.
+func SearchTextHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldSearchText, v))
+}
+
+// SearchTextHasSuffix applies the HasSuffix predicate on the "search_text" field.
+func SearchTextHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldSearchText, v))
+}
+
+// SearchTextIsNil applies the IsNil predicate on the "search_text" field.
+func SearchTextIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldSearchText))
+}
+
+// SearchTextNotNil applies the NotNil predicate on the "search_text" field.
+func SearchTextNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldSearchText))
+}
+
+// SearchTextEqualFold applies the EqualFold predicate on the "search_text" field.
+func SearchTextEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldSearchText, v))
+}
+
+// SearchTextContainsFold applies the ContainsFold predicate on the "search_text" field.
+func SearchTextContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldSearchText, v))
+}
+
+// TotalVotesEQ applies the EQ predicate on the "total_votes" field.
+func TotalVotesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTotalVotes, v))
+}
+
+// TotalVotesNEQ applies the NEQ predicate on the "total_votes" field.
+func TotalVotesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldTotalVotes, v))
+}
+
+// TotalVotesIn applies the In predicate on the "total_votes" field.
+func TotalVotesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldTotalVotes, vs...))
+}
+
+// TotalVotesNotIn applies the NotIn predicate on the "total_votes" field.
+func TotalVotesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldTotalVotes, vs...))
+}
+
+// TotalVotesGT applies the GT predicate on the "total_votes" field.
+func TotalVotesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldTotalVotes, v))
+}
+
+// TotalVotesGTE applies the GTE predicate on the "total_votes" field.
+func TotalVotesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldTotalVotes, v))
+}
+
+// TotalVotesLT applies the LT predicate on the "total_votes" field.
+func TotalVotesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldTotalVotes, v))
+}
+
+// TotalVotesLTE applies the LTE predicate on the "total_votes" field.
+func TotalVotesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldTotalVotes, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
@@ -591,6 +1386,98 @@ func HasVotesWith(preds ...predicate.Vote) predicate.Poll {
 	})
 }
 
+// HasInvites applies the HasEdge predicate on the "invites" edge.
+func HasInvites() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, InvitesTable, InvitesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInvitesWith applies the HasEdge predicate on the "invites" edge with a given conditions (other predicates).
+func HasInvitesWith(preds ...predicate.PollInvite) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInvitesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasTemplate applies the HasEdge predicate on the "template" edge.
+func HasTemplate() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, TemplateTable, TemplateColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTemplateWith applies the HasEdge predicate on the "template" edge with a given conditions (other predicates).
+func HasTemplateWith(preds ...predicate.Poll) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newTemplateStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOccurrences applies the HasEdge predicate on the "occurrences" edge.
+func HasOccurrences() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, OccurrencesTable, OccurrencesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOccurrencesWith applies the HasEdge predicate on the "occurrences" edge with a given conditions (other predicates).
+func HasOccurrencesWith(preds ...predicate.Poll) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newOccurrencesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOwner applies the HasEdge predicate on the "owner" edge.
+func HasOwner() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, OwnerTable, OwnerColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOwnerWith applies the HasEdge predicate on the "owner" edge with a given conditions (other predicates).
+func HasOwnerWith(preds ...predicate.User) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newOwnerStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Poll) predicate.Poll {
 	return predicate.Poll(sql.AndPredicates(predicates...))
@@ -4,7 +4,9 @@ package ent
 
 import (
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"errors"
@@ -84,6 +86,62 @@ func (_c *PollCreate) SetNillableMaxVotesPerUser(v *int) *PollCreate {
 	return _c
 }
 
+// SetRatingMin sets the "rating_min" field.
+func (_c *PollCreate) SetRatingMin(v int) *PollCreate {
+	_c.mutation.SetRatingMin(v)
+	return _c
+}
+
+// SetNillableRatingMin sets the "rating_min" field if the given value is not nil.
+func (_c *PollCreate) SetNillableRatingMin(v *int) *PollCreate {
+	if v != nil {
+		_c.SetRatingMin(*v)
+	}
+	return _c
+}
+
+// SetRatingMax sets the "rating_max" field.
+func (_c *PollCreate) SetRatingMax(v int) *PollCreate {
+	_c.mutation.SetRatingMax(v)
+	return _c
+}
+
+// SetNillableRatingMax sets the "rating_max" field if the given value is not nil.
+func (_c *PollCreate) SetNillableRatingMax(v *int) *PollCreate {
+	if v != nil {
+		_c.SetRatingMax(*v)
+	}
+	return _c
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (_c *PollCreate) SetAllowVoteChanges(v bool) *PollCreate {
+	_c.mutation.SetAllowVoteChanges(v)
+	return _c
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (_c *PollCreate) SetNillableAllowVoteChanges(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetAllowVoteChanges(*v)
+	}
+	return _c
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_c *PollCreate) SetOpensAt(v time.Time) *PollCreate {
+	_c.mutation.SetOpensAt(v)
+	return _c
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableOpensAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetOpensAt(*v)
+	}
+	return _c
+}
+
 // SetExpiresAt sets the "expires_at" field.
 func (_c *PollCreate) SetExpiresAt(v time.Time) *PollCreate {
 	_c.mutation.SetExpiresAt(v)
@@ -98,6 +156,138 @@ func (_c *PollCreate) SetNillableExpiresAt(v *time.Time) *PollCreate {
 	return _c
 }
 
+// SetState sets the "state" field.
+func (_c *PollCreate) SetState(v string) *PollCreate {
+	_c.mutation.SetState(v)
+	return _c
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (_c *PollCreate) SetNillableState(v *string) *PollCreate {
+	if v != nil {
+		_c.SetState(*v)
+	}
+	return _c
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (_c *PollCreate) SetClosedAt(v time.Time) *PollCreate {
+	_c.mutation.SetClosedAt(v)
+	return _c
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableClosedAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetClosedAt(*v)
+	}
+	return _c
+}
+
+// SetVisibility sets the "visibility" field.
+func (_c *PollCreate) SetVisibility(v string) *PollCreate {
+	_c.mutation.SetVisibility(v)
+	return _c
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (_c *PollCreate) SetNillableVisibility(v *string) *PollCreate {
+	if v != nil {
+		_c.SetVisibility(*v)
+	}
+	return _c
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_c *PollCreate) SetResultsVisibility(v string) *PollCreate {
+	_c.mutation.SetResultsVisibility(v)
+	return _c
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_c *PollCreate) SetNillableResultsVisibility(v *string) *PollCreate {
+	if v != nil {
+		_c.SetResultsVisibility(*v)
+	}
+	return _c
+}
+
+// SetInvitedEmails sets the "invited_emails" field.
+func (_c *PollCreate) SetInvitedEmails(v []string) *PollCreate {
+	_c.mutation.SetInvitedEmails(v)
+	return _c
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_c *PollCreate) SetRecurrence(v string) *PollCreate {
+	_c.mutation.SetRecurrence(v)
+	return _c
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_c *PollCreate) SetNillableRecurrence(v *string) *PollCreate {
+	if v != nil {
+		_c.SetRecurrence(*v)
+	}
+	return _c
+}
+
+// SetRecurrenceTimezone sets the "recurrence_timezone" field.
+func (_c *PollCreate) SetRecurrenceTimezone(v string) *PollCreate {
+	_c.mutation.SetRecurrenceTimezone(v)
+	return _c
+}
+
+// SetNillableRecurrenceTimezone sets the "recurrence_timezone" field if the given value is not nil.
+func (_c *PollCreate) SetNillableRecurrenceTimezone(v *string) *PollCreate {
+	if v != nil {
+		_c.SetRecurrenceTimezone(*v)
+	}
+	return _c
+}
+
+// SetNextOccurrenceAt sets the "next_occurrence_at" field.
+func (_c *PollCreate) SetNextOccurrenceAt(v time.Time) *PollCreate {
+	_c.mutation.SetNextOccurrenceAt(v)
+	return _c
+}
+
+// SetNillableNextOccurrenceAt sets the "next_occurrence_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableNextOccurrenceAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetNextOccurrenceAt(*v)
+	}
+	return _c
+}
+
+// SetSearchText sets the "search_text" field.
+func (_c *PollCreate) SetSearchText(v string) *PollCreate {
+	_c.mutation.SetSearchText(v)
+	return _c
+}
+
+// SetNillableSearchText sets the "search_text" field if the given value is not nil.
+func (_c *PollCreate) SetNillableSearchText(v *string) *PollCreate {
+	if v != nil {
+		_c.SetSearchText(*v)
+	}
+	return _c
+}
+
+// SetTotalVotes sets the "total_votes" field.
+func (_c *PollCreate) SetTotalVotes(v int) *PollCreate {
+	_c.mutation.SetTotalVotes(v)
+	return _c
+}
+
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_c *PollCreate) SetNillableTotalVotes(v *int) *PollCreate {
+	if v != nil {
+		_c.SetTotalVotes(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *PollCreate) SetCreatedAt(v time.Time) *PollCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -156,6 +346,74 @@ func (_c *PollCreate) AddVotes(v ...*Vote) *PollCreate {
 	return _c.AddVoteIDs(ids...)
 }
 
+// AddInviteIDs adds the "invites" edge to the PollInvite entity by IDs.
+func (_c *PollCreate) AddInviteIDs(ids ...int) *PollCreate {
+	_c.mutation.AddInviteIDs(ids...)
+	return _c
+}
+
+// AddInvites adds the "invites" edges to the PollInvite entity.
+func (_c *PollCreate) AddInvites(v ...*PollInvite) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddInviteIDs(ids...)
+}
+
+// SetTemplateID sets the "template" edge to the Poll entity by ID.
+func (_c *PollCreate) SetTemplateID(id int) *PollCreate {
+	_c.mutation.SetTemplateID(id)
+	return _c
+}
+
+// SetNillableTemplateID sets the "template" edge to the Poll entity by ID if the given value is not nil.
+func (_c *PollCreate) SetNillableTemplateID(id *int) *PollCreate {
+	if id != nil {
+		_c = _c.SetTemplateID(*id)
+	}
+	return _c
+}
+
+// SetTemplate sets the "template" edge to the Poll entity.
+func (_c *PollCreate) SetTemplate(v *Poll) *PollCreate {
+	return _c.SetTemplateID(v.ID)
+}
+
+// AddOccurrenceIDs adds the "occurrences" edge to the Poll entity by IDs.
+func (_c *PollCreate) AddOccurrenceIDs(ids ...int) *PollCreate {
+	_c.mutation.AddOccurrenceIDs(ids...)
+	return _c
+}
+
+// AddOccurrences adds the "occurrences" edges to the Poll entity.
+func (_c *PollCreate) AddOccurrences(v ...*Poll) *PollCreate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOccurrenceIDs(ids...)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_c *PollCreate) SetOwnerID(id int) *PollCreate {
+	_c.mutation.SetOwnerID(id)
+	return _c
+}
+
+// SetNillableOwnerID sets the "owner" edge to the User entity by ID if the given value is not nil.
+func (_c *PollCreate) SetNillableOwnerID(id *int) *PollCreate {
+	if id != nil {
+		_c = _c.SetOwnerID(*id)
+	}
+	return _c
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_c *PollCreate) SetOwner(v *User) *PollCreate {
+	return _c.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_c *PollCreate) Mutation() *PollMutation {
 	return _c.mutation
@@ -199,6 +457,26 @@ func (_c *PollCreate) defaults() {
 		v := poll.DefaultMaxVotesPerUser
 		_c.mutation.SetMaxVotesPerUser(v)
 	}
+	if _, ok := _c.mutation.AllowVoteChanges(); !ok {
+		v := poll.DefaultAllowVoteChanges
+		_c.mutation.SetAllowVoteChanges(v)
+	}
+	if _, ok := _c.mutation.State(); !ok {
+		v := poll.DefaultState
+		_c.mutation.SetState(v)
+	}
+	if _, ok := _c.mutation.Visibility(); !ok {
+		v := poll.DefaultVisibility
+		_c.mutation.SetVisibility(v)
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		v := poll.DefaultResultsVisibility
+		_c.mutation.SetResultsVisibility(v)
+	}
+	if _, ok := _c.mutation.TotalVotes(); !ok {
+		v := poll.DefaultTotalVotes
+		_c.mutation.SetTotalVotes(v)
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := poll.DefaultCreatedAt()
 		_c.mutation.SetCreatedAt(v)
@@ -225,6 +503,26 @@ func (_c *PollCreate) check() error {
 	if _, ok := _c.mutation.MaxVotesPerUser(); !ok {
 		return &ValidationError{Name: "max_votes_per_user", err: errors.New(`ent: missing required field "Poll.max_votes_per_user"`)}
 	}
+	if _, ok := _c.mutation.AllowVoteChanges(); !ok {
+		return &ValidationError{Name: "allow_vote_changes", err: errors.New(`ent: missing required field "Poll.allow_vote_changes"`)}
+	}
+	if _, ok := _c.mutation.State(); !ok {
+		return &ValidationError{Name: "state", err: errors.New(`ent: missing required field "Poll.state"`)}
+	}
+	if _, ok := _c.mutation.Visibility(); !ok {
+		return &ValidationError{Name: "visibility", err: errors.New(`ent: missing required field "Poll.visibility"`)}
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		return &ValidationError{Name: "results_visibility", err: errors.New(`ent: missing required field "Poll.results_visibility"`)}
+	}
+	if _, ok := _c.mutation.TotalVotes(); !ok {
+		return &ValidationError{Name: "total_votes", err: errors.New(`ent: missing required field "Poll.total_votes"`)}
+	}
+	if v, ok := _c.mutation.TotalVotes(); ok {
+		if err := poll.TotalVotesValidator(v); err != nil {
+			return &ValidationError{Name: "total_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.total_votes": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Poll.created_at"`)}
 	}
@@ -277,10 +575,66 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_spec.SetField(poll.FieldMaxVotesPerUser, field.TypeInt, value)
 		_node.MaxVotesPerUser = value
 	}
+	if value, ok := _c.mutation.RatingMin(); ok {
+		_spec.SetField(poll.FieldRatingMin, field.TypeInt, value)
+		_node.RatingMin = &value
+	}
+	if value, ok := _c.mutation.RatingMax(); ok {
+		_spec.SetField(poll.FieldRatingMax, field.TypeInt, value)
+		_node.RatingMax = &value
+	}
+	if value, ok := _c.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+		_node.AllowVoteChanges = value
+	}
+	if value, ok := _c.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+		_node.OpensAt = &value
+	}
 	if value, ok := _c.mutation.ExpiresAt(); ok {
 		_spec.SetField(poll.FieldExpiresAt, field.TypeTime, value)
 		_node.ExpiresAt = value
 	}
+	if value, ok := _c.mutation.State(); ok {
+		_spec.SetField(poll.FieldState, field.TypeString, value)
+		_node.State = value
+	}
+	if value, ok := _c.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+		_node.ClosedAt = &value
+	}
+	if value, ok := _c.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeString, value)
+		_node.Visibility = value
+	}
+	if value, ok := _c.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeString, value)
+		_node.ResultsVisibility = value
+	}
+	if value, ok := _c.mutation.InvitedEmails(); ok {
+		_spec.SetField(poll.FieldInvitedEmails, field.TypeJSON, value)
+		_node.InvitedEmails = value
+	}
+	if value, ok := _c.mutation.Recurrence(); ok {
+		_spec.SetField(poll.FieldRecurrence, field.TypeString, value)
+		_node.Recurrence = value
+	}
+	if value, ok := _c.mutation.RecurrenceTimezone(); ok {
+		_spec.SetField(poll.FieldRecurrenceTimezone, field.TypeString, value)
+		_node.RecurrenceTimezone = value
+	}
+	if value, ok := _c.mutation.NextOccurrenceAt(); ok {
+		_spec.SetField(poll.FieldNextOccurrenceAt, field.TypeTime, value)
+		_node.NextOccurrenceAt = &value
+	}
+	if value, ok := _c.mutation.SearchText(); ok {
+		_spec.SetField(poll.FieldSearchText, field.TypeString, value)
+		_node.SearchText = value
+	}
+	if value, ok := _c.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
+		_node.TotalVotes = value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -321,6 +675,72 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.TemplateIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TemplateTable,
+			Columns: []string{poll.TemplateColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.poll_occurrences = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OccurrencesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_polls = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -4,8 +4,10 @@ package ent
 
 import (
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
 	"backend/ent/predicate"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"database/sql/driver"
@@ -13,6 +15,7 @@ import (
 	"math"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -21,12 +24,18 @@ import (
 // PollQuery is the builder for querying Poll entities.
 type PollQuery struct {
 	config
-	ctx         *QueryContext
-	order       []poll.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.Poll
-	withOptions *PollOptionQuery
-	withVotes   *VoteQuery
+	ctx             *QueryContext
+	order           []poll.OrderOption
+	inters          []Interceptor
+	predicates      []predicate.Poll
+	withOptions     *PollOptionQuery
+	withVotes       *VoteQuery
+	withInvites     *PollInviteQuery
+	withTemplate    *PollQuery
+	withOccurrences *PollQuery
+	withOwner       *UserQuery
+	withFKs         bool
+	modifiers       []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -107,6 +116,94 @@ func (_q *PollQuery) QueryVotes() *VoteQuery {
 	return query
 }
 
+// QueryInvites chains the current query on the "invites" edge.
+func (_q *PollQuery) QueryInvites() *PollInviteQuery {
+	query := (&PollInviteClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(pollinvite.Table, pollinvite.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, poll.InvitesTable, poll.InvitesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryTemplate chains the current query on the "template" edge.
+func (_q *PollQuery) QueryTemplate() *PollQuery {
+	query := (&PollClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(poll.Table, poll.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, poll.TemplateTable, poll.TemplateColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryOccurrences chains the current query on the "occurrences" edge.
+func (_q *PollQuery) QueryOccurrences() *PollQuery {
+	query := (&PollClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(poll.Table, poll.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, poll.OccurrencesTable, poll.OccurrencesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryOwner chains the current query on the "owner" edge.
+func (_q *PollQuery) QueryOwner() *UserQuery {
+	query := (&UserClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, poll.OwnerTable, poll.OwnerColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Poll entity from the query.
 // Returns a *NotFoundError when no Poll was found.
 func (_q *PollQuery) First(ctx context.Context) (*Poll, error) {
@@ -294,13 +391,17 @@ func (_q *PollQuery) Clone() *PollQuery {
 		return nil
 	}
 	return &PollQuery{
-		config:      _q.config,
-		ctx:         _q.ctx.Clone(),
-		order:       append([]poll.OrderOption{}, _q.order...),
-		inters:      append([]Interceptor{}, _q.inters...),
-		predicates:  append([]predicate.Poll{}, _q.predicates...),
-		withOptions: _q.withOptions.Clone(),
-		withVotes:   _q.withVotes.Clone(),
+		config:          _q.config,
+		ctx:             _q.ctx.Clone(),
+		order:           append([]poll.OrderOption{}, _q.order...),
+		inters:          append([]Interceptor{}, _q.inters...),
+		predicates:      append([]predicate.Poll{}, _q.predicates...),
+		withOptions:     _q.withOptions.Clone(),
+		withVotes:       _q.withVotes.Clone(),
+		withInvites:     _q.withInvites.Clone(),
+		withTemplate:    _q.withTemplate.Clone(),
+		withOccurrences: _q.withOccurrences.Clone(),
+		withOwner:       _q.withOwner.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -329,6 +430,50 @@ func (_q *PollQuery) WithVotes(opts ...func(*VoteQuery)) *PollQuery {
 	return _q
 }
 
+// WithInvites tells the query-builder to eager-load the nodes that are connected to
+// the "invites" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithInvites(opts ...func(*PollInviteQuery)) *PollQuery {
+	query := (&PollInviteClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withInvites = query
+	return _q
+}
+
+// WithTemplate tells the query-builder to eager-load the nodes that are connected to
+// the "template" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithTemplate(opts ...func(*PollQuery)) *PollQuery {
+	query := (&PollClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withTemplate = query
+	return _q
+}
+
+// WithOccurrences tells the query-builder to eager-load the nodes that are connected to
+// the "occurrences" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithOccurrences(opts ...func(*PollQuery)) *PollQuery {
+	query := (&PollClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withOccurrences = query
+	return _q
+}
+
+// WithOwner tells the query-builder to eager-load the nodes that are connected to
+// the "owner" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithOwner(opts ...func(*UserQuery)) *PollQuery {
+	query := (&UserClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withOwner = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -406,12 +551,23 @@ func (_q *PollQuery) prepareQuery(ctx context.Context) error {
 func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, error) {
 	var (
 		nodes       = []*Poll{}
+		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [6]bool{
 			_q.withOptions != nil,
 			_q.withVotes != nil,
+			_q.withInvites != nil,
+			_q.withTemplate != nil,
+			_q.withOccurrences != nil,
+			_q.withOwner != nil,
 		}
 	)
+	if _q.withTemplate != nil || _q.withOwner != nil {
+		withFKs = true
+	}
+	if withFKs {
+		_spec.Node.Columns = append(_spec.Node.Columns, poll.ForeignKeys...)
+	}
 	_spec.ScanValues = func(columns []string) ([]any, error) {
 		return (*Poll).scanValues(nil, columns)
 	}
@@ -421,6 +577,9 @@ func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -444,6 +603,32 @@ func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 			return nil, err
 		}
 	}
+	if query := _q.withInvites; query != nil {
+		if err := _q.loadInvites(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Invites = []*PollInvite{} },
+			func(n *Poll, e *PollInvite) { n.Edges.Invites = append(n.Edges.Invites, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withTemplate; query != nil {
+		if err := _q.loadTemplate(ctx, query, nodes, nil,
+			func(n *Poll, e *Poll) { n.Edges.Template = e }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withOccurrences; query != nil {
+		if err := _q.loadOccurrences(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Occurrences = []*Poll{} },
+			func(n *Poll, e *Poll) { n.Edges.Occurrences = append(n.Edges.Occurrences, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withOwner; query != nil {
+		if err := _q.loadOwner(ctx, query, nodes, nil,
+			func(n *Poll, e *User) { n.Edges.Owner = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -509,9 +694,138 @@ func (_q *PollQuery) loadVotes(ctx context.Context, query *VoteQuery, nodes []*P
 	}
 	return nil
 }
+func (_q *PollQuery) loadInvites(ctx context.Context, query *PollInviteQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *PollInvite)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.PollInvite(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.InvitesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.poll_invites
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "poll_invites" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_invites" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadTemplate(ctx context.Context, query *PollQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Poll)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Poll)
+	for i := range nodes {
+		if nodes[i].poll_occurrences == nil {
+			continue
+		}
+		fk := *nodes[i].poll_occurrences
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(poll.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "poll_occurrences" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+func (_q *PollQuery) loadOccurrences(ctx context.Context, query *PollQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Poll)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Poll(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.OccurrencesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.poll_occurrences
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "poll_occurrences" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_occurrences" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadOwner(ctx context.Context, query *UserQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *User)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Poll)
+	for i := range nodes {
+		if nodes[i].user_polls == nil {
+			continue
+		}
+		fk := *nodes[i].user_polls
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "user_polls" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
 
 func (_q *PollQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	_spec.Node.Columns = _q.ctx.Fields
 	if len(_q.ctx.Fields) > 0 {
 		_spec.Unique = _q.ctx.Unique != nil && *_q.ctx.Unique
@@ -574,6 +888,9 @@ func (_q *PollQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if _q.ctx.Unique != nil && *_q.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range _q.modifiers {
+		m(selector)
+	}
 	for _, p := range _q.predicates {
 		p(selector)
 	}
@@ -591,6 +908,32 @@ func (_q *PollQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (_q *PollQuery) ForUpdate(opts ...sql.LockOption) *PollQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return _q
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (_q *PollQuery) ForShare(opts ...sql.LockOption) *PollQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return _q
+}
+
 // PollGroupBy is the group-by builder for Poll entities.
 type PollGroupBy struct {
 	selector
@@ -4,8 +4,10 @@ package ent
 
 import (
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
 	"backend/ent/predicate"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"errors"
@@ -14,6 +16,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 )
 
@@ -119,6 +122,94 @@ func (_u *PollUpdate) AddMaxVotesPerUser(v int) *PollUpdate {
 	return _u
 }
 
+// SetRatingMin sets the "rating_min" field.
+func (_u *PollUpdate) SetRatingMin(v int) *PollUpdate {
+	_u.mutation.ResetRatingMin()
+	_u.mutation.SetRatingMin(v)
+	return _u
+}
+
+// SetNillableRatingMin sets the "rating_min" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableRatingMin(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetRatingMin(*v)
+	}
+	return _u
+}
+
+// AddRatingMin adds value to the "rating_min" field.
+func (_u *PollUpdate) AddRatingMin(v int) *PollUpdate {
+	_u.mutation.AddRatingMin(v)
+	return _u
+}
+
+// ClearRatingMin clears the value of the "rating_min" field.
+func (_u *PollUpdate) ClearRatingMin() *PollUpdate {
+	_u.mutation.ClearRatingMin()
+	return _u
+}
+
+// SetRatingMax sets the "rating_max" field.
+func (_u *PollUpdate) SetRatingMax(v int) *PollUpdate {
+	_u.mutation.ResetRatingMax()
+	_u.mutation.SetRatingMax(v)
+	return _u
+}
+
+// SetNillableRatingMax sets the "rating_max" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableRatingMax(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetRatingMax(*v)
+	}
+	return _u
+}
+
+// AddRatingMax adds value to the "rating_max" field.
+func (_u *PollUpdate) AddRatingMax(v int) *PollUpdate {
+	_u.mutation.AddRatingMax(v)
+	return _u
+}
+
+// ClearRatingMax clears the value of the "rating_max" field.
+func (_u *PollUpdate) ClearRatingMax() *PollUpdate {
+	_u.mutation.ClearRatingMax()
+	return _u
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (_u *PollUpdate) SetAllowVoteChanges(v bool) *PollUpdate {
+	_u.mutation.SetAllowVoteChanges(v)
+	return _u
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableAllowVoteChanges(v *bool) *PollUpdate {
+	if v != nil {
+		_u.SetAllowVoteChanges(*v)
+	}
+	return _u
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdate) SetOpensAt(v time.Time) *PollUpdate {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableOpensAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdate) ClearOpensAt() *PollUpdate {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
 // SetExpiresAt sets the "expires_at" field.
 func (_u *PollUpdate) SetExpiresAt(v time.Time) *PollUpdate {
 	_u.mutation.SetExpiresAt(v)
@@ -139,6 +230,187 @@ func (_u *PollUpdate) ClearExpiresAt() *PollUpdate {
 	return _u
 }
 
+// SetState sets the "state" field.
+func (_u *PollUpdate) SetState(v string) *PollUpdate {
+	_u.mutation.SetState(v)
+	return _u
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableState(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetState(*v)
+	}
+	return _u
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (_u *PollUpdate) SetClosedAt(v time.Time) *PollUpdate {
+	_u.mutation.SetClosedAt(v)
+	return _u
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableClosedAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetClosedAt(*v)
+	}
+	return _u
+}
+
+// ClearClosedAt clears the value of the "closed_at" field.
+func (_u *PollUpdate) ClearClosedAt() *PollUpdate {
+	_u.mutation.ClearClosedAt()
+	return _u
+}
+
+// SetVisibility sets the "visibility" field.
+func (_u *PollUpdate) SetVisibility(v string) *PollUpdate {
+	_u.mutation.SetVisibility(v)
+	return _u
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableVisibility(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetVisibility(*v)
+	}
+	return _u
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdate) SetResultsVisibility(v string) *PollUpdate {
+	_u.mutation.SetResultsVisibility(v)
+	return _u
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableResultsVisibility(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetResultsVisibility(*v)
+	}
+	return _u
+}
+
+// SetInvitedEmails sets the "invited_emails" field.
+func (_u *PollUpdate) SetInvitedEmails(v []string) *PollUpdate {
+	_u.mutation.SetInvitedEmails(v)
+	return _u
+}
+
+// AppendInvitedEmails appends value to the "invited_emails" field.
+func (_u *PollUpdate) AppendInvitedEmails(v []string) *PollUpdate {
+	_u.mutation.AppendInvitedEmails(v)
+	return _u
+}
+
+// ClearInvitedEmails clears the value of the "invited_emails" field.
+func (_u *PollUpdate) ClearInvitedEmails() *PollUpdate {
+	_u.mutation.ClearInvitedEmails()
+	return _u
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_u *PollUpdate) SetRecurrence(v string) *PollUpdate {
+	_u.mutation.SetRecurrence(v)
+	return _u
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableRecurrence(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetRecurrence(*v)
+	}
+	return _u
+}
+
+// ClearRecurrence clears the value of the "recurrence" field.
+func (_u *PollUpdate) ClearRecurrence() *PollUpdate {
+	_u.mutation.ClearRecurrence()
+	return _u
+}
+
+// SetRecurrenceTimezone sets the "recurrence_timezone" field.
+func (_u *PollUpdate) SetRecurrenceTimezone(v string) *PollUpdate {
+	_u.mutation.SetRecurrenceTimezone(v)
+	return _u
+}
+
+// SetNillableRecurrenceTimezone sets the "recurrence_timezone" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableRecurrenceTimezone(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetRecurrenceTimezone(*v)
+	}
+	return _u
+}
+
+// ClearRecurrenceTimezone clears the value of the "recurrence_timezone" field.
+func (_u *PollUpdate) ClearRecurrenceTimezone() *PollUpdate {
+	_u.mutation.ClearRecurrenceTimezone()
+	return _u
+}
+
+// SetNextOccurrenceAt sets the "next_occurrence_at" field.
+func (_u *PollUpdate) SetNextOccurrenceAt(v time.Time) *PollUpdate {
+	_u.mutation.SetNextOccurrenceAt(v)
+	return _u
+}
+
+// SetNillableNextOccurrenceAt sets the "next_occurrence_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableNextOccurrenceAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetNextOccurrenceAt(*v)
+	}
+	return _u
+}
+
+// ClearNextOccurrenceAt clears the value of the "next_occurrence_at" field.
+func (_u *PollUpdate) ClearNextOccurrenceAt() *PollUpdate {
+	_u.mutation.ClearNextOccurrenceAt()
+	return _u
+}
+
+// SetSearchText sets the "search_text" field.
+func (_u *PollUpdate) SetSearchText(v string) *PollUpdate {
+	_u.mutation.SetSearchText(v)
+	return _u
+}
+
+// SetNillableSearchText sets the "search_text" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableSearchText(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetSearchText(*v)
+	}
+	return _u
+}
+
+// ClearSearchText clears the value of the "search_text" field.
+func (_u *PollUpdate) ClearSearchText() *PollUpdate {
+	_u.mutation.ClearSearchText()
+	return _u
+}
+
+// SetTotalVotes sets the "total_votes" field.
+func (_u *PollUpdate) SetTotalVotes(v int) *PollUpdate {
+	_u.mutation.ResetTotalVotes()
+	_u.mutation.SetTotalVotes(v)
+	return _u
+}
+
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableTotalVotes(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetTotalVotes(*v)
+	}
+	return _u
+}
+
+// AddTotalVotes adds value to the "total_votes" field.
+func (_u *PollUpdate) AddTotalVotes(v int) *PollUpdate {
+	_u.mutation.AddTotalVotes(v)
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *PollUpdate) SetCreatedAt(v time.Time) *PollUpdate {
 	_u.mutation.SetCreatedAt(v)
@@ -189,6 +461,74 @@ func (_u *PollUpdate) AddVotes(v ...*Vote) *PollUpdate {
 	return _u.AddVoteIDs(ids...)
 }
 
+// AddInviteIDs adds the "invites" edge to the PollInvite entity by IDs.
+func (_u *PollUpdate) AddInviteIDs(ids ...int) *PollUpdate {
+	_u.mutation.AddInviteIDs(ids...)
+	return _u
+}
+
+// AddInvites adds the "invites" edges to the PollInvite entity.
+func (_u *PollUpdate) AddInvites(v ...*PollInvite) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddInviteIDs(ids...)
+}
+
+// SetTemplateID sets the "template" edge to the Poll entity by ID.
+func (_u *PollUpdate) SetTemplateID(id int) *PollUpdate {
+	_u.mutation.SetTemplateID(id)
+	return _u
+}
+
+// SetNillableTemplateID sets the "template" edge to the Poll entity by ID if the given value is not nil.
+func (_u *PollUpdate) SetNillableTemplateID(id *int) *PollUpdate {
+	if id != nil {
+		_u = _u.SetTemplateID(*id)
+	}
+	return _u
+}
+
+// SetTemplate sets the "template" edge to the Poll entity.
+func (_u *PollUpdate) SetTemplate(v *Poll) *PollUpdate {
+	return _u.SetTemplateID(v.ID)
+}
+
+// AddOccurrenceIDs adds the "occurrences" edge to the Poll entity by IDs.
+func (_u *PollUpdate) AddOccurrenceIDs(ids ...int) *PollUpdate {
+	_u.mutation.AddOccurrenceIDs(ids...)
+	return _u
+}
+
+// AddOccurrences adds the "occurrences" edges to the Poll entity.
+func (_u *PollUpdate) AddOccurrences(v ...*Poll) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOccurrenceIDs(ids...)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *PollUpdate) SetOwnerID(id int) *PollUpdate {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner" edge to the User entity by ID if the given value is not nil.
+func (_u *PollUpdate) SetNillableOwnerID(id *int) *PollUpdate {
+	if id != nil {
+		_u = _u.SetOwnerID(*id)
+	}
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *PollUpdate) SetOwner(v *User) *PollUpdate {
+	return _u.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_u *PollUpdate) Mutation() *PollMutation {
 	return _u.mutation
@@ -236,6 +576,60 @@ func (_u *PollUpdate) RemoveVotes(v ...*Vote) *PollUpdate {
 	return _u.RemoveVoteIDs(ids...)
 }
 
+// ClearInvites clears all "invites" edges to the PollInvite entity.
+func (_u *PollUpdate) ClearInvites() *PollUpdate {
+	_u.mutation.ClearInvites()
+	return _u
+}
+
+// RemoveInviteIDs removes the "invites" edge to PollInvite entities by IDs.
+func (_u *PollUpdate) RemoveInviteIDs(ids ...int) *PollUpdate {
+	_u.mutation.RemoveInviteIDs(ids...)
+	return _u
+}
+
+// RemoveInvites removes "invites" edges to PollInvite entities.
+func (_u *PollUpdate) RemoveInvites(v ...*PollInvite) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveInviteIDs(ids...)
+}
+
+// ClearTemplate clears the "template" edge to the Poll entity.
+func (_u *PollUpdate) ClearTemplate() *PollUpdate {
+	_u.mutation.ClearTemplate()
+	return _u
+}
+
+// ClearOccurrences clears all "occurrences" edges to the Poll entity.
+func (_u *PollUpdate) ClearOccurrences() *PollUpdate {
+	_u.mutation.ClearOccurrences()
+	return _u
+}
+
+// RemoveOccurrenceIDs removes the "occurrences" edge to Poll entities by IDs.
+func (_u *PollUpdate) RemoveOccurrenceIDs(ids ...int) *PollUpdate {
+	_u.mutation.RemoveOccurrenceIDs(ids...)
+	return _u
+}
+
+// RemoveOccurrences removes "occurrences" edges to Poll entities.
+func (_u *PollUpdate) RemoveOccurrences(v ...*Poll) *PollUpdate {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOccurrenceIDs(ids...)
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *PollUpdate) ClearOwner() *PollUpdate {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *PollUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -279,6 +673,11 @@ func (_u *PollUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TotalVotes(); ok {
+		if err := poll.TotalVotesValidator(v); err != nil {
+			return &ValidationError{Name: "total_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.total_votes": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -318,12 +717,95 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedMaxVotesPerUser(); ok {
 		_spec.AddField(poll.FieldMaxVotesPerUser, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.RatingMin(); ok {
+		_spec.SetField(poll.FieldRatingMin, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRatingMin(); ok {
+		_spec.AddField(poll.FieldRatingMin, field.TypeInt, value)
+	}
+	if _u.mutation.RatingMinCleared() {
+		_spec.ClearField(poll.FieldRatingMin, field.TypeInt)
+	}
+	if value, ok := _u.mutation.RatingMax(); ok {
+		_spec.SetField(poll.FieldRatingMax, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRatingMax(); ok {
+		_spec.AddField(poll.FieldRatingMax, field.TypeInt, value)
+	}
+	if _u.mutation.RatingMaxCleared() {
+		_spec.ClearField(poll.FieldRatingMax, field.TypeInt)
+	}
+	if value, ok := _u.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
 	if value, ok := _u.mutation.ExpiresAt(); ok {
 		_spec.SetField(poll.FieldExpiresAt, field.TypeTime, value)
 	}
 	if _u.mutation.ExpiresAtCleared() {
 		_spec.ClearField(poll.FieldExpiresAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.State(); ok {
+		_spec.SetField(poll.FieldState, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosedAtCleared() {
+		_spec.ClearField(poll.FieldClosedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.InvitedEmails(); ok {
+		_spec.SetField(poll.FieldInvitedEmails, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedInvitedEmails(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, poll.FieldInvitedEmails, value)
+		})
+	}
+	if _u.mutation.InvitedEmailsCleared() {
+		_spec.ClearField(poll.FieldInvitedEmails, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Recurrence(); ok {
+		_spec.SetField(poll.FieldRecurrence, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceCleared() {
+		_spec.ClearField(poll.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := _u.mutation.RecurrenceTimezone(); ok {
+		_spec.SetField(poll.FieldRecurrenceTimezone, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceTimezoneCleared() {
+		_spec.ClearField(poll.FieldRecurrenceTimezone, field.TypeString)
+	}
+	if value, ok := _u.mutation.NextOccurrenceAt(); ok {
+		_spec.SetField(poll.FieldNextOccurrenceAt, field.TypeTime, value)
+	}
+	if _u.mutation.NextOccurrenceAtCleared() {
+		_spec.ClearField(poll.FieldNextOccurrenceAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.SearchText(); ok {
+		_spec.SetField(poll.FieldSearchText, field.TypeString, value)
+	}
+	if _u.mutation.SearchTextCleared() {
+		_spec.ClearField(poll.FieldSearchText, field.TypeString)
+	}
+	if value, ok := _u.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTotalVotes(); ok {
+		_spec.AddField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -420,16 +902,164 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
-	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
-		if _, ok := err.(*sqlgraph.NotFoundError); ok {
-			err = &NotFoundError{poll.Label}
-		} else if sqlgraph.IsConstraintError(err) {
-			err = &ConstraintError{msg: err.Error(), wrap: err}
+	if _u.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
 		}
-		return 0, err
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
 	}
-	_u.mutation.done = true
-	return _node, nil
+	if nodes := _u.mutation.RemovedInvitesIDs(); len(nodes) > 0 && !_u.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TemplateCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TemplateTable,
+			Columns: []string{poll.TemplateColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TemplateIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TemplateTable,
+			Columns: []string{poll.TemplateColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OccurrencesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOccurrencesIDs(); len(nodes) > 0 && !_u.mutation.OccurrencesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OccurrencesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{poll.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
 }
 
 // PollUpdateOne is the builder for updating a single Poll entity.
@@ -440,112 +1070,381 @@ type PollUpdateOne struct {
 	mutation *PollMutation
 }
 
-// SetTitle sets the "title" field.
-func (_u *PollUpdateOne) SetTitle(v string) *PollUpdateOne {
-	_u.mutation.SetTitle(v)
+// SetTitle sets the "title" field.
+func (_u *PollUpdateOne) SetTitle(v string) *PollUpdateOne {
+	_u.mutation.SetTitle(v)
+	return _u
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableTitle(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetTitle(*v)
+	}
+	return _u
+}
+
+// SetDescription sets the "description" field.
+func (_u *PollUpdateOne) SetDescription(v string) *PollUpdateOne {
+	_u.mutation.SetDescription(v)
+	return _u
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableDescription(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetDescription(*v)
+	}
+	return _u
+}
+
+// ClearDescription clears the value of the "description" field.
+func (_u *PollUpdateOne) ClearDescription() *PollUpdateOne {
+	_u.mutation.ClearDescription()
+	return _u
+}
+
+// SetPollType sets the "poll_type" field.
+func (_u *PollUpdateOne) SetPollType(v string) *PollUpdateOne {
+	_u.mutation.SetPollType(v)
+	return _u
+}
+
+// SetNillablePollType sets the "poll_type" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillablePollType(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetPollType(*v)
+	}
+	return _u
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (_u *PollUpdateOne) SetCreatedBy(v string) *PollUpdateOne {
+	_u.mutation.SetCreatedBy(v)
+	return _u
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableCreatedBy(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetCreatedBy(*v)
+	}
+	return _u
+}
+
+// ClearCreatedBy clears the value of the "created_by" field.
+func (_u *PollUpdateOne) ClearCreatedBy() *PollUpdateOne {
+	_u.mutation.ClearCreatedBy()
+	return _u
+}
+
+// SetMaxVotesPerUser sets the "max_votes_per_user" field.
+func (_u *PollUpdateOne) SetMaxVotesPerUser(v int) *PollUpdateOne {
+	_u.mutation.ResetMaxVotesPerUser()
+	_u.mutation.SetMaxVotesPerUser(v)
+	return _u
+}
+
+// SetNillableMaxVotesPerUser sets the "max_votes_per_user" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableMaxVotesPerUser(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetMaxVotesPerUser(*v)
+	}
+	return _u
+}
+
+// AddMaxVotesPerUser adds value to the "max_votes_per_user" field.
+func (_u *PollUpdateOne) AddMaxVotesPerUser(v int) *PollUpdateOne {
+	_u.mutation.AddMaxVotesPerUser(v)
+	return _u
+}
+
+// SetRatingMin sets the "rating_min" field.
+func (_u *PollUpdateOne) SetRatingMin(v int) *PollUpdateOne {
+	_u.mutation.ResetRatingMin()
+	_u.mutation.SetRatingMin(v)
+	return _u
+}
+
+// SetNillableRatingMin sets the "rating_min" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableRatingMin(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetRatingMin(*v)
+	}
+	return _u
+}
+
+// AddRatingMin adds value to the "rating_min" field.
+func (_u *PollUpdateOne) AddRatingMin(v int) *PollUpdateOne {
+	_u.mutation.AddRatingMin(v)
+	return _u
+}
+
+// ClearRatingMin clears the value of the "rating_min" field.
+func (_u *PollUpdateOne) ClearRatingMin() *PollUpdateOne {
+	_u.mutation.ClearRatingMin()
+	return _u
+}
+
+// SetRatingMax sets the "rating_max" field.
+func (_u *PollUpdateOne) SetRatingMax(v int) *PollUpdateOne {
+	_u.mutation.ResetRatingMax()
+	_u.mutation.SetRatingMax(v)
+	return _u
+}
+
+// SetNillableRatingMax sets the "rating_max" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableRatingMax(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetRatingMax(*v)
+	}
+	return _u
+}
+
+// AddRatingMax adds value to the "rating_max" field.
+func (_u *PollUpdateOne) AddRatingMax(v int) *PollUpdateOne {
+	_u.mutation.AddRatingMax(v)
+	return _u
+}
+
+// ClearRatingMax clears the value of the "rating_max" field.
+func (_u *PollUpdateOne) ClearRatingMax() *PollUpdateOne {
+	_u.mutation.ClearRatingMax()
+	return _u
+}
+
+// SetAllowVoteChanges sets the "allow_vote_changes" field.
+func (_u *PollUpdateOne) SetAllowVoteChanges(v bool) *PollUpdateOne {
+	_u.mutation.SetAllowVoteChanges(v)
+	return _u
+}
+
+// SetNillableAllowVoteChanges sets the "allow_vote_changes" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableAllowVoteChanges(v *bool) *PollUpdateOne {
+	if v != nil {
+		_u.SetAllowVoteChanges(*v)
+	}
+	return _u
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdateOne) SetOpensAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableOpensAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdateOne) ClearOpensAt() *PollUpdateOne {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *PollUpdateOne) SetExpiresAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableExpiresAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *PollUpdateOne) ClearExpiresAt() *PollUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetState sets the "state" field.
+func (_u *PollUpdateOne) SetState(v string) *PollUpdateOne {
+	_u.mutation.SetState(v)
+	return _u
+}
+
+// SetNillableState sets the "state" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableState(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetState(*v)
+	}
+	return _u
+}
+
+// SetClosedAt sets the "closed_at" field.
+func (_u *PollUpdateOne) SetClosedAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetClosedAt(v)
+	return _u
+}
+
+// SetNillableClosedAt sets the "closed_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableClosedAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetClosedAt(*v)
+	}
+	return _u
+}
+
+// ClearClosedAt clears the value of the "closed_at" field.
+func (_u *PollUpdateOne) ClearClosedAt() *PollUpdateOne {
+	_u.mutation.ClearClosedAt()
+	return _u
+}
+
+// SetVisibility sets the "visibility" field.
+func (_u *PollUpdateOne) SetVisibility(v string) *PollUpdateOne {
+	_u.mutation.SetVisibility(v)
+	return _u
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableVisibility(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetVisibility(*v)
+	}
+	return _u
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdateOne) SetResultsVisibility(v string) *PollUpdateOne {
+	_u.mutation.SetResultsVisibility(v)
 	return _u
 }
 
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableTitle(v *string) *PollUpdateOne {
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableResultsVisibility(v *string) *PollUpdateOne {
 	if v != nil {
-		_u.SetTitle(*v)
+		_u.SetResultsVisibility(*v)
 	}
 	return _u
 }
 
-// SetDescription sets the "description" field.
-func (_u *PollUpdateOne) SetDescription(v string) *PollUpdateOne {
-	_u.mutation.SetDescription(v)
+// SetInvitedEmails sets the "invited_emails" field.
+func (_u *PollUpdateOne) SetInvitedEmails(v []string) *PollUpdateOne {
+	_u.mutation.SetInvitedEmails(v)
 	return _u
 }
 
-// SetNillableDescription sets the "description" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableDescription(v *string) *PollUpdateOne {
+// AppendInvitedEmails appends value to the "invited_emails" field.
+func (_u *PollUpdateOne) AppendInvitedEmails(v []string) *PollUpdateOne {
+	_u.mutation.AppendInvitedEmails(v)
+	return _u
+}
+
+// ClearInvitedEmails clears the value of the "invited_emails" field.
+func (_u *PollUpdateOne) ClearInvitedEmails() *PollUpdateOne {
+	_u.mutation.ClearInvitedEmails()
+	return _u
+}
+
+// SetRecurrence sets the "recurrence" field.
+func (_u *PollUpdateOne) SetRecurrence(v string) *PollUpdateOne {
+	_u.mutation.SetRecurrence(v)
+	return _u
+}
+
+// SetNillableRecurrence sets the "recurrence" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableRecurrence(v *string) *PollUpdateOne {
 	if v != nil {
-		_u.SetDescription(*v)
+		_u.SetRecurrence(*v)
 	}
 	return _u
 }
 
-// ClearDescription clears the value of the "description" field.
-func (_u *PollUpdateOne) ClearDescription() *PollUpdateOne {
-	_u.mutation.ClearDescription()
+// ClearRecurrence clears the value of the "recurrence" field.
+func (_u *PollUpdateOne) ClearRecurrence() *PollUpdateOne {
+	_u.mutation.ClearRecurrence()
 	return _u
 }
 
-// SetPollType sets the "poll_type" field.
-func (_u *PollUpdateOne) SetPollType(v string) *PollUpdateOne {
-	_u.mutation.SetPollType(v)
+// SetRecurrenceTimezone sets the "recurrence_timezone" field.
+func (_u *PollUpdateOne) SetRecurrenceTimezone(v string) *PollUpdateOne {
+	_u.mutation.SetRecurrenceTimezone(v)
 	return _u
 }
 
-// SetNillablePollType sets the "poll_type" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillablePollType(v *string) *PollUpdateOne {
+// SetNillableRecurrenceTimezone sets the "recurrence_timezone" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableRecurrenceTimezone(v *string) *PollUpdateOne {
 	if v != nil {
-		_u.SetPollType(*v)
+		_u.SetRecurrenceTimezone(*v)
 	}
 	return _u
 }
 
-// SetCreatedBy sets the "created_by" field.
-func (_u *PollUpdateOne) SetCreatedBy(v string) *PollUpdateOne {
-	_u.mutation.SetCreatedBy(v)
+// ClearRecurrenceTimezone clears the value of the "recurrence_timezone" field.
+func (_u *PollUpdateOne) ClearRecurrenceTimezone() *PollUpdateOne {
+	_u.mutation.ClearRecurrenceTimezone()
 	return _u
 }
 
-// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableCreatedBy(v *string) *PollUpdateOne {
+// SetNextOccurrenceAt sets the "next_occurrence_at" field.
+func (_u *PollUpdateOne) SetNextOccurrenceAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetNextOccurrenceAt(v)
+	return _u
+}
+
+// SetNillableNextOccurrenceAt sets the "next_occurrence_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableNextOccurrenceAt(v *time.Time) *PollUpdateOne {
 	if v != nil {
-		_u.SetCreatedBy(*v)
+		_u.SetNextOccurrenceAt(*v)
 	}
 	return _u
 }
 
-// ClearCreatedBy clears the value of the "created_by" field.
-func (_u *PollUpdateOne) ClearCreatedBy() *PollUpdateOne {
-	_u.mutation.ClearCreatedBy()
+// ClearNextOccurrenceAt clears the value of the "next_occurrence_at" field.
+func (_u *PollUpdateOne) ClearNextOccurrenceAt() *PollUpdateOne {
+	_u.mutation.ClearNextOccurrenceAt()
 	return _u
 }
 
-// SetMaxVotesPerUser sets the "max_votes_per_user" field.
-func (_u *PollUpdateOne) SetMaxVotesPerUser(v int) *PollUpdateOne {
-	_u.mutation.ResetMaxVotesPerUser()
-	_u.mutation.SetMaxVotesPerUser(v)
+// SetSearchText sets the "search_text" field.
+func (_u *PollUpdateOne) SetSearchText(v string) *PollUpdateOne {
+	_u.mutation.SetSearchText(v)
 	return _u
 }
 
-// SetNillableMaxVotesPerUser sets the "max_votes_per_user" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableMaxVotesPerUser(v *int) *PollUpdateOne {
+// SetNillableSearchText sets the "search_text" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableSearchText(v *string) *PollUpdateOne {
 	if v != nil {
-		_u.SetMaxVotesPerUser(*v)
+		_u.SetSearchText(*v)
 	}
 	return _u
 }
 
-// AddMaxVotesPerUser adds value to the "max_votes_per_user" field.
-func (_u *PollUpdateOne) AddMaxVotesPerUser(v int) *PollUpdateOne {
-	_u.mutation.AddMaxVotesPerUser(v)
+// ClearSearchText clears the value of the "search_text" field.
+func (_u *PollUpdateOne) ClearSearchText() *PollUpdateOne {
+	_u.mutation.ClearSearchText()
 	return _u
 }
 
-// SetExpiresAt sets the "expires_at" field.
-func (_u *PollUpdateOne) SetExpiresAt(v time.Time) *PollUpdateOne {
-	_u.mutation.SetExpiresAt(v)
+// SetTotalVotes sets the "total_votes" field.
+func (_u *PollUpdateOne) SetTotalVotes(v int) *PollUpdateOne {
+	_u.mutation.ResetTotalVotes()
+	_u.mutation.SetTotalVotes(v)
 	return _u
 }
 
-// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
-func (_u *PollUpdateOne) SetNillableExpiresAt(v *time.Time) *PollUpdateOne {
+// SetNillableTotalVotes sets the "total_votes" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableTotalVotes(v *int) *PollUpdateOne {
 	if v != nil {
-		_u.SetExpiresAt(*v)
+		_u.SetTotalVotes(*v)
 	}
 	return _u
 }
 
-// ClearExpiresAt clears the value of the "expires_at" field.
-func (_u *PollUpdateOne) ClearExpiresAt() *PollUpdateOne {
-	_u.mutation.ClearExpiresAt()
+// AddTotalVotes adds value to the "total_votes" field.
+func (_u *PollUpdateOne) AddTotalVotes(v int) *PollUpdateOne {
+	_u.mutation.AddTotalVotes(v)
 	return _u
 }
 
@@ -599,6 +1498,74 @@ func (_u *PollUpdateOne) AddVotes(v ...*Vote) *PollUpdateOne {
 	return _u.AddVoteIDs(ids...)
 }
 
+// AddInviteIDs adds the "invites" edge to the PollInvite entity by IDs.
+func (_u *PollUpdateOne) AddInviteIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.AddInviteIDs(ids...)
+	return _u
+}
+
+// AddInvites adds the "invites" edges to the PollInvite entity.
+func (_u *PollUpdateOne) AddInvites(v ...*PollInvite) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddInviteIDs(ids...)
+}
+
+// SetTemplateID sets the "template" edge to the Poll entity by ID.
+func (_u *PollUpdateOne) SetTemplateID(id int) *PollUpdateOne {
+	_u.mutation.SetTemplateID(id)
+	return _u
+}
+
+// SetNillableTemplateID sets the "template" edge to the Poll entity by ID if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableTemplateID(id *int) *PollUpdateOne {
+	if id != nil {
+		_u = _u.SetTemplateID(*id)
+	}
+	return _u
+}
+
+// SetTemplate sets the "template" edge to the Poll entity.
+func (_u *PollUpdateOne) SetTemplate(v *Poll) *PollUpdateOne {
+	return _u.SetTemplateID(v.ID)
+}
+
+// AddOccurrenceIDs adds the "occurrences" edge to the Poll entity by IDs.
+func (_u *PollUpdateOne) AddOccurrenceIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.AddOccurrenceIDs(ids...)
+	return _u
+}
+
+// AddOccurrences adds the "occurrences" edges to the Poll entity.
+func (_u *PollUpdateOne) AddOccurrences(v ...*Poll) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOccurrenceIDs(ids...)
+}
+
+// SetOwnerID sets the "owner" edge to the User entity by ID.
+func (_u *PollUpdateOne) SetOwnerID(id int) *PollUpdateOne {
+	_u.mutation.SetOwnerID(id)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner" edge to the User entity by ID if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableOwnerID(id *int) *PollUpdateOne {
+	if id != nil {
+		_u = _u.SetOwnerID(*id)
+	}
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *PollUpdateOne) SetOwner(v *User) *PollUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_u *PollUpdateOne) Mutation() *PollMutation {
 	return _u.mutation
@@ -646,6 +1613,60 @@ func (_u *PollUpdateOne) RemoveVotes(v ...*Vote) *PollUpdateOne {
 	return _u.RemoveVoteIDs(ids...)
 }
 
+// ClearInvites clears all "invites" edges to the PollInvite entity.
+func (_u *PollUpdateOne) ClearInvites() *PollUpdateOne {
+	_u.mutation.ClearInvites()
+	return _u
+}
+
+// RemoveInviteIDs removes the "invites" edge to PollInvite entities by IDs.
+func (_u *PollUpdateOne) RemoveInviteIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.RemoveInviteIDs(ids...)
+	return _u
+}
+
+// RemoveInvites removes "invites" edges to PollInvite entities.
+func (_u *PollUpdateOne) RemoveInvites(v ...*PollInvite) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveInviteIDs(ids...)
+}
+
+// ClearTemplate clears the "template" edge to the Poll entity.
+func (_u *PollUpdateOne) ClearTemplate() *PollUpdateOne {
+	_u.mutation.ClearTemplate()
+	return _u
+}
+
+// ClearOccurrences clears all "occurrences" edges to the Poll entity.
+func (_u *PollUpdateOne) ClearOccurrences() *PollUpdateOne {
+	_u.mutation.ClearOccurrences()
+	return _u
+}
+
+// RemoveOccurrenceIDs removes the "occurrences" edge to Poll entities by IDs.
+func (_u *PollUpdateOne) RemoveOccurrenceIDs(ids ...int) *PollUpdateOne {
+	_u.mutation.RemoveOccurrenceIDs(ids...)
+	return _u
+}
+
+// RemoveOccurrences removes "occurrences" edges to Poll entities.
+func (_u *PollUpdateOne) RemoveOccurrences(v ...*Poll) *PollUpdateOne {
+	ids := make([]int, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOccurrenceIDs(ids...)
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *PollUpdateOne) ClearOwner() *PollUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
 // Where appends a list predicates to the PollUpdate builder.
 func (_u *PollUpdateOne) Where(ps ...predicate.Poll) *PollUpdateOne {
 	_u.mutation.Where(ps...)
@@ -702,6 +1723,11 @@ func (_u *PollUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.TotalVotes(); ok {
+		if err := poll.TotalVotesValidator(v); err != nil {
+			return &ValidationError{Name: "total_votes", err: fmt.Errorf(`ent: validator failed for field "Poll.total_votes": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -758,12 +1784,95 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 	if value, ok := _u.mutation.AddedMaxVotesPerUser(); ok {
 		_spec.AddField(poll.FieldMaxVotesPerUser, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.RatingMin(); ok {
+		_spec.SetField(poll.FieldRatingMin, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRatingMin(); ok {
+		_spec.AddField(poll.FieldRatingMin, field.TypeInt, value)
+	}
+	if _u.mutation.RatingMinCleared() {
+		_spec.ClearField(poll.FieldRatingMin, field.TypeInt)
+	}
+	if value, ok := _u.mutation.RatingMax(); ok {
+		_spec.SetField(poll.FieldRatingMax, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRatingMax(); ok {
+		_spec.AddField(poll.FieldRatingMax, field.TypeInt, value)
+	}
+	if _u.mutation.RatingMaxCleared() {
+		_spec.ClearField(poll.FieldRatingMax, field.TypeInt)
+	}
+	if value, ok := _u.mutation.AllowVoteChanges(); ok {
+		_spec.SetField(poll.FieldAllowVoteChanges, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
 	if value, ok := _u.mutation.ExpiresAt(); ok {
 		_spec.SetField(poll.FieldExpiresAt, field.TypeTime, value)
 	}
 	if _u.mutation.ExpiresAtCleared() {
 		_spec.ClearField(poll.FieldExpiresAt, field.TypeTime)
 	}
+	if value, ok := _u.mutation.State(); ok {
+		_spec.SetField(poll.FieldState, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ClosedAt(); ok {
+		_spec.SetField(poll.FieldClosedAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosedAtCleared() {
+		_spec.ClearField(poll.FieldClosedAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.InvitedEmails(); ok {
+		_spec.SetField(poll.FieldInvitedEmails, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedInvitedEmails(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, poll.FieldInvitedEmails, value)
+		})
+	}
+	if _u.mutation.InvitedEmailsCleared() {
+		_spec.ClearField(poll.FieldInvitedEmails, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Recurrence(); ok {
+		_spec.SetField(poll.FieldRecurrence, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceCleared() {
+		_spec.ClearField(poll.FieldRecurrence, field.TypeString)
+	}
+	if value, ok := _u.mutation.RecurrenceTimezone(); ok {
+		_spec.SetField(poll.FieldRecurrenceTimezone, field.TypeString, value)
+	}
+	if _u.mutation.RecurrenceTimezoneCleared() {
+		_spec.ClearField(poll.FieldRecurrenceTimezone, field.TypeString)
+	}
+	if value, ok := _u.mutation.NextOccurrenceAt(); ok {
+		_spec.SetField(poll.FieldNextOccurrenceAt, field.TypeTime, value)
+	}
+	if _u.mutation.NextOccurrenceAtCleared() {
+		_spec.ClearField(poll.FieldNextOccurrenceAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.SearchText(); ok {
+		_spec.SetField(poll.FieldSearchText, field.TypeString, value)
+	}
+	if _u.mutation.SearchTextCleared() {
+		_spec.ClearField(poll.FieldSearchText, field.TypeString)
+	}
+	if value, ok := _u.mutation.TotalVotes(); ok {
+		_spec.SetField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTotalVotes(); ok {
+		_spec.AddField(poll.FieldTotalVotes, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -860,6 +1969,154 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedInvitesIDs(); len(nodes) > 0 && !_u.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.TemplateCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TemplateTable,
+			Columns: []string{poll.TemplateColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.TemplateIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TemplateTable,
+			Columns: []string{poll.TemplateColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OccurrencesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOccurrencesIDs(); len(nodes) > 0 && !_u.mutation.OccurrencesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OccurrencesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.OccurrencesTable,
+			Columns: []string{poll.OccurrencesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OwnerCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OwnerIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.OwnerTable,
+			Columns: []string{poll.OwnerColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Poll{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,184 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend/ent/poll"
+	"backend/ent/pollinvite"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// PollInvite is the model entity for the PollInvite schema.
+type PollInvite struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Creator's note on who the link was shared with
+	Label string `json:"label,omitempty"`
+	// When the link stops working; nil for never
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// When the creator revoked the link; nil while it works
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// When the link was generated
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PollInviteQuery when eager-loading is set.
+	Edges        PollInviteEdges `json:"edges"`
+	poll_invites *int
+	selectValues sql.SelectValues
+}
+
+// PollInviteEdges holds the relations/edges for other nodes in the graph.
+type PollInviteEdges struct {
+	// The poll the link grants access to
+	Poll *Poll `json:"poll,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollInviteEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PollInvite) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case pollinvite.FieldID:
+			values[i] = new(sql.NullInt64)
+		case pollinvite.FieldLabel:
+			values[i] = new(sql.NullString)
+		case pollinvite.FieldExpiresAt, pollinvite.FieldRevokedAt, pollinvite.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case pollinvite.ForeignKeys[0]: // poll_invites
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PollInvite fields.
+func (_m *PollInvite) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case pollinvite.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case pollinvite.FieldLabel:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field label", values[i])
+			} else if value.Valid {
+				_m.Label = value.String
+			}
+		case pollinvite.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = new(time.Time)
+				*_m.ExpiresAt = value.Time
+			}
+		case pollinvite.FieldRevokedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[i])
+			} else if value.Valid {
+				_m.RevokedAt = new(time.Time)
+				*_m.RevokedAt = value.Time
+			}
+		case pollinvite.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case pollinvite.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field poll_invites", value)
+			} else if value.Valid {
+				_m.poll_invites = new(int)
+				*_m.poll_invites = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PollInvite.
+// This includes values selected through modifiers, order, etc.
+func (_m *PollInvite) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryPoll queries the "poll" edge of the PollInvite entity.
+func (_m *PollInvite) QueryPoll() *PollQuery {
+	return NewPollInviteClient(_m.config).QueryPoll(_m)
+}
+
+// Update returns a builder for updating this PollInvite.
+// Note that you need to call PollInvite.Unwrap() before calling this method if this PollInvite
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *PollInvite) Update() *PollInviteUpdateOne {
+	return NewPollInviteClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the PollInvite entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *PollInvite) Unwrap() *PollInvite {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PollInvite is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *PollInvite) String() string {
+	var builder strings.Builder
+	builder.WriteString("PollInvite(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("label=")
+	builder.WriteString(_m.Label)
+	builder.WriteString(", ")
+	if v := _m.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.RevokedAt; v != nil {
+		builder.WriteString("revoked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PollInvites is a parsable slice of PollInvite.
+type PollInvites []*PollInvite
@@ -0,0 +1,115 @@
+// Code generated by ent, DO NOT EDIT.
+
+package pollinvite
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the pollinvite type in the database.
+	Label = "poll_invite"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldLabel holds the string denoting the label field in the database.
+	FieldLabel = "label"
+	// FieldExpiresAt holds the string denoting the expires_at field in the database.
+	FieldExpiresAt = "expires_at"
+	// FieldRevokedAt holds the string denoting the revoked_at field in the database.
+	FieldRevokedAt = "revoked_at"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// EdgePoll holds the string denoting the poll edge name in mutations.
+	EdgePoll = "poll"
+	// Table holds the table name of the pollinvite in the database.
+	Table = "poll_invites"
+	// PollTable is the table that holds the poll relation/edge.
+	PollTable = "poll_invites"
+	// PollInverseTable is the table name for the Poll entity.
+	// It exists in this package in order to avoid circular dependency with the "poll" package.
+	PollInverseTable = "polls"
+	// PollColumn is the table column denoting the poll relation/edge.
+	PollColumn = "poll_invites"
+)
+
+// Columns holds all SQL columns for pollinvite fields.
+var Columns = []string{
+	FieldID,
+	FieldLabel,
+	FieldExpiresAt,
+	FieldRevokedAt,
+	FieldCreatedAt,
+}
+
+// ForeignKeys holds the SQL foreign-keys that are owned by the "poll_invites"
+// table and are not defined as standalone fields in the schema.
+var ForeignKeys = []string{
+	"poll_invites",
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	for i := range ForeignKeys {
+		if column == ForeignKeys[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// LabelValidator is a validator for the "label" field. It is called by the builders before save.
+	LabelValidator func(string) error
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// OrderOption defines the ordering options for the PollInvite queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByLabel orders the results by the label field.
+func ByLabel(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLabel, opts...).ToFunc()
+}
+
+// ByExpiresAt orders the results by the expires_at field.
+func ByExpiresAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExpiresAt, opts...).ToFunc()
+}
+
+// ByRevokedAt orders the results by the revoked_at field.
+func ByRevokedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRevokedAt, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
+
+// ByPollField orders the results by poll field.
+func ByPollField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPollStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newPollStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PollInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, PollTable, PollColumn),
+	)
+}
@@ -0,0 +1,293 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend/ent/poll"
+	"backend/ent/pollinvite"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PollInviteCreate is the builder for creating a PollInvite entity.
+type PollInviteCreate struct {
+	config
+	mutation *PollInviteMutation
+	hooks    []Hook
+}
+
+// SetLabel sets the "label" field.
+func (_c *PollInviteCreate) SetLabel(v string) *PollInviteCreate {
+	_c.mutation.SetLabel(v)
+	return _c
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_c *PollInviteCreate) SetNillableLabel(v *string) *PollInviteCreate {
+	if v != nil {
+		_c.SetLabel(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *PollInviteCreate) SetExpiresAt(v time.Time) *PollInviteCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *PollInviteCreate) SetNillableExpiresAt(v *time.Time) *PollInviteCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_c *PollInviteCreate) SetRevokedAt(v time.Time) *PollInviteCreate {
+	_c.mutation.SetRevokedAt(v)
+	return _c
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_c *PollInviteCreate) SetNillableRevokedAt(v *time.Time) *PollInviteCreate {
+	if v != nil {
+		_c.SetRevokedAt(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *PollInviteCreate) SetCreatedAt(v time.Time) *PollInviteCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *PollInviteCreate) SetNillableCreatedAt(v *time.Time) *PollInviteCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_c *PollInviteCreate) SetPollID(id int) *PollInviteCreate {
+	_c.mutation.SetPollID(id)
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *PollInviteCreate) SetPoll(v *Poll) *PollInviteCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// Mutation returns the PollInviteMutation object of the builder.
+func (_c *PollInviteCreate) Mutation() *PollInviteMutation {
+	return _c.mutation
+}
+
+// Save creates the PollInvite in the database.
+func (_c *PollInviteCreate) Save(ctx context.Context) (*PollInvite, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PollInviteCreate) SaveX(ctx context.Context) *PollInvite {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollInviteCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollInviteCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PollInviteCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := pollinvite.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PollInviteCreate) check() error {
+	if v, ok := _c.mutation.Label(); ok {
+		if err := pollinvite.LabelValidator(v); err != nil {
+			return &ValidationError{Name: "label", err: fmt.Errorf(`ent: validator failed for field "PollInvite.label": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PollInvite.created_at"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "PollInvite.poll"`)}
+	}
+	return nil
+}
+
+func (_c *PollInviteCreate) sqlSave(ctx context.Context) (*PollInvite, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PollInviteCreate) createSpec() (*PollInvite, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PollInvite{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(pollinvite.Table, sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt))
+	)
+	if value, ok := _c.mutation.Label(); ok {
+		_spec.SetField(pollinvite.FieldLabel, field.TypeString, value)
+		_node.Label = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(pollinvite.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
+	if value, ok := _c.mutation.RevokedAt(); ok {
+		_spec.SetField(pollinvite.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(pollinvite.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollinvite.PollTable,
+			Columns: []string{pollinvite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.poll_invites = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PollInviteCreateBulk is the builder for creating many PollInvite entities in bulk.
+type PollInviteCreateBulk struct {
+	config
+	err      error
+	builders []*PollInviteCreate
+}
+
+// Save creates the PollInvite entities in the database.
+func (_c *PollInviteCreateBulk) Save(ctx context.Context) ([]*PollInvite, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PollInvite, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PollInviteMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PollInviteCreateBulk) SaveX(ctx context.Context) []*PollInvite {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollInviteCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollInviteCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,457 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend/ent/poll"
+	"backend/ent/pollinvite"
+	"backend/ent/predicate"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PollInviteUpdate is the builder for updating PollInvite entities.
+type PollInviteUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PollInviteMutation
+}
+
+// Where appends a list predicates to the PollInviteUpdate builder.
+func (_u *PollInviteUpdate) Where(ps ...predicate.PollInvite) *PollInviteUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetLabel sets the "label" field.
+func (_u *PollInviteUpdate) SetLabel(v string) *PollInviteUpdate {
+	_u.mutation.SetLabel(v)
+	return _u
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_u *PollInviteUpdate) SetNillableLabel(v *string) *PollInviteUpdate {
+	if v != nil {
+		_u.SetLabel(*v)
+	}
+	return _u
+}
+
+// ClearLabel clears the value of the "label" field.
+func (_u *PollInviteUpdate) ClearLabel() *PollInviteUpdate {
+	_u.mutation.ClearLabel()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *PollInviteUpdate) SetExpiresAt(v time.Time) *PollInviteUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *PollInviteUpdate) SetNillableExpiresAt(v *time.Time) *PollInviteUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *PollInviteUpdate) ClearExpiresAt() *PollInviteUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *PollInviteUpdate) SetRevokedAt(v time.Time) *PollInviteUpdate {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *PollInviteUpdate) SetNillableRevokedAt(v *time.Time) *PollInviteUpdate {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *PollInviteUpdate) ClearRevokedAt() *PollInviteUpdate {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_u *PollInviteUpdate) SetPollID(id int) *PollInviteUpdate {
+	_u.mutation.SetPollID(id)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollInviteUpdate) SetPoll(v *Poll) *PollInviteUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the PollInviteMutation object of the builder.
+func (_u *PollInviteUpdate) Mutation() *PollInviteMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollInviteUpdate) ClearPoll() *PollInviteUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PollInviteUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollInviteUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PollInviteUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollInviteUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollInviteUpdate) check() error {
+	if v, ok := _u.mutation.Label(); ok {
+		if err := pollinvite.LabelValidator(v); err != nil {
+			return &ValidationError{Name: "label", err: fmt.Errorf(`ent: validator failed for field "PollInvite.label": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollInvite.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollInviteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollinvite.Table, pollinvite.Columns, sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Label(); ok {
+		_spec.SetField(pollinvite.FieldLabel, field.TypeString, value)
+	}
+	if _u.mutation.LabelCleared() {
+		_spec.ClearField(pollinvite.FieldLabel, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(pollinvite.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(pollinvite.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(pollinvite.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(pollinvite.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollinvite.PollTable,
+			Columns: []string{pollinvite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollinvite.PollTable,
+			Columns: []string{pollinvite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollinvite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PollInviteUpdateOne is the builder for updating a single PollInvite entity.
+type PollInviteUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollInviteMutation
+}
+
+// SetLabel sets the "label" field.
+func (_u *PollInviteUpdateOne) SetLabel(v string) *PollInviteUpdateOne {
+	_u.mutation.SetLabel(v)
+	return _u
+}
+
+// SetNillableLabel sets the "label" field if the given value is not nil.
+func (_u *PollInviteUpdateOne) SetNillableLabel(v *string) *PollInviteUpdateOne {
+	if v != nil {
+		_u.SetLabel(*v)
+	}
+	return _u
+}
+
+// ClearLabel clears the value of the "label" field.
+func (_u *PollInviteUpdateOne) ClearLabel() *PollInviteUpdateOne {
+	_u.mutation.ClearLabel()
+	return _u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_u *PollInviteUpdateOne) SetExpiresAt(v time.Time) *PollInviteUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *PollInviteUpdateOne) SetNillableExpiresAt(v *time.Time) *PollInviteUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *PollInviteUpdateOne) ClearExpiresAt() *PollInviteUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (_u *PollInviteUpdateOne) SetRevokedAt(v time.Time) *PollInviteUpdateOne {
+	_u.mutation.SetRevokedAt(v)
+	return _u
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (_u *PollInviteUpdateOne) SetNillableRevokedAt(v *time.Time) *PollInviteUpdateOne {
+	if v != nil {
+		_u.SetRevokedAt(*v)
+	}
+	return _u
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (_u *PollInviteUpdateOne) ClearRevokedAt() *PollInviteUpdateOne {
+	_u.mutation.ClearRevokedAt()
+	return _u
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (_u *PollInviteUpdateOne) SetPollID(id int) *PollInviteUpdateOne {
+	_u.mutation.SetPollID(id)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollInviteUpdateOne) SetPoll(v *Poll) *PollInviteUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the PollInviteMutation object of the builder.
+func (_u *PollInviteUpdateOne) Mutation() *PollInviteMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollInviteUpdateOne) ClearPoll() *PollInviteUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Where appends a list predicates to the PollInviteUpdate builder.
+func (_u *PollInviteUpdateOne) Where(ps ...predicate.PollInvite) *PollInviteUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PollInviteUpdateOne) Select(field string, fields ...string) *PollInviteUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PollInvite entity.
+func (_u *PollInviteUpdateOne) Save(ctx context.Context) (*PollInvite, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollInviteUpdateOne) SaveX(ctx context.Context) *PollInvite {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PollInviteUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollInviteUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollInviteUpdateOne) check() error {
+	if v, ok := _u.mutation.Label(); ok {
+		if err := pollinvite.LabelValidator(v); err != nil {
+			return &ValidationError{Name: "label", err: fmt.Errorf(`ent: validator failed for field "PollInvite.label": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollInvite.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollInviteUpdateOne) sqlSave(ctx context.Context) (_node *PollInvite, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollinvite.Table, pollinvite.Columns, sqlgraph.NewFieldSpec(pollinvite.FieldID, field.TypeInt))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PollInvite.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pollinvite.FieldID)
+		for _, f := range fields {
+			if !pollinvite.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != pollinvite.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Label(); ok {
+		_spec.SetField(pollinvite.FieldLabel, field.TypeString, value)
+	}
+	if _u.mutation.LabelCleared() {
+		_spec.ClearField(pollinvite.FieldLabel, field.TypeString)
+	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(pollinvite.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(pollinvite.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.RevokedAt(); ok {
+		_spec.SetField(pollinvite.FieldRevokedAt, field.TypeTime, value)
+	}
+	if _u.mutation.RevokedAtCleared() {
+		_spec.ClearField(pollinvite.FieldRevokedAt, field.TypeTime)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollinvite.PollTable,
+			Columns: []string{pollinvite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   pollinvite.PollTable,
+			Columns: []string{pollinvite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &PollInvite{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollinvite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,9 +6,15 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// AuditLog is the predicate function for auditlog builders.
+type AuditLog func(*sql.Selector)
+
 // Poll is the predicate function for poll builders.
 type Poll func(*sql.Selector)
 
+// PollInvite is the predicate function for pollinvite builders.
+type PollInvite func(*sql.Selector)
+
 // PollOption is the predicate function for polloption builders.
 type PollOption func(*sql.Selector)
 
@@ -17,3 +23,9 @@ type User func(*sql.Selector)
 
 // Vote is the predicate function for vote builders.
 type Vote func(*sql.Selector)
+
+// Webhook is the predicate function for webhook builders.
+type Webhook func(*sql.Selector)
+
+// WebhookDelivery is the predicate function for webhookdelivery builders.
+type WebhookDelivery func(*sql.Selector)
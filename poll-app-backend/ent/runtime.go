@@ -3,11 +3,15 @@
 package ent
 
 import (
+	"backend/ent/auditlog"
 	"backend/ent/poll"
+	"backend/ent/pollinvite"
 	"backend/ent/polloption"
 	"backend/ent/schema"
 	"backend/ent/user"
 	"backend/ent/vote"
+	"backend/ent/webhook"
+	"backend/ent/webhookdelivery"
 	"time"
 )
 
@@ -15,6 +19,16 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescAction is the schema descriptor for action field.
+	auditlogDescAction := auditlogFields[0].Descriptor()
+	// auditlog.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditlog.ActionValidator = auditlogDescAction.Validators[0].(func(string) error)
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[5].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
 	pollFields := schema.Poll{}.Fields()
 	_ = pollFields
 	// pollDescTitle is the schema descriptor for title field.
@@ -29,16 +43,48 @@ func init() {
 	pollDescMaxVotesPerUser := pollFields[4].Descriptor()
 	// poll.DefaultMaxVotesPerUser holds the default value on creation for the max_votes_per_user field.
 	poll.DefaultMaxVotesPerUser = pollDescMaxVotesPerUser.Default.(int)
+	// pollDescAllowVoteChanges is the schema descriptor for allow_vote_changes field.
+	pollDescAllowVoteChanges := pollFields[7].Descriptor()
+	// poll.DefaultAllowVoteChanges holds the default value on creation for the allow_vote_changes field.
+	poll.DefaultAllowVoteChanges = pollDescAllowVoteChanges.Default.(bool)
+	// pollDescState is the schema descriptor for state field.
+	pollDescState := pollFields[10].Descriptor()
+	// poll.DefaultState holds the default value on creation for the state field.
+	poll.DefaultState = pollDescState.Default.(string)
+	// pollDescVisibility is the schema descriptor for visibility field.
+	pollDescVisibility := pollFields[12].Descriptor()
+	// poll.DefaultVisibility holds the default value on creation for the visibility field.
+	poll.DefaultVisibility = pollDescVisibility.Default.(string)
+	// pollDescResultsVisibility is the schema descriptor for results_visibility field.
+	pollDescResultsVisibility := pollFields[13].Descriptor()
+	// poll.DefaultResultsVisibility holds the default value on creation for the results_visibility field.
+	poll.DefaultResultsVisibility = pollDescResultsVisibility.Default.(string)
+	// pollDescTotalVotes is the schema descriptor for total_votes field.
+	pollDescTotalVotes := pollFields[19].Descriptor()
+	// poll.DefaultTotalVotes holds the default value on creation for the total_votes field.
+	poll.DefaultTotalVotes = pollDescTotalVotes.Default.(int)
+	// poll.TotalVotesValidator is a validator for the "total_votes" field. It is called by the builders before save.
+	poll.TotalVotesValidator = pollDescTotalVotes.Validators[0].(func(int) error)
 	// pollDescCreatedAt is the schema descriptor for created_at field.
-	pollDescCreatedAt := pollFields[6].Descriptor()
+	pollDescCreatedAt := pollFields[20].Descriptor()
 	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
 	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
 	// pollDescUpdatedAt is the schema descriptor for updated_at field.
-	pollDescUpdatedAt := pollFields[7].Descriptor()
+	pollDescUpdatedAt := pollFields[21].Descriptor()
 	// poll.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	poll.DefaultUpdatedAt = pollDescUpdatedAt.Default.(func() time.Time)
 	// poll.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
 	poll.UpdateDefaultUpdatedAt = pollDescUpdatedAt.UpdateDefault.(func() time.Time)
+	pollinviteFields := schema.PollInvite{}.Fields()
+	_ = pollinviteFields
+	// pollinviteDescLabel is the schema descriptor for label field.
+	pollinviteDescLabel := pollinviteFields[0].Descriptor()
+	// pollinvite.LabelValidator is a validator for the "label" field. It is called by the builders before save.
+	pollinvite.LabelValidator = pollinviteDescLabel.Validators[0].(func(string) error)
+	// pollinviteDescCreatedAt is the schema descriptor for created_at field.
+	pollinviteDescCreatedAt := pollinviteFields[3].Descriptor()
+	// pollinvite.DefaultCreatedAt holds the default value on creation for the created_at field.
+	pollinvite.DefaultCreatedAt = pollinviteDescCreatedAt.Default.(func() time.Time)
 	polloptionFields := schema.PollOption{}.Fields()
 	_ = polloptionFields
 	// polloptionDescOptionText is the schema descriptor for option_text field.
@@ -55,14 +101,94 @@ func init() {
 	polloption.DefaultCreatedAt = polloptionDescCreatedAt.Default.(func() time.Time)
 	userFields := schema.User{}.Fields()
 	_ = userFields
+	// userDescDisplayName is the schema descriptor for display_name field.
+	userDescDisplayName := userFields[2].Descriptor()
+	// user.DisplayNameValidator is a validator for the "display_name" field. It is called by the builders before save.
+	user.DisplayNameValidator = userDescDisplayName.Validators[0].(func(string) error)
+	// userDescAvatarURL is the schema descriptor for avatar_url field.
+	userDescAvatarURL := userFields[3].Descriptor()
+	// user.AvatarURLValidator is a validator for the "avatar_url" field. It is called by the builders before save.
+	user.AvatarURLValidator = userDescAvatarURL.Validators[0].(func(string) error)
+	// userDescTokenVersion is the schema descriptor for token_version field.
+	userDescTokenVersion := userFields[5].Descriptor()
+	// user.DefaultTokenVersion holds the default value on creation for the token_version field.
+	user.DefaultTokenVersion = userDescTokenVersion.Default.(int)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[2].Descriptor()
+	userDescCreatedAt := userFields[7].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userFields[8].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
 	voteFields := schema.Vote{}.Fields()
 	_ = voteFields
+	// voteDescRank is the schema descriptor for rank field.
+	voteDescRank := voteFields[1].Descriptor()
+	// vote.RankValidator is a validator for the "rank" field. It is called by the builders before save.
+	vote.RankValidator = voteDescRank.Validators[0].(func(int) error)
 	// voteDescCreatedAt is the schema descriptor for created_at field.
-	voteDescCreatedAt := voteFields[1].Descriptor()
+	voteDescCreatedAt := voteFields[3].Descriptor()
 	// vote.DefaultCreatedAt holds the default value on creation for the created_at field.
 	vote.DefaultCreatedAt = voteDescCreatedAt.Default.(func() time.Time)
+	webhookFields := schema.Webhook{}.Fields()
+	_ = webhookFields
+	// webhookDescURL is the schema descriptor for url field.
+	webhookDescURL := webhookFields[0].Descriptor()
+	// webhook.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhook.URLValidator = func() func(string) error {
+		validators := webhookDescURL.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(url string) error {
+			for _, fn := range fns {
+				if err := fn(url); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhookDescSecret is the schema descriptor for secret field.
+	webhookDescSecret := webhookFields[1].Descriptor()
+	// webhook.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhook.SecretValidator = webhookDescSecret.Validators[0].(func(string) error)
+	// webhookDescActive is the schema descriptor for active field.
+	webhookDescActive := webhookFields[3].Descriptor()
+	// webhook.DefaultActive holds the default value on creation for the active field.
+	webhook.DefaultActive = webhookDescActive.Default.(bool)
+	// webhookDescCreatedAt is the schema descriptor for created_at field.
+	webhookDescCreatedAt := webhookFields[4].Descriptor()
+	// webhook.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhook.DefaultCreatedAt = webhookDescCreatedAt.Default.(func() time.Time)
+	// webhookDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookDescUpdatedAt := webhookFields[5].Descriptor()
+	// webhook.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhook.DefaultUpdatedAt = webhookDescUpdatedAt.Default.(func() time.Time)
+	// webhook.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhook.UpdateDefaultUpdatedAt = webhookDescUpdatedAt.UpdateDefault.(func() time.Time)
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescEvent is the schema descriptor for event field.
+	webhookdeliveryDescEvent := webhookdeliveryFields[0].Descriptor()
+	// webhookdelivery.EventValidator is a validator for the "event" field. It is called by the builders before save.
+	webhookdelivery.EventValidator = webhookdeliveryDescEvent.Validators[0].(func(string) error)
+	// webhookdeliveryDescStatus is the schema descriptor for status field.
+	webhookdeliveryDescStatus := webhookdeliveryFields[2].Descriptor()
+	// webhookdelivery.DefaultStatus holds the default value on creation for the status field.
+	webhookdelivery.DefaultStatus = webhookdeliveryDescStatus.Default.(string)
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[3].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdelivery.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
+	webhookdelivery.AttemptsValidator = webhookdeliveryDescAttempts.Validators[0].(func(int) error)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryFields[8].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
 }
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+type AuditLog struct {
+	ent.Schema
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("action").
+			NotEmpty().
+			Immutable().
+			Comment("What happened, e.g. poll.deleted or user.disabled"),
+		field.String("actor_email").
+			Immutable().
+			Comment("Email of the user who did it, kept after the account is gone"),
+		field.String("target_type").
+			Immutable().
+			Comment("Kind of record acted on: poll or user"),
+		field.Int("target_id").
+			Immutable().
+			Comment("ID of the record acted on"),
+		field.JSON("details", map[string]any{}).
+			Optional().
+			Immutable().
+			Comment("Action-specific context, such as the old and new role"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the action happened"),
+	}
+}
+
+// Edges of the AuditLog.
+func (AuditLog) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Many entries belong to one acting user
+		edge.From("actor", User.Type).
+			Ref("audit_logs").
+			Unique().
+			Comment("The user who did it, unset once the account is deleted"),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		// Lookups of everything that happened to one record
+		index.Fields("target_type", "target_id"),
+		index.Fields("action"),
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Poll holds the schema definition for the Poll entity.
@@ -24,16 +26,66 @@ func (Poll) Fields() []ent.Field {
 			Comment("Optional poll description"),
 		field.String("poll_type").
 			Default("single_choice").
-			Comment("Type of poll: single_choice, multiple_choice"),
+			Comment("Type of poll: single_choice, multiple_choice, ranked_choice, rating"),
 		field.String("created_by").
 			Optional().
-			Comment("Email of user who created the poll"),
+			Comment("Email of user who created the poll (kept alongside the owner edge)"),
 		field.Int("max_votes_per_user").
 			Default(1).
-			Comment("Maximum votes allowed per user"),
+			Comment("Maximum votes allowed per user (options ranked, for ranked_choice)"),
+		field.Int("rating_min").
+			Optional().
+			Nillable().
+			Comment("Lowest score voters can give on rating polls"),
+		field.Int("rating_max").
+			Optional().
+			Nillable().
+			Comment("Highest score voters can give on rating polls"),
+		field.Bool("allow_vote_changes").
+			Default(true).
+			Comment("Whether voters may change or withdraw their vote"),
+		field.Time("opens_at").
+			Optional().
+			Nillable().
+			Comment("When a draft poll starts taking votes; nil if it opened on creation"),
 		field.Time("expires_at").
 			Optional().
 			Comment("When the poll expires"),
+		field.String("state").
+			Default("open").
+			Comment("Lifecycle state: draft (waiting for opens_at), open, closed, or template for recurring polls"),
+		field.Time("closed_at").
+			Optional().
+			Nillable().
+			Comment("When the poll was closed, early by its creator or at expiry; nil while it is open"),
+		field.String("visibility").
+			Default("public").
+			Comment("Who can find and vote on the poll: public, unlisted or private"),
+		field.String("results_visibility").
+			Default("always").
+			Comment("When vote counts are shown: always, after_vote, after_close or never (creator only)"),
+		field.Strings("invited_emails").
+			Optional().
+			StructTag(`json:"-"`).
+			Comment("Emails allowed to see and vote on a private poll"),
+		field.String("recurrence").
+			Optional().
+			Comment("Cron schedule a recurring template creates occurrences on; empty for other polls"),
+		field.String("recurrence_timezone").
+			Optional().
+			Comment("IANA time zone the recurrence is read in; UTC when empty"),
+		field.Time("next_occurrence_at").
+			Optional().
+			Nillable().
+			Comment("When a recurring template next creates an occurrence"),
+		field.Text("search_text").
+			Optional().
+			StructTag(`json:"-"`).
+			Comment("Option texts joined together, indexed for full-text search"),
+		field.Int("total_votes").
+			Default(0).
+			NonNegative().
+			Comment("Sum of the options' vote counts, kept in step for sorting"),
 		field.Time("created_at").
 			Default(time.Now).
 			Comment("Poll creation timestamp"),
@@ -53,5 +105,36 @@ func (Poll) Edges() []ent.Edge {
 		// One poll has many votes
 		edge.To("votes", Vote.Type).
 			Comment("Votes cast on this poll"),
+		// One poll has many invite links, removed along with it
+		edge.To("invites", PollInvite.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Invite links to a private poll"),
+		// A recurring template has one poll per occurrence; occurrences
+		// outlive their template
+		edge.To("occurrences", Poll.Type).
+			Annotations(entsql.OnDelete(entsql.SetNull)).
+			Comment("Polls created from this recurring template").
+			From("template").
+			Unique().
+			Comment("The recurring template this poll is an occurrence of"),
+		// Many polls belong to one user
+		edge.From("owner", User.Type).
+			Ref("polls").
+			Unique().
+			Comment("The user who created this poll"),
+	}
+}
+
+// Indexes of the Poll.
+func (Poll) Indexes() []ent.Index {
+	return []ent.Index{
+		// Keyset pagination for each GET /polls sort order
+		index.Fields("created_at", "id"),
+		index.Fields("total_votes", "id"),
+		index.Fields("expires_at", "id"),
+		// Due-poll lookups by the scheduler
+		index.Fields("state", "opens_at"),
+		index.Fields("state", "expires_at"),
+		index.Fields("state", "next_occurrence_at"),
 	}
 }
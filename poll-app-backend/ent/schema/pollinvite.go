@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// PollInvite holds the schema definition for the PollInvite entity.
+type PollInvite struct {
+	ent.Schema
+}
+
+// Fields of the PollInvite.
+func (PollInvite) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("label").
+			Optional().
+			MaxLen(100).
+			Comment("Creator's note on who the link was shared with"),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Comment("When the link stops working; nil for never"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable().
+			Comment("When the creator revoked the link; nil while it works"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the link was generated"),
+	}
+}
+
+// Edges of the PollInvite.
+func (PollInvite) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Many invite links belong to one poll
+		edge.From("poll", Poll.Type).
+			Ref("invites").
+			Unique().
+			Required().
+			Comment("The poll the link grants access to"),
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
 )
 
@@ -20,14 +23,63 @@ func (User) Fields() []ent.Field {
 			Comment("User email address for login"),
 		field.String("password").
 			Sensitive().
-			Comment("User password (plain text for now)"),
+			Comment("bcrypt hash of the user password (legacy rows may still be plain text until next login)"),
+		field.String("display_name").
+			Optional().
+			MaxLen(100).
+			Comment("Name shown to other users"),
+		field.String("avatar_url").
+			Optional().
+			MaxLen(2048).
+			Comment("URL of the user's profile picture"),
+		field.Enum("role").
+			Values("admin", "creator", "voter").
+			Default("voter").
+			Comment("Access level: admin, creator or voter; new accounts are voters until an admin promotes them"),
+		field.Int("token_version").
+			Default(0).
+			StructTag(`json:"-"`).
+			Comment("Bumped when the password changes; session tokens carrying an older version are rejected"),
+		field.Time("disabled_at").
+			Optional().
+			Nillable().
+			Comment("When an admin disabled the account; disabled accounts can't sign in"),
 		field.Time("created_at").
 			Default(time.Now).
+			Immutable().
 			Comment("User creation timestamp"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("User last update timestamp"),
+	}
+}
+
+// Annotations of the User.
+func (User) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		// ent checks roles on write; the database refuses any other value too
+		entsql.Checks(map[string]string{
+			"users_role_check": "role IN ('admin', 'creator', 'voter')",
+		}),
 	}
 }
 
 // Edges of the User.
 func (User) Edges() []ent.Edge {
-	return nil
+	return []ent.Edge{
+		// One user creates many polls
+		edge.To("polls", Poll.Type).
+			Comment("Polls created by this user"),
+		// One user casts many votes
+		edge.To("votes", Vote.Type).
+			Comment("Votes cast by this user"),
+		// One user owns many webhooks, removed along with the account
+		edge.To("webhooks", Webhook.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Webhook subscriptions for this user's polls"),
+		// One user performs many audited actions; the log outlives the account
+		edge.To("audit_logs", AuditLog.Type).
+			Comment("Audited actions taken by this user"),
+	}
 }
@@ -6,6 +6,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Vote holds the schema definition for the Vote entity.
@@ -18,7 +19,16 @@ func (Vote) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("voter_identifier").
 			Optional().
-			Comment("Anonymous identifier for the voter (IP, session, etc.)"),
+			Comment("Who cast the vote: the user's email, or anon: and the ID in an anonymous voter's cookie"),
+		field.Int("rank").
+			Optional().
+			Nillable().
+			Positive().
+			Comment("Preference position (1 = first choice) on ranked_choice polls"),
+		field.Int("score").
+			Optional().
+			Nillable().
+			Comment("Score given to the option on rating polls"),
 		field.Time("created_at").
 			Default(time.Now).
 			Comment("When the vote was cast"),
@@ -40,5 +50,21 @@ func (Vote) Edges() []ent.Edge {
 			Unique().
 			Required().
 			Comment("The option this vote is for"),
+		// Many votes belong to one user; left empty for anonymous votes and
+		// for legacy votes whose identifier matches no account
+		edge.From("voter", User.Type).
+			Ref("votes").
+			Unique().
+			Comment("The user who cast this vote"),
+	}
+}
+
+// Indexes of the Vote.
+func (Vote) Indexes() []ent.Index {
+	return []ent.Index{
+		// A voter can pick each option of a poll at most once
+		index.Fields("voter_identifier").
+			Edges("poll", "option").
+			Unique(),
 	}
 }
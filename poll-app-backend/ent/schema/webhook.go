@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Webhook holds the schema definition for the Webhook entity.
+type Webhook struct {
+	ent.Schema
+}
+
+// Fields of the Webhook.
+func (Webhook) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("url").
+			NotEmpty().
+			MaxLen(2048).
+			Comment("Endpoint events are POSTed to"),
+		field.String("secret").
+			Sensitive().
+			NotEmpty().
+			Comment("Key used to HMAC-sign every delivery"),
+		field.Strings("events").
+			Comment("Event types this subscription receives"),
+		field.Bool("active").
+			Default(true).
+			Comment("Inactive webhooks receive no new deliveries"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("Webhook creation timestamp"),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now).
+			Comment("Webhook last update timestamp"),
+	}
+}
+
+// Edges of the Webhook.
+func (Webhook) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Many webhooks belong to one user
+		edge.From("owner", User.Type).
+			Ref("webhooks").
+			Unique().
+			Required().
+			Comment("The user whose polls this webhook reports on"),
+		// One webhook has many deliveries
+		edge.To("deliveries", WebhookDelivery.Type).
+			Annotations(entsql.OnDelete(entsql.Cascade)).
+			Comment("Delivery log of this webhook"),
+	}
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebhookDelivery holds the schema definition for the WebhookDelivery entity.
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("event").
+			NotEmpty().
+			Comment("Event type, e.g. poll.voted"),
+		field.Text("payload").
+			Comment("JSON body sent to the webhook"),
+		field.String("status").
+			Default("pending").
+			Comment("Delivery state: pending, succeeded, failed"),
+		field.Int("attempts").
+			Default(0).
+			NonNegative().
+			Comment("How many times delivery has been tried"),
+		field.Int("response_status").
+			Optional().
+			Nillable().
+			Comment("HTTP status of the last attempt, if a response came back"),
+		field.String("last_error").
+			Optional().
+			Comment("Why the last attempt failed"),
+		field.Time("next_attempt_at").
+			Optional().
+			Nillable().
+			Comment("When a pending delivery is next tried"),
+		field.Time("delivered_at").
+			Optional().
+			Nillable().
+			Comment("When the webhook acknowledged the delivery"),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			Comment("When the event was queued"),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Many deliveries belong to one webhook
+		edge.From("webhook", Webhook.Type).
+			Ref("deliveries").
+			Unique().
+			Required().
+			Comment("The webhook this delivery is for"),
+	}
+}
+
+// Indexes of the WebhookDelivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		// The dispatcher polls for pending deliveries that are due
+		index.Fields("status", "next_attempt_at"),
+	}
+}
@@ -19,23 +19,89 @@ type User struct {
 	ID int `json:"id,omitempty"`
 	// User email address for login
 	Email string `json:"email,omitempty"`
-	// User password (plain text for now)
+	// bcrypt hash of the user password (legacy rows may still be plain text until next login)
 	Password string `json:"-"`
+	// Name shown to other users
+	DisplayName string `json:"display_name,omitempty"`
+	// URL of the user's profile picture
+	AvatarURL string `json:"avatar_url,omitempty"`
+	// Access level: admin, creator or voter; new accounts are voters until an admin promotes them
+	Role user.Role `json:"role,omitempty"`
+	// Bumped when the password changes; session tokens carrying an older version are rejected
+	TokenVersion int `json:"-"`
+	// When an admin disabled the account; disabled accounts can't sign in
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
 	// User creation timestamp
-	CreatedAt    time.Time `json:"created_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// User last update timestamp
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the UserQuery when eager-loading is set.
+	Edges        UserEdges `json:"edges"`
 	selectValues sql.SelectValues
 }
 
+// UserEdges holds the relations/edges for other nodes in the graph.
+type UserEdges struct {
+	// Polls created by this user
+	Polls []*Poll `json:"polls,omitempty"`
+	// Votes cast by this user
+	Votes []*Vote `json:"votes,omitempty"`
+	// Webhook subscriptions for this user's polls
+	Webhooks []*Webhook `json:"webhooks,omitempty"`
+	// Audited actions taken by this user
+	AuditLogs []*AuditLog `json:"audit_logs,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [4]bool
+}
+
+// PollsOrErr returns the Polls value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PollsOrErr() ([]*Poll, error) {
+	if e.loadedTypes[0] {
+		return e.Polls, nil
+	}
+	return nil, &NotLoadedError{edge: "polls"}
+}
+
+// VotesOrErr returns the Votes value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) VotesOrErr() ([]*Vote, error) {
+	if e.loadedTypes[1] {
+		return e.Votes, nil
+	}
+	return nil, &NotLoadedError{edge: "votes"}
+}
+
+// WebhooksOrErr returns the Webhooks value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) WebhooksOrErr() ([]*Webhook, error) {
+	if e.loadedTypes[2] {
+		return e.Webhooks, nil
+	}
+	return nil, &NotLoadedError{edge: "webhooks"}
+}
+
+// AuditLogsOrErr returns the AuditLogs value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) AuditLogsOrErr() ([]*AuditLog, error) {
+	if e.loadedTypes[3] {
+		return e.AuditLogs, nil
+	}
+	return nil, &NotLoadedError{edge: "audit_logs"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldID:
+		case user.FieldID, user.FieldTokenVersion:
 			values[i] = new(sql.NullInt64)
-		case user.FieldEmail, user.FieldPassword:
+		case user.FieldEmail, user.FieldPassword, user.FieldDisplayName, user.FieldAvatarURL, user.FieldRole:
 			values[i] = new(sql.NullString)
-		case user.FieldCreatedAt:
+		case user.FieldDisabledAt, user.FieldCreatedAt, user.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -70,12 +136,49 @@ func (_m *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Password = value.String
 			}
+		case user.FieldDisplayName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field display_name", values[i])
+			} else if value.Valid {
+				_m.DisplayName = value.String
+			}
+		case user.FieldAvatarURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field avatar_url", values[i])
+			} else if value.Valid {
+				_m.AvatarURL = value.String
+			}
+		case user.FieldRole:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field role", values[i])
+			} else if value.Valid {
+				_m.Role = user.Role(value.String)
+			}
+		case user.FieldTokenVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field token_version", values[i])
+			} else if value.Valid {
+				_m.TokenVersion = int(value.Int64)
+			}
+		case user.FieldDisabledAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field disabled_at", values[i])
+			} else if value.Valid {
+				_m.DisabledAt = new(time.Time)
+				*_m.DisabledAt = value.Time
+			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
 			} else if value.Valid {
 				_m.CreatedAt = value.Time
 			}
+		case user.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -89,6 +192,26 @@ func (_m *User) Value(name string) (ent.Value, error) {
 	return _m.selectValues.Get(name)
 }
 
+// QueryPolls queries the "polls" edge of the User entity.
+func (_m *User) QueryPolls() *PollQuery {
+	return NewUserClient(_m.config).QueryPolls(_m)
+}
+
+// QueryVotes queries the "votes" edge of the User entity.
+func (_m *User) QueryVotes() *VoteQuery {
+	return NewUserClient(_m.config).QueryVotes(_m)
+}
+
+// QueryWebhooks queries the "webhooks" edge of the User entity.
+func (_m *User) QueryWebhooks() *WebhookQuery {
+	return NewUserClient(_m.config).QueryWebhooks(_m)
+}
+
+// QueryAuditLogs queries the "audit_logs" edge of the User entity.
+func (_m *User) QueryAuditLogs() *AuditLogQuery {
+	return NewUserClient(_m.config).QueryAuditLogs(_m)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -117,8 +240,28 @@ func (_m *User) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("password=<sensitive>")
 	builder.WriteString(", ")
+	builder.WriteString("display_name=")
+	builder.WriteString(_m.DisplayName)
+	builder.WriteString(", ")
+	builder.WriteString("avatar_url=")
+	builder.WriteString(_m.AvatarURL)
+	builder.WriteString(", ")
+	builder.WriteString("role=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Role))
+	builder.WriteString(", ")
+	builder.WriteString("token_version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TokenVersion))
+	builder.WriteString(", ")
+	if v := _m.DisabledAt; v != nil {
+		builder.WriteString("disabled_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
 	return builder.String()
 }
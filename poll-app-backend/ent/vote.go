@@ -5,6 +5,7 @@ package ent
 import (
 	"backend/ent/poll"
 	"backend/ent/polloption"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"fmt"
 	"strings"
@@ -19,8 +20,12 @@ type Vote struct {
 	config `json:"-"`
 	// ID of the ent.
 	ID int `json:"id,omitempty"`
-	// Anonymous identifier for the voter (IP, session, etc.)
+	// Who cast the vote: the user's email, or anon: and the ID in an anonymous voter's cookie
 	VoterIdentifier string `json:"voter_identifier,omitempty"`
+	// Preference position (1 = first choice) on ranked_choice polls
+	Rank *int `json:"rank,omitempty"`
+	// Score given to the option on rating polls
+	Score *int `json:"score,omitempty"`
 	// When the vote was cast
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -28,6 +33,7 @@ type Vote struct {
 	Edges             VoteEdges `json:"edges"`
 	poll_votes        *int
 	poll_option_votes *int
+	user_votes        *int
 	selectValues      sql.SelectValues
 }
 
@@ -37,9 +43,11 @@ type VoteEdges struct {
 	Poll *Poll `json:"poll,omitempty"`
 	// The option this vote is for
 	Option *PollOption `json:"option,omitempty"`
+	// The user who cast this vote
+	Voter *User `json:"voter,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [3]bool
 }
 
 // PollOrErr returns the Poll value or an error if the edge
@@ -64,12 +72,23 @@ func (e VoteEdges) OptionOrErr() (*PollOption, error) {
 	return nil, &NotLoadedError{edge: "option"}
 }
 
+// VoterOrErr returns the Voter value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e VoteEdges) VoterOrErr() (*User, error) {
+	if e.Voter != nil {
+		return e.Voter, nil
+	} else if e.loadedTypes[2] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "voter"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Vote) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case vote.FieldID:
+		case vote.FieldID, vote.FieldRank, vote.FieldScore:
 			values[i] = new(sql.NullInt64)
 		case vote.FieldVoterIdentifier:
 			values[i] = new(sql.NullString)
@@ -79,6 +98,8 @@ func (*Vote) scanValues(columns []string) ([]any, error) {
 			values[i] = new(sql.NullInt64)
 		case vote.ForeignKeys[1]: // poll_option_votes
 			values[i] = new(sql.NullInt64)
+		case vote.ForeignKeys[2]: // user_votes
+			values[i] = new(sql.NullInt64)
 		default:
 			values[i] = new(sql.UnknownType)
 		}
@@ -106,6 +127,20 @@ func (_m *Vote) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.VoterIdentifier = value.String
 			}
+		case vote.FieldRank:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rank", values[i])
+			} else if value.Valid {
+				_m.Rank = new(int)
+				*_m.Rank = int(value.Int64)
+			}
+		case vote.FieldScore:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score", values[i])
+			} else if value.Valid {
+				_m.Score = new(int)
+				*_m.Score = int(value.Int64)
+			}
 		case vote.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -126,6 +161,13 @@ func (_m *Vote) assignValues(columns []string, values []any) error {
 				_m.poll_option_votes = new(int)
 				*_m.poll_option_votes = int(value.Int64)
 			}
+		case vote.ForeignKeys[2]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_votes", value)
+			} else if value.Valid {
+				_m.user_votes = new(int)
+				*_m.user_votes = int(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -149,6 +191,11 @@ func (_m *Vote) QueryOption() *PollOptionQuery {
 	return NewVoteClient(_m.config).QueryOption(_m)
 }
 
+// QueryVoter queries the "voter" edge of the Vote entity.
+func (_m *Vote) QueryVoter() *UserQuery {
+	return NewVoteClient(_m.config).QueryVoter(_m)
+}
+
 // Update returns a builder for updating this Vote.
 // Note that you need to call Vote.Unwrap() before calling this method if this Vote
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -175,6 +222,16 @@ func (_m *Vote) String() string {
 	builder.WriteString("voter_identifier=")
 	builder.WriteString(_m.VoterIdentifier)
 	builder.WriteString(", ")
+	if v := _m.Rank; v != nil {
+		builder.WriteString("rank=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.Score; v != nil {
+		builder.WriteString("score=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
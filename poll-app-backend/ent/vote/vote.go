@@ -16,12 +16,18 @@ const (
 	FieldID = "id"
 	// FieldVoterIdentifier holds the string denoting the voter_identifier field in the database.
 	FieldVoterIdentifier = "voter_identifier"
+	// FieldRank holds the string denoting the rank field in the database.
+	FieldRank = "rank"
+	// FieldScore holds the string denoting the score field in the database.
+	FieldScore = "score"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgePoll holds the string denoting the poll edge name in mutations.
 	EdgePoll = "poll"
 	// EdgeOption holds the string denoting the option edge name in mutations.
 	EdgeOption = "option"
+	// EdgeVoter holds the string denoting the voter edge name in mutations.
+	EdgeVoter = "voter"
 	// Table holds the table name of the vote in the database.
 	Table = "votes"
 	// PollTable is the table that holds the poll relation/edge.
@@ -38,12 +44,21 @@ const (
 	OptionInverseTable = "poll_options"
 	// OptionColumn is the table column denoting the option relation/edge.
 	OptionColumn = "poll_option_votes"
+	// VoterTable is the table that holds the voter relation/edge.
+	VoterTable = "votes"
+	// VoterInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	VoterInverseTable = "users"
+	// VoterColumn is the table column denoting the voter relation/edge.
+	VoterColumn = "user_votes"
 )
 
 // Columns holds all SQL columns for vote fields.
 var Columns = []string{
 	FieldID,
 	FieldVoterIdentifier,
+	FieldRank,
+	FieldScore,
 	FieldCreatedAt,
 }
 
@@ -52,6 +67,7 @@ var Columns = []string{
 var ForeignKeys = []string{
 	"poll_votes",
 	"poll_option_votes",
+	"user_votes",
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -70,6 +86,8 @@ func ValidColumn(column string) bool {
 }
 
 var (
+	// RankValidator is a validator for the "rank" field. It is called by the builders before save.
+	RankValidator func(int) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 )
@@ -87,6 +105,16 @@ func ByVoterIdentifier(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldVoterIdentifier, opts...).ToFunc()
 }
 
+// ByRank orders the results by the rank field.
+func ByRank(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRank, opts...).ToFunc()
+}
+
+// ByScore orders the results by the score field.
+func ByScore(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScore, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -105,6 +133,13 @@ func ByOptionField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newOptionStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByVoterField orders the results by voter field.
+func ByVoterField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newVoterStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newPollStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -119,3 +154,10 @@ func newOptionStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, true, OptionTable, OptionColumn),
 	)
 }
+func newVoterStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(VoterInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, VoterTable, VoterColumn),
+	)
+}
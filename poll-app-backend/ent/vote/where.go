@@ -60,6 +60,16 @@ func VoterIdentifier(v string) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldVoterIdentifier, v))
 }
 
+// Rank applies equality check predicate on the "rank" field. It's identical to RankEQ.
+func Rank(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldRank, v))
+}
+
+// Score applies equality check predicate on the "score" field. It's identical to ScoreEQ.
+func Score(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldScore, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
@@ -140,6 +150,106 @@ func VoterIdentifierContainsFold(v string) predicate.Vote {
 	return predicate.Vote(sql.FieldContainsFold(FieldVoterIdentifier, v))
 }
 
+// RankEQ applies the EQ predicate on the "rank" field.
+func RankEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldRank, v))
+}
+
+// RankNEQ applies the NEQ predicate on the "rank" field.
+func RankNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldRank, v))
+}
+
+// RankIn applies the In predicate on the "rank" field.
+func RankIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldRank, vs...))
+}
+
+// RankNotIn applies the NotIn predicate on the "rank" field.
+func RankNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldRank, vs...))
+}
+
+// RankGT applies the GT predicate on the "rank" field.
+func RankGT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldRank, v))
+}
+
+// RankGTE applies the GTE predicate on the "rank" field.
+func RankGTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldRank, v))
+}
+
+// RankLT applies the LT predicate on the "rank" field.
+func RankLT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldRank, v))
+}
+
+// RankLTE applies the LTE predicate on the "rank" field.
+func RankLTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldRank, v))
+}
+
+// RankIsNil applies the IsNil predicate on the "rank" field.
+func RankIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldRank))
+}
+
+// RankNotNil applies the NotNil predicate on the "rank" field.
+func RankNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldRank))
+}
+
+// ScoreEQ applies the EQ predicate on the "score" field.
+func ScoreEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldScore, v))
+}
+
+// ScoreNEQ applies the NEQ predicate on the "score" field.
+func ScoreNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldScore, v))
+}
+
+// ScoreIn applies the In predicate on the "score" field.
+func ScoreIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldScore, vs...))
+}
+
+// ScoreNotIn applies the NotIn predicate on the "score" field.
+func ScoreNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldScore, vs...))
+}
+
+// ScoreGT applies the GT predicate on the "score" field.
+func ScoreGT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldScore, v))
+}
+
+// ScoreGTE applies the GTE predicate on the "score" field.
+func ScoreGTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldScore, v))
+}
+
+// ScoreLT applies the LT predicate on the "score" field.
+func ScoreLT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldScore, v))
+}
+
+// ScoreLTE applies the LTE predicate on the "score" field.
+func ScoreLTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldScore, v))
+}
+
+// ScoreIsNil applies the IsNil predicate on the "score" field.
+func ScoreIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldScore))
+}
+
+// ScoreNotNil applies the NotNil predicate on the "score" field.
+func ScoreNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldScore))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
@@ -226,6 +336,29 @@ func HasOptionWith(preds ...predicate.PollOption) predicate.Vote {
 	})
 }
 
+// HasVoter applies the HasEdge predicate on the "voter" edge.
+func HasVoter() predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, VoterTable, VoterColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasVoterWith applies the HasEdge predicate on the "voter" edge with a given conditions (other predicates).
+func HasVoterWith(preds ...predicate.User) predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := newVoterStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Vote) predicate.Vote {
 	return predicate.Vote(sql.AndPredicates(predicates...))
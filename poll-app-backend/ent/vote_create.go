@@ -5,6 +5,7 @@ package ent
 import (
 	"backend/ent/poll"
 	"backend/ent/polloption"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"errors"
@@ -36,6 +37,34 @@ func (_c *VoteCreate) SetNillableVoterIdentifier(v *string) *VoteCreate {
 	return _c
 }
 
+// SetRank sets the "rank" field.
+func (_c *VoteCreate) SetRank(v int) *VoteCreate {
+	_c.mutation.SetRank(v)
+	return _c
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableRank(v *int) *VoteCreate {
+	if v != nil {
+		_c.SetRank(*v)
+	}
+	return _c
+}
+
+// SetScore sets the "score" field.
+func (_c *VoteCreate) SetScore(v int) *VoteCreate {
+	_c.mutation.SetScore(v)
+	return _c
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableScore(v *int) *VoteCreate {
+	if v != nil {
+		_c.SetScore(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *VoteCreate) SetCreatedAt(v time.Time) *VoteCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -72,6 +101,25 @@ func (_c *VoteCreate) SetOption(v *PollOption) *VoteCreate {
 	return _c.SetOptionID(v.ID)
 }
 
+// SetVoterID sets the "voter" edge to the User entity by ID.
+func (_c *VoteCreate) SetVoterID(id int) *VoteCreate {
+	_c.mutation.SetVoterID(id)
+	return _c
+}
+
+// SetNillableVoterID sets the "voter" edge to the User entity by ID if the given value is not nil.
+func (_c *VoteCreate) SetNillableVoterID(id *int) *VoteCreate {
+	if id != nil {
+		_c = _c.SetVoterID(*id)
+	}
+	return _c
+}
+
+// SetVoter sets the "voter" edge to the User entity.
+func (_c *VoteCreate) SetVoter(v *User) *VoteCreate {
+	return _c.SetVoterID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_c *VoteCreate) Mutation() *VoteMutation {
 	return _c.mutation
@@ -115,6 +163,11 @@ func (_c *VoteCreate) defaults() {
 
 // check runs all checks and user-defined validators on the builder.
 func (_c *VoteCreate) check() error {
+	if v, ok := _c.mutation.Rank(); ok {
+		if err := vote.RankValidator(v); err != nil {
+			return &ValidationError{Name: "rank", err: fmt.Errorf(`ent: validator failed for field "Vote.rank": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Vote.created_at"`)}
 	}
@@ -154,6 +207,14 @@ func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_spec.SetField(vote.FieldVoterIdentifier, field.TypeString, value)
 		_node.VoterIdentifier = value
 	}
+	if value, ok := _c.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+		_node.Rank = &value
+	}
+	if value, ok := _c.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+		_node.Score = &value
+	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -192,6 +253,23 @@ func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_node.poll_option_votes = &nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.VoterIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.VoterTable,
+			Columns: []string{vote.VoterColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_votes = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -6,12 +6,14 @@ import (
 	"backend/ent/poll"
 	"backend/ent/polloption"
 	"backend/ent/predicate"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"fmt"
 	"math"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -26,7 +28,9 @@ type VoteQuery struct {
 	predicates []predicate.Vote
 	withPoll   *PollQuery
 	withOption *PollOptionQuery
+	withVoter  *UserQuery
 	withFKs    bool
+	modifiers  []func(*sql.Selector)
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -107,6 +111,28 @@ func (_q *VoteQuery) QueryOption() *PollOptionQuery {
 	return query
 }
 
+// QueryVoter chains the current query on the "voter" edge.
+func (_q *VoteQuery) QueryVoter() *UserQuery {
+	query := (&UserClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(vote.Table, vote.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, vote.VoterTable, vote.VoterColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Vote entity from the query.
 // Returns a *NotFoundError when no Vote was found.
 func (_q *VoteQuery) First(ctx context.Context) (*Vote, error) {
@@ -301,6 +327,7 @@ func (_q *VoteQuery) Clone() *VoteQuery {
 		predicates: append([]predicate.Vote{}, _q.predicates...),
 		withPoll:   _q.withPoll.Clone(),
 		withOption: _q.withOption.Clone(),
+		withVoter:  _q.withVoter.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -329,6 +356,17 @@ func (_q *VoteQuery) WithOption(opts ...func(*PollOptionQuery)) *VoteQuery {
 	return _q
 }
 
+// WithVoter tells the query-builder to eager-load the nodes that are connected to
+// the "voter" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *VoteQuery) WithVoter(opts ...func(*UserQuery)) *VoteQuery {
+	query := (&UserClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withVoter = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -408,12 +446,13 @@ func (_q *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 		nodes       = []*Vote{}
 		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [3]bool{
 			_q.withPoll != nil,
 			_q.withOption != nil,
+			_q.withVoter != nil,
 		}
 	)
-	if _q.withPoll != nil || _q.withOption != nil {
+	if _q.withPoll != nil || _q.withOption != nil || _q.withVoter != nil {
 		withFKs = true
 	}
 	if withFKs {
@@ -428,6 +467,9 @@ func (_q *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 		node.Edges.loadedTypes = loadedTypes
 		return node.assignValues(columns, values)
 	}
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	for i := range hooks {
 		hooks[i](ctx, _spec)
 	}
@@ -449,6 +491,12 @@ func (_q *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 			return nil, err
 		}
 	}
+	if query := _q.withVoter; query != nil {
+		if err := _q.loadVoter(ctx, query, nodes, nil,
+			func(n *Vote, e *User) { n.Edges.Voter = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -516,9 +564,44 @@ func (_q *VoteQuery) loadOption(ctx context.Context, query *PollOptionQuery, nod
 	}
 	return nil
 }
+func (_q *VoteQuery) loadVoter(ctx context.Context, query *UserQuery, nodes []*Vote, init func(*Vote), assign func(*Vote, *User)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Vote)
+	for i := range nodes {
+		if nodes[i].user_votes == nil {
+			continue
+		}
+		fk := *nodes[i].user_votes
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(user.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "user_votes" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
 
 func (_q *VoteQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
 	_spec.Node.Columns = _q.ctx.Fields
 	if len(_q.ctx.Fields) > 0 {
 		_spec.Unique = _q.ctx.Unique != nil && *_q.ctx.Unique
@@ -581,6 +664,9 @@ func (_q *VoteQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	if _q.ctx.Unique != nil && *_q.ctx.Unique {
 		selector.Distinct()
 	}
+	for _, m := range _q.modifiers {
+		m(selector)
+	}
 	for _, p := range _q.predicates {
 		p(selector)
 	}
@@ -598,6 +684,32 @@ func (_q *VoteQuery) sqlQuery(ctx context.Context) *sql.Selector {
 	return selector
 }
 
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (_q *VoteQuery) ForUpdate(opts ...sql.LockOption) *VoteQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return _q
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (_q *VoteQuery) ForShare(opts ...sql.LockOption) *VoteQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return _q
+}
+
 // VoteGroupBy is the group-by builder for Vote entities.
 type VoteGroupBy struct {
 	selector
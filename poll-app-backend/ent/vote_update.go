@@ -6,6 +6,7 @@ import (
 	"backend/ent/poll"
 	"backend/ent/polloption"
 	"backend/ent/predicate"
+	"backend/ent/user"
 	"backend/ent/vote"
 	"context"
 	"errors"
@@ -50,6 +51,60 @@ func (_u *VoteUpdate) ClearVoterIdentifier() *VoteUpdate {
 	return _u
 }
 
+// SetRank sets the "rank" field.
+func (_u *VoteUpdate) SetRank(v int) *VoteUpdate {
+	_u.mutation.ResetRank()
+	_u.mutation.SetRank(v)
+	return _u
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableRank(v *int) *VoteUpdate {
+	if v != nil {
+		_u.SetRank(*v)
+	}
+	return _u
+}
+
+// AddRank adds value to the "rank" field.
+func (_u *VoteUpdate) AddRank(v int) *VoteUpdate {
+	_u.mutation.AddRank(v)
+	return _u
+}
+
+// ClearRank clears the value of the "rank" field.
+func (_u *VoteUpdate) ClearRank() *VoteUpdate {
+	_u.mutation.ClearRank()
+	return _u
+}
+
+// SetScore sets the "score" field.
+func (_u *VoteUpdate) SetScore(v int) *VoteUpdate {
+	_u.mutation.ResetScore()
+	_u.mutation.SetScore(v)
+	return _u
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableScore(v *int) *VoteUpdate {
+	if v != nil {
+		_u.SetScore(*v)
+	}
+	return _u
+}
+
+// AddScore adds value to the "score" field.
+func (_u *VoteUpdate) AddScore(v int) *VoteUpdate {
+	_u.mutation.AddScore(v)
+	return _u
+}
+
+// ClearScore clears the value of the "score" field.
+func (_u *VoteUpdate) ClearScore() *VoteUpdate {
+	_u.mutation.ClearScore()
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *VoteUpdate) SetCreatedAt(v time.Time) *VoteUpdate {
 	_u.mutation.SetCreatedAt(v)
@@ -86,6 +141,25 @@ func (_u *VoteUpdate) SetOption(v *PollOption) *VoteUpdate {
 	return _u.SetOptionID(v.ID)
 }
 
+// SetVoterID sets the "voter" edge to the User entity by ID.
+func (_u *VoteUpdate) SetVoterID(id int) *VoteUpdate {
+	_u.mutation.SetVoterID(id)
+	return _u
+}
+
+// SetNillableVoterID sets the "voter" edge to the User entity by ID if the given value is not nil.
+func (_u *VoteUpdate) SetNillableVoterID(id *int) *VoteUpdate {
+	if id != nil {
+		_u = _u.SetVoterID(*id)
+	}
+	return _u
+}
+
+// SetVoter sets the "voter" edge to the User entity.
+func (_u *VoteUpdate) SetVoter(v *User) *VoteUpdate {
+	return _u.SetVoterID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_u *VoteUpdate) Mutation() *VoteMutation {
 	return _u.mutation
@@ -103,6 +177,12 @@ func (_u *VoteUpdate) ClearOption() *VoteUpdate {
 	return _u
 }
 
+// ClearVoter clears the "voter" edge to the User entity.
+func (_u *VoteUpdate) ClearVoter() *VoteUpdate {
+	_u.mutation.ClearVoter()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *VoteUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
@@ -132,6 +212,11 @@ func (_u *VoteUpdate) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdate) check() error {
+	if v, ok := _u.mutation.Rank(); ok {
+		if err := vote.RankValidator(v); err != nil {
+			return &ValidationError{Name: "rank", err: fmt.Errorf(`ent: validator failed for field "Vote.rank": %w`, err)}
+		}
+	}
 	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.poll"`)
 	}
@@ -159,6 +244,24 @@ func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.VoterIdentifierCleared() {
 		_spec.ClearField(vote.FieldVoterIdentifier, field.TypeString)
 	}
+	if value, ok := _u.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRank(); ok {
+		_spec.AddField(vote.FieldRank, field.TypeInt, value)
+	}
+	if _u.mutation.RankCleared() {
+		_spec.ClearField(vote.FieldRank, field.TypeInt)
+	}
+	if value, ok := _u.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if _u.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -220,6 +323,35 @@ func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.VoterCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.VoterTable,
+			Columns: []string{vote.VoterColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.VoterIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.VoterTable,
+			Columns: []string{vote.VoterColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{vote.Label}
@@ -260,6 +392,60 @@ func (_u *VoteUpdateOne) ClearVoterIdentifier() *VoteUpdateOne {
 	return _u
 }
 
+// SetRank sets the "rank" field.
+func (_u *VoteUpdateOne) SetRank(v int) *VoteUpdateOne {
+	_u.mutation.ResetRank()
+	_u.mutation.SetRank(v)
+	return _u
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableRank(v *int) *VoteUpdateOne {
+	if v != nil {
+		_u.SetRank(*v)
+	}
+	return _u
+}
+
+// AddRank adds value to the "rank" field.
+func (_u *VoteUpdateOne) AddRank(v int) *VoteUpdateOne {
+	_u.mutation.AddRank(v)
+	return _u
+}
+
+// ClearRank clears the value of the "rank" field.
+func (_u *VoteUpdateOne) ClearRank() *VoteUpdateOne {
+	_u.mutation.ClearRank()
+	return _u
+}
+
+// SetScore sets the "score" field.
+func (_u *VoteUpdateOne) SetScore(v int) *VoteUpdateOne {
+	_u.mutation.ResetScore()
+	_u.mutation.SetScore(v)
+	return _u
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableScore(v *int) *VoteUpdateOne {
+	if v != nil {
+		_u.SetScore(*v)
+	}
+	return _u
+}
+
+// AddScore adds value to the "score" field.
+func (_u *VoteUpdateOne) AddScore(v int) *VoteUpdateOne {
+	_u.mutation.AddScore(v)
+	return _u
+}
+
+// ClearScore clears the value of the "score" field.
+func (_u *VoteUpdateOne) ClearScore() *VoteUpdateOne {
+	_u.mutation.ClearScore()
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *VoteUpdateOne) SetCreatedAt(v time.Time) *VoteUpdateOne {
 	_u.mutation.SetCreatedAt(v)
@@ -296,6 +482,25 @@ func (_u *VoteUpdateOne) SetOption(v *PollOption) *VoteUpdateOne {
 	return _u.SetOptionID(v.ID)
 }
 
+// SetVoterID sets the "voter" edge to the User entity by ID.
+func (_u *VoteUpdateOne) SetVoterID(id int) *VoteUpdateOne {
+	_u.mutation.SetVoterID(id)
+	return _u
+}
+
+// SetNillableVoterID sets the "voter" edge to the User entity by ID if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableVoterID(id *int) *VoteUpdateOne {
+	if id != nil {
+		_u = _u.SetVoterID(*id)
+	}
+	return _u
+}
+
+// SetVoter sets the "voter" edge to the User entity.
+func (_u *VoteUpdateOne) SetVoter(v *User) *VoteUpdateOne {
+	return _u.SetVoterID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_u *VoteUpdateOne) Mutation() *VoteMutation {
 	return _u.mutation
@@ -313,6 +518,12 @@ func (_u *VoteUpdateOne) ClearOption() *VoteUpdateOne {
 	return _u
 }
 
+// ClearVoter clears the "voter" edge to the User entity.
+func (_u *VoteUpdateOne) ClearVoter() *VoteUpdateOne {
+	_u.mutation.ClearVoter()
+	return _u
+}
+
 // Where appends a list predicates to the VoteUpdate builder.
 func (_u *VoteUpdateOne) Where(ps ...predicate.Vote) *VoteUpdateOne {
 	_u.mutation.Where(ps...)
@@ -355,6 +566,11 @@ func (_u *VoteUpdateOne) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdateOne) check() error {
+	if v, ok := _u.mutation.Rank(); ok {
+		if err := vote.RankValidator(v); err != nil {
+			return &ValidationError{Name: "rank", err: fmt.Errorf(`ent: validator failed for field "Vote.rank": %w`, err)}
+		}
+	}
 	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.poll"`)
 	}
@@ -399,6 +615,24 @@ func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
 	if _u.mutation.VoterIdentifierCleared() {
 		_spec.ClearField(vote.FieldVoterIdentifier, field.TypeString)
 	}
+	if value, ok := _u.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedRank(); ok {
+		_spec.AddField(vote.FieldRank, field.TypeInt, value)
+	}
+	if _u.mutation.RankCleared() {
+		_spec.ClearField(vote.FieldRank, field.TypeInt)
+	}
+	if value, ok := _u.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if _u.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -460,6 +694,35 @@ func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.VoterCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.VoterTable,
+			Columns: []string{vote.VoterColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.VoterIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   vote.VoterTable,
+			Columns: []string{vote.VoterColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Vote{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -0,0 +1,222 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"backend/ent/user"
+	"backend/ent/webhook"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Webhook is the model entity for the Webhook schema.
+type Webhook struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Endpoint events are POSTed to
+	URL string `json:"url,omitempty"`
+	// Key used to HMAC-sign every delivery
+	Secret string `json:"-"`
+	// Event types this subscription receives
+	Events []string `json:"events,omitempty"`
+	// Inactive webhooks receive no new deliveries
+	Active bool `json:"active,omitempty"`
+	// Webhook creation timestamp
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Webhook last update timestamp
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WebhookQuery when eager-loading is set.
+	Edges         WebhookEdges `json:"edges"`
+	user_webhooks *int
+	selectValues  sql.SelectValues
+}
+
+// WebhookEdges holds the relations/edges for other nodes in the graph.
+type WebhookEdges struct {
+	// The user whose polls this webhook reports on
+	Owner *User `json:"owner,omitempty"`
+	// Delivery log of this webhook
+	Deliveries []*WebhookDelivery `json:"deliveries,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// OwnerOrErr returns the Owner value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e WebhookEdges) OwnerOrErr() (*User, error) {
+	if e.Owner != nil {
+		return e.Owner, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "owner"}
+}
+
+// DeliveriesOrErr returns the Deliveries value or an error if the edge
+// was not loaded in eager-loading.
+func (e WebhookEdges) DeliveriesOrErr() ([]*WebhookDelivery, error) {
+	if e.loadedTypes[1] {
+		return e.Deliveries, nil
+	}
+	return nil, &NotLoadedError{edge: "deliveries"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Webhook) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case webhook.FieldEvents:
+			values[i] = new([]byte)
+		case webhook.FieldActive:
+			values[i] = new(sql.NullBool)
+		case webhook.FieldID:
+			values[i] = new(sql.NullInt64)
+		case webhook.FieldURL, webhook.FieldSecret:
+			values[i] = new(sql.NullString)
+		case webhook.FieldCreatedAt, webhook.FieldUpdatedAt:
+			values[i] = new(sql.NullTime)
+		case webhook.ForeignKeys[0]: // user_webhooks
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Webhook fields.
+func (_m *Webhook) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case webhook.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int(value.Int64)
+		case webhook.FieldURL:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field url", values[i])
+			} else if value.Valid {
+				_m.URL = value.String
+			}
+		case webhook.FieldSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field secret", values[i])
+			} else if value.Valid {
+				_m.Secret = value.String
+			}
+		case webhook.FieldEvents:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field events", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Events); err != nil {
+					return fmt.Errorf("unmarshal field events: %w", err)
+				}
+			}
+		case webhook.FieldActive:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field active", values[i])
+			} else if value.Valid {
+				_m.Active = value.Bool
+			}
+		case webhook.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		case webhook.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				_m.UpdatedAt = value.Time
+			}
+		case webhook.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_webhooks", value)
+			} else if value.Valid {
+				_m.user_webhooks = new(int)
+				*_m.user_webhooks = int(value.Int64)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Webhook.
+// This includes values selected through modifiers, order, etc.
+func (_m *Webhook) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryOwner queries the "owner" edge of the Webhook entity.
+func (_m *Webhook) QueryOwner() *UserQuery {
+	return NewWebhookClient(_m.config).QueryOwner(_m)
+}
+
+// QueryDeliveries queries the "deliveries" edge of the Webhook entity.
+func (_m *Webhook) QueryDeliveries() *WebhookDeliveryQuery {
+	return NewWebhookClient(_m.config).QueryDeliveries(_m)
+}
+
+// Update returns a builder for updating this Webhook.
+// Note that you need to call Webhook.Unwrap() before calling this method if this Webhook
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Webhook) Update() *WebhookUpdateOne {
+	return NewWebhookClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Webhook entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Webhook) Unwrap() *Webhook {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Webhook is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Webhook) String() string {
+	var builder strings.Builder
+	builder.WriteString("Webhook(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("url=")
+	builder.WriteString(_m.URL)
+	builder.WriteString(", ")
+	builder.WriteString("secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("events=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Events))
+	builder.WriteString(", ")
+	builder.WriteString("active=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Active))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(_m.UpdatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Webhooks is a parsable slice of Webhook.
+type Webhooks []*Webhook
@@ -0,0 +1,10 @@
+-- reverse: create "votes" table
+DROP TABLE "votes";
+-- reverse: create "poll_options" table
+DROP TABLE "poll_options";
+-- reverse: create "polls" table
+DROP TABLE "polls";
+-- reverse: create index "users_email_key" to table: "users"
+DROP INDEX "users_email_key";
+-- reverse: create "users" table
+DROP TABLE "users";
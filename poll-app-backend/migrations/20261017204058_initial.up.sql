@@ -0,0 +1,10 @@
+-- create "users" table
+CREATE TABLE "users" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "email" character varying NOT NULL, "password" character varying NOT NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
+-- create "polls" table
+CREATE TABLE "polls" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "title" character varying NOT NULL, "description" character varying NULL, "poll_type" character varying NOT NULL DEFAULT 'single_choice', "created_by" character varying NULL, "max_votes_per_user" bigint NOT NULL DEFAULT 1, "expires_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create "poll_options" table
+CREATE TABLE "poll_options" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "option_text" character varying NOT NULL, "vote_count" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, "poll_options" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "poll_options_polls_options" FOREIGN KEY ("poll_options") REFERENCES "polls" ("id") ON DELETE NO ACTION);
+-- create "votes" table
+CREATE TABLE "votes" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "voter_identifier" character varying NULL, "created_at" timestamptz NOT NULL, "poll_votes" bigint NOT NULL, "poll_option_votes" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "votes_polls_votes" FOREIGN KEY ("poll_votes") REFERENCES "polls" ("id") ON DELETE NO ACTION, CONSTRAINT "votes_poll_options_votes" FOREIGN KEY ("poll_option_votes") REFERENCES "poll_options" ("id") ON DELETE NO ACTION);
@@ -0,0 +1,2 @@
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "updated_at", DROP COLUMN "avatar_url", DROP COLUMN "display_name";
@@ -0,0 +1,6 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "display_name" character varying NULL, ADD COLUMN "avatar_url" character varying NULL, ADD COLUMN "updated_at" timestamptz NULL;
+-- fill "updated_at" of existing users
+UPDATE "users" SET "updated_at" = "created_at";
+-- modify "users" table
+ALTER TABLE "users" ALTER COLUMN "updated_at" SET NOT NULL;
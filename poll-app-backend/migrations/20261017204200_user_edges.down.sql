@@ -0,0 +1,4 @@
+-- reverse: modify "votes" table
+ALTER TABLE "votes" DROP CONSTRAINT "votes_users_votes", DROP COLUMN "user_votes";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP CONSTRAINT "polls_users_polls", DROP COLUMN "user_polls";
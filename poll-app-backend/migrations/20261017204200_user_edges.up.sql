@@ -0,0 +1,4 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "user_polls" bigint NULL, ADD CONSTRAINT "polls_users_polls" FOREIGN KEY ("user_polls") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- modify "votes" table
+ALTER TABLE "votes" ADD COLUMN "user_votes" bigint NULL, ADD CONSTRAINT "votes_users_votes" FOREIGN KEY ("user_votes") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
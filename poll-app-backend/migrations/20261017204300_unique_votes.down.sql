@@ -0,0 +1,3 @@
+-- reverse: create index "vote_voter_identifier_poll_votes_poll_option_votes" to table: "votes"
+DROP INDEX "vote_voter_identifier_poll_votes_poll_option_votes";
+-- Duplicate votes dropped on the way up are not restored.
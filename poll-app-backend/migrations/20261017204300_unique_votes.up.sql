@@ -0,0 +1,4 @@
+-- drop votes repeating an earlier vote by the same voter for the same option, and take them off the option's count
+WITH "dropped" AS (DELETE FROM "votes" v USING "votes" e WHERE v."voter_identifier" = e."voter_identifier" AND v."poll_votes" = e."poll_votes" AND v."poll_option_votes" = e."poll_option_votes" AND v."id" > e."id" RETURNING v."poll_option_votes") UPDATE "poll_options" o SET "vote_count" = GREATEST(o."vote_count" - d."n", 0) FROM (SELECT "poll_option_votes", count(*) AS "n" FROM "dropped" GROUP BY "poll_option_votes") d WHERE o."id" = d."poll_option_votes";
+-- create index "vote_voter_identifier_poll_votes_poll_option_votes" to table: "votes"
+CREATE UNIQUE INDEX "vote_voter_identifier_poll_votes_poll_option_votes" ON "votes" ("voter_identifier", "poll_votes", "poll_option_votes");
@@ -0,0 +1,2 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "allow_vote_changes" boolean NOT NULL DEFAULT true;
@@ -0,0 +1,2 @@
+-- reverse: modify "votes" table
+ALTER TABLE "votes" DROP COLUMN "rank";
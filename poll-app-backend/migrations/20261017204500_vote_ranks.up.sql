@@ -0,0 +1,2 @@
+-- modify "votes" table
+ALTER TABLE "votes" ADD COLUMN "rank" bigint NULL;
@@ -0,0 +1,4 @@
+-- reverse: modify "votes" table
+ALTER TABLE "votes" DROP COLUMN "score";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "rating_max", DROP COLUMN "rating_min";
@@ -0,0 +1,4 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "rating_min" bigint NULL, ADD COLUMN "rating_max" bigint NULL;
+-- modify "votes" table
+ALTER TABLE "votes" ADD COLUMN "score" bigint NULL;
@@ -0,0 +1,8 @@
+-- reverse: create index "poll_expires_at_id" to table: "polls"
+DROP INDEX "poll_expires_at_id";
+-- reverse: create index "poll_total_votes_id" to table: "polls"
+DROP INDEX "poll_total_votes_id";
+-- reverse: create index "poll_created_at_id" to table: "polls"
+DROP INDEX "poll_created_at_id";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "total_votes";
@@ -0,0 +1,8 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "total_votes" bigint NOT NULL DEFAULT 0;
+-- create index "poll_created_at_id" to table: "polls"
+CREATE INDEX "poll_created_at_id" ON "polls" ("created_at", "id");
+-- create index "poll_total_votes_id" to table: "polls"
+CREATE INDEX "poll_total_votes_id" ON "polls" ("total_votes", "id");
+-- create index "poll_expires_at_id" to table: "polls"
+CREATE INDEX "poll_expires_at_id" ON "polls" ("expires_at", "id");
@@ -0,0 +1,4 @@
+-- reverse: create index "polls_search_idx" to table: "polls"
+DROP INDEX "polls_search_idx";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "search_text";
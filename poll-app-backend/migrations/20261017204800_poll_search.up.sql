@@ -0,0 +1,4 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "search_text" text NULL;
+-- create index "polls_search_idx" to table: "polls"
+CREATE INDEX "polls_search_idx" ON "polls" USING GIN ((setweight(to_tsvector('english', coalesce(title, '')), 'A') || setweight(to_tsvector('english', coalesce(description, '')), 'B') || setweight(to_tsvector('english', coalesce(search_text, '')), 'C')));
@@ -0,0 +1,6 @@
+-- create "webhooks" table
+CREATE TABLE "webhooks" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "url" character varying NOT NULL, "secret" character varying NOT NULL, "events" jsonb NOT NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "user_webhooks" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "webhooks_users_webhooks" FOREIGN KEY ("user_webhooks") REFERENCES "users" ("id") ON DELETE CASCADE);
+-- create "webhook_deliveries" table
+CREATE TABLE "webhook_deliveries" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "event" character varying NOT NULL, "payload" text NOT NULL, "status" character varying NOT NULL DEFAULT 'pending', "attempts" bigint NOT NULL DEFAULT 0, "response_status" bigint NULL, "last_error" character varying NULL, "next_attempt_at" timestamptz NULL, "delivered_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "webhook_deliveries" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "webhook_deliveries_webhooks_deliveries" FOREIGN KEY ("webhook_deliveries") REFERENCES "webhooks" ("id") ON DELETE CASCADE);
+-- create index "webhookdelivery_status_next_attempt_at" to table: "webhook_deliveries"
+CREATE INDEX "webhookdelivery_status_next_attempt_at" ON "webhook_deliveries" ("status", "next_attempt_at");
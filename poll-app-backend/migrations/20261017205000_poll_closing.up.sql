@@ -0,0 +1,2 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "closed_at" timestamptz NULL;
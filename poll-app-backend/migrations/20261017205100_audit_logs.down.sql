@@ -0,0 +1,8 @@
+-- reverse: create index "auditlog_action" to table: "audit_logs"
+DROP INDEX "auditlog_action";
+-- reverse: create index "auditlog_target_type_target_id" to table: "audit_logs"
+DROP INDEX "auditlog_target_type_target_id";
+-- reverse: create "audit_logs" table
+DROP TABLE "audit_logs";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "disabled_at";
@@ -0,0 +1,8 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "disabled_at" timestamptz NULL;
+-- create "audit_logs" table
+CREATE TABLE "audit_logs" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "action" character varying NOT NULL, "actor_email" character varying NOT NULL, "target_type" character varying NOT NULL, "target_id" bigint NOT NULL, "details" jsonb NULL, "created_at" timestamptz NOT NULL, "user_audit_logs" bigint NULL, PRIMARY KEY ("id"), CONSTRAINT "audit_logs_users_audit_logs" FOREIGN KEY ("user_audit_logs") REFERENCES "users" ("id") ON DELETE SET NULL);
+-- create index "auditlog_target_type_target_id" to table: "audit_logs"
+CREATE INDEX "auditlog_target_type_target_id" ON "audit_logs" ("target_type", "target_id");
+-- create index "auditlog_action" to table: "audit_logs"
+CREATE INDEX "auditlog_action" ON "audit_logs" ("action");
@@ -0,0 +1,4 @@
+-- reverse: create "poll_invites" table
+DROP TABLE "poll_invites";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "invited_emails", DROP COLUMN "visibility";
@@ -0,0 +1,4 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "visibility" character varying NOT NULL DEFAULT 'public', ADD COLUMN "invited_emails" jsonb NULL;
+-- create "poll_invites" table
+CREATE TABLE "poll_invites" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "label" character varying NULL, "expires_at" timestamptz NULL, "revoked_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "poll_invites" bigint NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "poll_invites_polls_invites" FOREIGN KEY ("poll_invites") REFERENCES "polls" ("id") ON DELETE CASCADE);
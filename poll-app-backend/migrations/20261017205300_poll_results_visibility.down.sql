@@ -0,0 +1,2 @@
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "results_visibility";
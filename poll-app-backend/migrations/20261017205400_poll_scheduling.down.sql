@@ -0,0 +1,6 @@
+-- reverse: create index "poll_state_expires_at" to table: "polls"
+DROP INDEX "poll_state_expires_at";
+-- reverse: create index "poll_state_opens_at" to table: "polls"
+DROP INDEX "poll_state_opens_at";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "state", DROP COLUMN "opens_at";
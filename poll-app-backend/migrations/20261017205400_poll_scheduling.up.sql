@@ -0,0 +1,6 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "opens_at" timestamptz NULL, ADD COLUMN "state" character varying NOT NULL DEFAULT 'open';
+-- create index "poll_state_opens_at" to table: "polls"
+CREATE INDEX "poll_state_opens_at" ON "polls" ("state", "opens_at");
+-- create index "poll_state_expires_at" to table: "polls"
+CREATE INDEX "poll_state_expires_at" ON "polls" ("state", "expires_at");
@@ -0,0 +1,4 @@
+-- reverse: create index "poll_state_next_occurrence_at" to table: "polls"
+DROP INDEX "poll_state_next_occurrence_at";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP CONSTRAINT "polls_polls_occurrences", DROP COLUMN "poll_occurrences", DROP COLUMN "next_occurrence_at", DROP COLUMN "recurrence_timezone", DROP COLUMN "recurrence";
@@ -0,0 +1,4 @@
+-- modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "recurrence" character varying NULL, ADD COLUMN "recurrence_timezone" character varying NULL, ADD COLUMN "next_occurrence_at" timestamptz NULL, ADD COLUMN "poll_occurrences" bigint NULL, ADD CONSTRAINT "polls_polls_occurrences" FOREIGN KEY ("poll_occurrences") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- create index "poll_state_next_occurrence_at" to table: "polls"
+CREATE INDEX "poll_state_next_occurrence_at" ON "polls" ("state", "next_occurrence_at");
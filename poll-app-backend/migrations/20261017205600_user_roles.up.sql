@@ -0,0 +1,2 @@
+-- modify "users" table
+ALTER TABLE "users" ADD COLUMN "role" character varying NOT NULL DEFAULT 'voter', ADD CONSTRAINT "users_role_check" CHECK (role IN ('admin', 'creator', 'voter'));
@@ -0,0 +1,2 @@
+-- reverse: link polls and votes to users
+-- Nothing to undo: the links are kept, as the application maintains them itself.
@@ -0,0 +1,4 @@
+-- link polls to the users whose email created them
+UPDATE "polls" p SET "user_polls" = u."id" FROM "users" u WHERE lower(p."created_by") = lower(u."email") AND p."user_polls" IS NULL;
+-- link votes to the users whose email cast them
+UPDATE "votes" v SET "user_votes" = u."id" FROM "users" u WHERE lower(v."voter_identifier") = lower(u."email") AND v."user_votes" IS NULL;
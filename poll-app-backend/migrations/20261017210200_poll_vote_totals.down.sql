@@ -0,0 +1,2 @@
+-- reverse: fill "total_votes" of polls
+-- Nothing to undo: the totals are kept, as the application maintains them itself.
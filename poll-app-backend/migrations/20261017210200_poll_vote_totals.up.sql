@@ -0,0 +1,2 @@
+-- fill "total_votes" of polls from their options' vote counts
+UPDATE "polls" SET "total_votes" = COALESCE((SELECT SUM("vote_count") FROM "poll_options" WHERE "poll_options"."poll_options" = "polls"."id"), 0);
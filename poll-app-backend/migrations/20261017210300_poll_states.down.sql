@@ -0,0 +1,2 @@
+-- reverse: mark closed polls as closed
+-- Nothing to undo: the states are kept, as the application maintains them itself.
@@ -0,0 +1,2 @@
+-- mark polls closed before "state" existed as closed
+UPDATE "polls" SET "state" = 'closed' WHERE "closed_at" IS NOT NULL AND "state" <> 'closed';
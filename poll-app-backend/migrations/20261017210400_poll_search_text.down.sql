@@ -0,0 +1,2 @@
+-- reverse: fill "search_text" of polls
+-- Nothing to undo: the search text is kept, as the application maintains it itself.
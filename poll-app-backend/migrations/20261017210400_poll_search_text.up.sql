@@ -0,0 +1,2 @@
+-- fill "search_text" of polls with their option texts, one per line
+UPDATE "polls" SET "search_text" = COALESCE((SELECT string_agg("option_text", E'\n' ORDER BY "id") FROM "poll_options" WHERE "poll_options"."poll_options" = "polls"."id"), '') WHERE "search_text" IS NULL;
@@ -0,0 +1,2 @@
+-- reverse: make existing users creators
+-- Nothing to undo: the roles are kept, as admins manage them from here on.
@@ -0,0 +1,2 @@
+-- let users who signed up before roles existed keep creating polls; new accounts start as voters
+UPDATE "users" SET "role" = 'creator' WHERE "role" = 'voter';
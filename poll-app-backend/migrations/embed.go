@@ -0,0 +1,13 @@
+// Package migrations holds the versioned SQL migrations of the database
+// schema, generated from the ent schema with "api migrate diff". Each
+// version is a pair of files, <version>_<name>.up.sql and .down.sql;
+// atlas.sum guards them against accidental edits.
+package migrations
+
+import "embed"
+
+// Files holds every migration file, compiled into the binary so the server
+// can check the database is up to date and "api migrate" can apply them
+//
+//go:embed *.sql
+var Files embed.FS
@@ -36,13 +36,14 @@ const Login = () => {
             const result = await response.json();
 
             if (response.ok && !result.error) {
-                // Login successful
-                if(result.data.email === "admin@example.com"){
+                // Login successful - keep the session token with the user
+                const loggedInUser = { ...result.data.user, token: result.data.token };
+                if(loggedInUser.email === "admin@example.com"){
                     setAdmin(true);
                 }
                 setAlertClassName("alert-success-login");
                 setAlertMessage("Login successful!");
-                setUser(result.data); // Store the full user object
+                setUser(loggedInUser); // Store the user object and token
                 
                 // Auto-clear success message after 3 seconds (before navigation)
                 setTimeout(() => {
@@ -51,7 +52,7 @@ const Login = () => {
                 }, 3000);
                 
                 // Navigate all users to Vote on Polls page after login
-                if(result.data.email === "admin@example.com"){
+                if(loggedInUser.email === "admin@example.com"){
                     navigate("/MakePolls/0");
                 }else{
                     navigate("/VoteOnPolls/0");
@@ -122,7 +122,6 @@ const MakePolls = () => {
             
             const pollPayload = {
                 ...pollData,  // Include all form fields
-                created_by: user.email || "anonymous",  // Use logged-in user or 'anonymous'
                 options: validOptions.map(opt => opt.trim()),  // Clean up option text
                 // Convert date to server format (ISO string) or null if not provided
                 expires_at: pollData.expires_at ? new Date(pollData.expires_at).toISOString() : null
@@ -133,6 +132,7 @@ const MakePolls = () => {
                 method: 'POST',
                 headers: {
                     'Content-Type': 'application/json',  // Tell server we're sending JSON
+                    'Authorization': `Bearer ${user.token}`,  // Server takes the creator from the token
                 },
                 body: JSON.stringify(pollPayload)  // Convert data to JSON string
             });
@@ -37,6 +37,36 @@ const PieChart = () => {
         };
 
         fetchPollData();
+
+        // Keep the counts live: the backend pushes a "results" event
+        // every time someone votes on this poll
+        const stream = new EventSource(`http://localhost:8080/poll/${id}/stream`);
+        stream.addEventListener("results", (event) => {
+            const update = JSON.parse(event.data);
+            const votesByOption = {};
+            update.options.forEach(option => {
+                votesByOption[option.option_id] = option.votes;
+            });
+
+            setPollData(current => {
+                if (!current) {
+                    return current;
+                }
+                return {
+                    ...current,
+                    edges: {
+                        ...current.edges,
+                        options: current.edges.options.map(option => ({
+                            ...option,
+                            vote_count: votesByOption[option.id] ?? option.vote_count,
+                        })),
+                    },
+                };
+            });
+        });
+
+        // EventSource reconnects by itself after network errors
+        return () => stream.close();
     }, [id]);
 
     // Show loading spinner while fetching
@@ -73,6 +103,19 @@ const PieChart = () => {
         );
     }
 
+    // The poll's creator chose to hide the counts from us for now
+    if (pollData.results_hidden) {
+        return (
+            <div className="text-center">
+                <h2>{pollData.title}</h2>
+                <hr />
+                <div className="alert alert-info" role="alert">
+                    Results for this poll aren't visible yet.
+                </div>
+            </div>
+        );
+    }
+
     // Calculate total votes for percentage calculations
     const totalVotes = pollData.edges.options.reduce((sum, option) => sum + (option.vote_count || 0), 0);
 
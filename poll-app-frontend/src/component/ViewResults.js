@@ -6,13 +6,13 @@ const ViewResults = () => {
     useEffect(() => {
         const headers = new Headers();
         headers.append("Content-Type", "application/json");
-        fetch("http://localhost:8080/polls", {
+        fetch("http://localhost:8080/polls?limit=100", {
             method: "GET",
             headers: headers,
         })
             .then((response) => response.json())
             .then((data) => {
-                setPollResults(data);
+                setPollResults(data.polls);
             })
             .catch((error) => {
                 console.error("Error fetching poll results:", error);
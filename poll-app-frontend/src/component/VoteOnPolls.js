@@ -29,13 +29,13 @@ const VoteOnPolls = () => {
             setIsLoadingPolls(true);
             
             // Get all polls with their options
-            const response = await fetch('http://localhost:8080/polls');
+            const response = await fetch('http://localhost:8080/polls?status=open&limit=100');
             const result = await response.json();
             
             if (response.ok) {
                 // ✅ SUCCESS: Store polls and initialize empty vote tracking
-                setPolls(result);
-                initializeUserVotes(result);
+                setPolls(result.polls);
+                initializeUserVotes(result.polls);
             } else {
                 // ❌ SERVER ERROR: Show error message
                 setAlertClassName('alert-danger');
@@ -74,12 +74,12 @@ const VoteOnPolls = () => {
     const refreshPollCounts = async () => {
         try {
             // Get updated polls with new vote counts
-            const response = await fetch('http://localhost:8080/polls');
+            const response = await fetch('http://localhost:8080/polls?status=open&limit=100');
             const result = await response.json();
             
             if (response.ok) {
                 // ✅ UPDATE POLLS: Only update poll data, keep vote tracking intact
-                setPolls(result);
+                setPolls(result.polls);
                 // Note: We DON'T call initializeUserVotes here to preserve vote state
             }
         } catch (error) {
@@ -147,16 +147,19 @@ const VoteOnPolls = () => {
             // 📦 PREPARE VOTE DATA: Format for backend
             const voteData = {
                 poll_id: pollId,
-                option_ids: selectedOptions,
-                voter_identifier: user?.email || "anonymous"
+                option_ids: selectedOptions
             };
 
-            // 📡 SEND VOTE TO BACKEND
+            // 📡 SEND VOTE TO BACKEND: Signed-out voters are recognized by the
+            // cookie the backend sets, so it has to travel with the request
+            const headers = { 'Content-Type': 'application/json' };
+            if (user?.token) {
+                headers['Authorization'] = `Bearer ${user.token}`;
+            }
             const response = await fetch('http://localhost:8080/vote', {
                 method: 'POST',
-                headers: {
-                    'Content-Type': 'application/json',
-                },
+                headers,
+                credentials: 'include',
                 body: JSON.stringify(voteData)
             });
 